@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// arenasCmd represents the arenas command
+var arenasCmd = &cobra.Command{
+	Use:   "arenas",
+	Short: "List the broker's arenas",
+	Long: `List the arenas run by this Erebus instance, along with the timestep and
+state of each one's simulation. Every other command operates a single arena,
+chosen with --arena.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		res, err := client.GetArenas(context.Background(), &pb.Null{})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error getting arenas")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ARENA\tTIMESTEP\tSTATE")
+		for _, arena := range res.GetArenas() {
+			name := arena.GetName()
+			if name == "" {
+				name = "[unnamed]"
+			}
+			fmt.Fprintf(w, "%s\t%dms\t%s\n", name, arena.GetTimestep(),
+				strings.ToLower(arena.GetSimState().GetState().String()))
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(arenasCmd)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// certsCaCmd represents the certs ca command
+var certsCaCmd = &cobra.Command{
+	Use:   "ca",
+	Short: "Generate a self-signed CA",
+	Long: `Generate a self-signed CA as ca.pem and ca-key.pem, which signs the
+certificates of the broker and the teams. Give ca.pem to the broker as
+-tls-client-ca, and to every team to verify the broker with.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := func() error {
+			template, err := newCertTemplate("Erebus CA")
+			if err != nil {
+				return err
+			}
+			template.IsCA = true
+			template.BasicConstraintsValid = true
+			template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				return err
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+			if err != nil {
+				return err
+			}
+			return writeCert("ca", der, key)
+		}()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error generating CA")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	certsCmd.AddCommand(certsCaCmd)
+}
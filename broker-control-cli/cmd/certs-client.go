@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// certsClientCmd represents the certs client command
+var certsClientCmd = &cobra.Command{
+	Use:   "client NAME...",
+	Short: "Generate client certificates",
+	Long: `Generate a certificate signed by the CA for each NAME, as NAME.pem and
+NAME-key.pem. The broker only accepts a client certificate for client
+sessions named after it, so NAME should be a team name; certificates for
+Webots controllers and this CLI can have any other name.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, name := range args {
+			err := func() error {
+				template, err := newCertTemplate(name)
+				if err != nil {
+					return err
+				}
+				template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+				return issueCert(name, template)
+			}()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error generating certificate for \"%s\"\n", name)
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	certsCmd.AddCommand(certsClientCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// certsServerCmd represents the certs server command
+var certsServerCmd = &cobra.Command{
+	Use:   "server HOST...",
+	Short: "Generate the broker's certificate",
+	Long: `Generate the broker's certificate as server.pem and server-key.pem,
+signed by the CA. Each HOST is a hostname or IP address clients reach the
+broker at. Give them to the broker as -tls-cert and -tls-key.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := func() error {
+			template, err := newCertTemplate(args[0])
+			if err != nil {
+				return err
+			}
+			template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+			for _, host := range args {
+				if ip := net.ParseIP(host); ip != nil {
+					template.IPAddresses = append(template.IPAddresses, ip)
+				} else {
+					template.DNSNames = append(template.DNSNames, host)
+				}
+			}
+			return issueCert("server", template)
+		}()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error generating server certificate")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	certsCmd.AddCommand(certsServerCmd)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var certsDir string
+var certsDays int
+
+// certsCmd represents the certs command
+var certsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Generate TLS certificates",
+	Long: `Generate a self-signed CA, and certificates signed by it for the broker
+and for each team's client. Certificates are written to the certificate
+directory as NAME.pem, with their private keys in NAME-key.pem.
+
+This command works locally, and does not connect to a broker.`,
+}
+
+// certFile returns the paths of the certificate and key named name
+func certFile(name string) (string, string) {
+	return filepath.Join(certsDir, name+".pem"), filepath.Join(certsDir, name+"-key.pem")
+}
+
+// writeCert writes a certificate and its private key to the certificate
+// directory, refusing to overwrite existing files
+func writeCert(name string, certDER []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(certsDir, 0755); err != nil {
+		return err
+	}
+	certPath, keyPath := certFile(name)
+	for _, path := range []string{certPath, keyPath} {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0644)
+}
+
+// loadCA reads the CA certificate and key from the certificate directory
+func loadCA() (*x509.Certificate, crypto.Signer, error) {
+	certPath, keyPath := certFile("ca")
+	certPEM, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, errors.New("CA certificate or key is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+// newCertTemplate returns a template for a certificate valid from now for
+// --days days
+func newCertTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Erebus"}, CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.AddDate(0, 0, certsDays),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, nil
+}
+
+// issueCert signs a new certificate made from template with the CA, and writes
+// it as name
+func issueCert(name string, template *x509.Certificate) error {
+	caCert, caKey, err := loadCA()
+	if err != nil {
+		return err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return err
+	}
+	return writeCert(name, der, key)
+}
+
+func init() {
+	rootCmd.AddCommand(certsCmd)
+
+	certsCmd.PersistentFlags().StringVarP(&certsDir, "dir", "d", "certs", "certificate directory")
+	certsCmd.PersistentFlags().IntVar(&certsDays, "days", 365, "number of days certificates are valid for")
+}
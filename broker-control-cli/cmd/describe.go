@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// describeCmd represents the describe command
+var describeCmd = &cobra.Command{
+	Use:   "describe robot NAME",
+	Short: "Describe an object (robot)",
+	Long: `Describe an object connected to this Erebus instance. For a robot, this lists
+the sensors, motors and LEDs it declared when it connected.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires an object type")
+		}
+		if !strings.HasPrefix(args[0], "robot") {
+			return fmt.Errorf("invalid object type \"%s\"", args[0])
+		}
+		if len(args) != 2 {
+			return errors.New("requires exactly one object name")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		res, err := client.DescribeRobot(context.Background(), &pb.ControlMessage_DescribeRobotRequest{RobotName: args[1]})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error describing robot")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_DescribeRobotResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error describing robot")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_DescribeRobotResponse_Ok_:
+			info := res.GetOk().GetRobotInfo()
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "SENSOR\tTYPE")
+			for _, sensor := range info.GetSensorInfos() {
+				fmt.Fprintf(w, "%s\t%s\n", sensor.GetName(), sensor.GetType())
+			}
+			fmt.Fprintln(w, "\t")
+			fmt.Fprintln(w, "MOTOR\tMAX VELOCITY")
+			for _, motor := range info.GetMotorInfos() {
+				fmt.Fprintf(w, "%s\t%g\n", motor.GetName(), motor.GetMaxVelocity())
+			}
+			fmt.Fprintln(w, "\t")
+			fmt.Fprintln(w, "LED\t")
+			for _, led := range info.GetLedInfos() {
+				fmt.Fprintf(w, "%s\t\n", led.GetName())
+			}
+			w.Flush()
+		default:
+			fmt.Fprintln(os.Stderr, "Error describing robot")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Tail broker events",
+	Long: `Get streaming updates of robots and clients registering and
+unregistering, connections being bound and unbound, and changes to the
+simulation state in the running Erebus instance`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		stream, err := client.SubscribeEvents(context.Background(), &pb.Null{})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error subscribing to events")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return
+				} else {
+					fmt.Fprintln(os.Stderr, "Error subscribing to events")
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+			}
+			fmt.Println(formatEvent(res))
+		}
+	},
+}
+
+func formatEvent(event *pb.ControlMessage_BrokerEvent) string {
+	eventTime := "[unknown]"
+	if t, err := ptypes.Timestamp(event.GetTime()); err == nil {
+		eventTime = t.Local().Format(time.RFC3339)
+	}
+	eventType := strings.ToLower(strings.ReplaceAll(event.GetEventType().String(), "_", "-"))
+	fields := []string{eventTime, eventType}
+	if event.GetRobotName() != "" {
+		fields = append(fields, "robot="+event.GetRobotName())
+	}
+	if event.GetClientName() != "" {
+		fields = append(fields, "client="+event.GetClientName())
+	}
+	if event.GetSimState() != nil {
+		fields = append(fields, "state="+strings.ToLower(event.GetSimState().GetState().String()))
+	}
+	return strings.Join(fields, " ")
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+}
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/cobra"
 
 	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
@@ -14,10 +17,10 @@ import (
 
 // getCmd represents the get command
 var listCmd = &cobra.Command{
-	Use:   "list (robot|client)",
-	Short: "List objects (robots and clients)",
+	Use:   "list (robot|client|connection)",
+	Short: "List objects (robots, clients and connections)",
 	Long: `List objects (robots and clients) that are currently connected to this
-Erebus instance`,
+Erebus instance, or the connections currently binding clients to robots`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
 			return errors.New("requires an object type")
@@ -25,7 +28,8 @@ Erebus instance`,
 		if len(args) > 1 {
 			return errors.New("too many arguments received; expected 1")
 		}
-		if strings.HasPrefix(args[0], "robot") || strings.HasPrefix(args[0], "client") {
+		if strings.HasPrefix(args[0], "robot") || strings.HasPrefix(args[0], "client") ||
+			strings.HasPrefix(args[0], "connection") {
 			return nil
 		}
 
@@ -55,6 +59,26 @@ Erebus instance`,
 				fmt.Println(client)
 			}
 		}
+		if strings.HasPrefix(args[0], "connection") {
+			conns, err := client.GetConnections(context.Background(), &pb.Null{})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error getting connections")
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "CLIENT\tROBOT\tSYNC\tBOUND SINCE\tREJECTED")
+			for _, conn := range conns.GetConnections() {
+				boundSince := "[unknown]"
+				if t, err := ptypes.Timestamp(conn.GetBoundSince()); err == nil {
+					boundSince = t.Local().Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%d\n",
+					conn.GetClientName(), conn.GetRobotName(), conn.GetIsSync(), boundSince,
+					conn.GetRejectedCommands())
+			}
+			w.Flush()
+		}
 	},
 }
 
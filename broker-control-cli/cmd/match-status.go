@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// matchStatusCmd represents the match status command
+var matchStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the match time remaining",
+	Long: `Show whether the match clock of the running Erebus instance is running,
+how much time has elapsed, and how much remains. The clock counts simulation
+time from the robots' sensor frames, or wall time while no robot is bound.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		status, err := client.GetMatchStatus(context.Background(), &pb.Null{})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error getting match status")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		state := "paused"
+		if status.GetRunning() {
+			state = "running"
+		} else if status.GetExpired() {
+			state = "time up"
+		}
+		clock := "wall time"
+		if status.GetSimTimed() {
+			clock = "sim time"
+		}
+		if status.GetDuration() == 0 {
+			fmt.Printf("%s: %s elapsed, no time limit (%s)\n",
+				state, formatClock(status.GetElapsed()), clock)
+			return
+		}
+		fmt.Printf("%s: %s remaining, %s of %s elapsed (%s)\n",
+			state,
+			formatClock(status.GetRemaining()),
+			formatClock(status.GetElapsed()),
+			formatClock(status.GetDuration()),
+			clock,
+		)
+	},
+}
+
+// formatClock formats seconds to a tenth of a second
+func formatClock(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(100 * time.Millisecond).String()
+}
+
+func init() {
+	matchCmd.AddCommand(matchStatusCmd)
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// matchCmd represents the match command
+var matchCmd = &cobra.Command{
+	Use:   "match",
+	Short: "Inspect the match clock",
+	Long: `Inspect the match clock of the running Erebus instance, which counts the
+time the simulation has been started for since it was last reset, and stops
+the simulation once the broker's match duration is up.`,
+}
+
+func init() {
+	rootCmd.AddCommand(matchCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// pairAddCmd represents the pair add command
+var pairAddCmd = &cobra.Command{
+	Use:   "add RULE...",
+	Short: "Add auto-pairing rules",
+	Long: `Add rules of the form CLIENT=ROBOT after the existing auto-pairing rules of
+the running Erebus instance. Clients and robots which are already waiting are
+paired straight away.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		for _, rule := range args {
+			res, err := client.AddPairingRule(context.Background(), &pb.ControlMessage_AddPairingRuleRequest{Rule: rule})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error adding pairing rule \"%s\"\n", rule)
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			switch res.Data.(type) {
+			case *pb.ControlMessage_AddPairingRuleResponse_Error:
+				fmt.Fprintf(os.Stderr, "Error adding pairing rule \"%s\"\n", rule)
+				fmt.Fprintln(os.Stderr, res.GetError())
+				os.Exit(1)
+			case *pb.ControlMessage_AddPairingRuleResponse_Ok_:
+			default:
+				fmt.Fprintf(os.Stderr, "Error adding pairing rule \"%s\"\n", rule)
+				fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	pairCmd.AddCommand(pairAddCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// pairListCmd represents the pair list command
+var pairListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List auto-pairing rules",
+	Long: `List the auto-pairing rules of the running Erebus instance in the order
+they are tried, with the connections each rule made which are still bound
+and the clients waiting for a robot matching it.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		res, err := client.GetPairingRules(context.Background(), &pb.Null{})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error getting pairing rules")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "RULE\tPAIRED\tWAITING")
+		for _, rule := range res.GetRules() {
+			pairs := make([]string, 0, len(rule.GetPairs()))
+			for _, conn := range rule.GetPairs() {
+				pairs = append(pairs, conn.GetClientName()+"="+conn.GetRobotName())
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n",
+				rule.GetRule(), listOrNone(pairs), listOrNone(rule.GetWaitingClients()))
+		}
+		w.Flush()
+	},
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ",")
+}
+
+func init() {
+	pairCmd.AddCommand(pairListCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// pairRemoveCmd represents the pair remove command
+var pairRemoveCmd = &cobra.Command{
+	Use:   "remove RULE...",
+	Short: "Remove auto-pairing rules",
+	Long: `Remove auto-pairing rules from the running Erebus instance, given exactly
+as listed by pair list. Connections the rules made are left bound.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		for _, rule := range args {
+			res, err := client.RemovePairingRule(context.Background(), &pb.ControlMessage_RemovePairingRuleRequest{Rule: rule})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error removing pairing rule \"%s\"\n", rule)
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			switch res.Data.(type) {
+			case *pb.ControlMessage_RemovePairingRuleResponse_Error:
+				fmt.Fprintf(os.Stderr, "Error removing pairing rule \"%s\"\n", rule)
+				fmt.Fprintln(os.Stderr, res.GetError())
+				os.Exit(1)
+			case *pb.ControlMessage_RemovePairingRuleResponse_Ok_:
+			default:
+				fmt.Fprintf(os.Stderr, "Error removing pairing rule \"%s\"\n", rule)
+				fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	pairCmd.AddCommand(pairRemoveCmd)
+}
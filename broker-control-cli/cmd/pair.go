@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// pairCmd represents the pair command
+var pairCmd = &cobra.Command{
+	Use:   "pair",
+	Short: "Manage auto-pairing rules",
+	Long: `Manage the rules the running Erebus instance follows to connect clients to
+robots automatically.
+
+A rule has the form CLIENT=ROBOT, and connects every client whose name
+matches CLIENT to the first free robot whose name matches ROBOT, as soon as
+both are registered. Each side is an exact name, a glob (using *, ? and
+[...]), or a regular expression between slashes; if CLIENT is a regular
+expression, ROBOT may refer to its groups as $1 or ${name}. Rules are tried in
+the order they were added, so later rules act as fallbacks:
+
+  team-a=robot0
+  team-*=robot*
+  /team-(.*)/=robot-$1`,
+}
+
+func init() {
+	rootCmd.AddCommand(pairCmd)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// recordStartCmd represents the record start command
+var recordStartCmd = &cobra.Command{
+	Use:   "start [DIRECTORY]",
+	Short: "Start recording sessions",
+	Long: `Start recording every bound session in the running Erebus instance.
+Recordings are written to DIRECTORY on the broker's host, or the broker's
+default recording directory if none is given.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		rArgs := &pb.ControlMessage_StartRecordingRequest{}
+		if len(args) > 0 {
+			rArgs.Directory = args[0]
+		}
+		res, err := client.StartRecording(context.Background(), rArgs)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error starting recording")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_StartRecordingResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error starting recording")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_StartRecordingResponse_Ok_:
+		default:
+			fmt.Fprintln(os.Stderr, "Error starting recording")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	recordCmd.AddCommand(recordStartCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// recordStopCmd represents the record stop command
+var recordStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop recording sessions",
+	Long: `Stop recording sessions in the running Erebus instance, closing all
+open recordings.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		res, err := client.StopRecording(context.Background(), &pb.Null{})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error stopping recording")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_StopRecordingResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error stopping recording")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_StopRecordingResponse_Ok_:
+		default:
+			fmt.Fprintln(os.Stderr, "Error stopping recording")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	recordCmd.AddCommand(recordStopCmd)
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// recordCmd represents the record command
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Control session recording",
+	Long: `Control the recording of bound sessions in the running Erebus instance.
+Each connection is recorded to its own file on the broker's host.`,
+}
+
+func init() {
+	rootCmd.AddCommand(recordCmd)
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+var regressName string
+var regressWait time.Duration
+var regressVelocityTolerance float64
+var regressLatencyTolerance time.Duration
+
+// regressCmd represents the regress command
+var regressCmd = &cobra.Command{
+	Use:   "regress RECORDING CLIENT",
+	Short: "Check a client against a session recording",
+	Long: `Replay RECORDING, a session recording on the broker's host, to the client
+CLIENT in lockstep, and compare the commands it answers each frame with
+against the recorded ones. CLIENT must request sync mode, and is waited for if
+it has not registered yet.
+
+Every frame which differs from the recording is reported, followed by a
+summary. Exits with status 2 if there are any differences, or 1 on error.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		rArgs := &pb.ControlMessage_RegressRequest{
+			RecordingPath:     args[0],
+			ClientName:        args[1],
+			RobotName:         regressName,
+			ClientTimeout:     regressWait.Seconds(),
+			VelocityTolerance: regressVelocityTolerance,
+			LatencyTolerance:  regressLatencyTolerance.Seconds(),
+		}
+		res, err := client.Regress(context.Background(), rArgs)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error running regression")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_RegressResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error running regression")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_RegressResponse_Ok_:
+			report := res.GetOk()
+			for _, frame := range report.GetFailures() {
+				printRegressionFrame(frame)
+			}
+			fmt.Printf("%d frames, %d differ; max velocity delta %g; latency mean %s (recorded %s), max %s\n",
+				report.GetFrames(),
+				len(report.GetFailures()),
+				report.GetMaxVelocityDelta(),
+				formatSeconds(report.GetMeanActualLatency()),
+				formatSeconds(report.GetMeanExpectedLatency()),
+				formatSeconds(report.GetMaxActualLatency()),
+			)
+			if len(report.GetFailures()) > 0 {
+				fmt.Println("FAIL")
+				os.Exit(2)
+			}
+			fmt.Println("PASS")
+		default:
+			fmt.Fprintln(os.Stderr, "Error running regression")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func formatSeconds(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Microsecond).String()
+}
+
+func describeCommand(cmd *pb.Command) string {
+	switch cmd.GetCommand().(type) {
+	case *pb.Command_MotorCommand_:
+		return fmt.Sprintf("velocity %g", cmd.GetMotorCommand().GetVelocity())
+	case *pb.Command_LedCommand:
+		return fmt.Sprintf("LED state %d", cmd.GetLedCommand().GetState())
+	default:
+		return "empty command"
+	}
+}
+
+func printRegressionFrame(frame *pb.ControlMessage_RegressionFrame) {
+	fmt.Printf("Frame %d (t=%gs):", frame.GetIndex(), frame.GetTimestamp())
+	if frame.GetMissedReply() {
+		fmt.Println(" no reply")
+		return
+	}
+	if frame.GetLatencyExceeded() {
+		fmt.Printf(" latency %s (recorded %s)",
+			formatSeconds(frame.GetActualLatency()),
+			formatSeconds(frame.GetExpectedLatency()),
+		)
+	}
+	fmt.Println()
+	for _, diff := range frame.GetCommandDiffs() {
+		switch diff.GetKind() {
+		case pb.ControlMessage_CommandDiff_MISSING:
+			fmt.Printf("  %s: missing (recorded %s)\n", diff.GetDevice(), describeCommand(diff.GetExpected()))
+		case pb.ControlMessage_CommandDiff_EXTRA:
+			fmt.Printf("  %s: extra (%s)\n", diff.GetDevice(), describeCommand(diff.GetActual()))
+		case pb.ControlMessage_CommandDiff_VELOCITY:
+			fmt.Printf("  %s: %s (recorded %s, delta %+g)\n",
+				diff.GetDevice(),
+				describeCommand(diff.GetActual()),
+				describeCommand(diff.GetExpected()),
+				diff.GetVelocityDelta(),
+			)
+		default:
+			fmt.Printf("  %s: %s (recorded %s)\n",
+				diff.GetDevice(),
+				describeCommand(diff.GetActual()),
+				describeCommand(diff.GetExpected()),
+			)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(regressCmd)
+
+	regressCmd.Flags().StringVarP(&regressName, "name", "n", "", "name of the replay robot (default: the recorded robot's name)")
+	regressCmd.Flags().DurationVar(&regressWait, "wait", 30*time.Second, "how long to wait for the client to register (0: forever)")
+	regressCmd.Flags().Float64Var(&regressVelocityTolerance, "velocity-tolerance", 0, "largest motor velocity difference to ignore")
+	regressCmd.Flags().DurationVar(&regressLatencyTolerance, "latency-tolerance", 0, "how much slower than recorded the client may answer (0: don't compare timing)")
+}
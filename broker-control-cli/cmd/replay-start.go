@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+var replayName string
+var replaySpeed float64
+var replayStep bool
+
+// replayStartCmd represents the replay start command
+var replayStartCmd = &cobra.Command{
+	Use:   "start RECORDING",
+	Short: "Start a replay robot",
+	Long: `Start a replay robot playing back RECORDING, a session recording on the
+broker's host. The name of the started robot is printed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		rArgs := &pb.ControlMessage_StartReplayRequest{
+			RecordingPath: args[0],
+			RobotName:     replayName,
+			Speed:         replaySpeed,
+			StepMode:      replayStep,
+		}
+		res, err := client.StartReplay(context.Background(), rArgs)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error starting replay")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_StartReplayResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error starting replay")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_StartReplayResponse_Ok_:
+			fmt.Println(res.GetOk().GetRobotName())
+		default:
+			fmt.Fprintln(os.Stderr, "Error starting replay")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	replayCmd.AddCommand(replayStartCmd)
+
+	replayStartCmd.Flags().StringVarP(&replayName, "name", "n", "", "name of the replay robot (default: the recorded robot's name)")
+	replayStartCmd.Flags().Float64Var(&replaySpeed, "speed", 1, "playback speed relative to the recording (0: as fast as the client answers)")
+	replayStartCmd.Flags().BoolVar(&replayStep, "step", false, "only play frames released with \"replay step\"")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// replayStepCmd represents the replay step command
+var replayStepCmd = &cobra.Command{
+	Use:   "step ROBOT [FRAMES]",
+	Short: "Play frames of a replay robot in step mode",
+	Long: `Release the next FRAMES (default 1) frames of a replay robot started in
+step mode.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.RangeArgs(1, 2)(cmd, args); err != nil {
+			return err
+		}
+		if len(args) > 1 {
+			if frames, err := strconv.Atoi(args[1]); err != nil || frames < 1 {
+				return fmt.Errorf("invalid frame count \"%s\"", args[1])
+			}
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		frames := 1
+		if len(args) > 1 {
+			frames, _ = strconv.Atoi(args[1])
+		}
+		rArgs := &pb.ControlMessage_StepReplayRequest{
+			RobotName: args[0],
+			Frames:    int32(frames),
+		}
+		res, err := client.StepReplay(context.Background(), rArgs)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error stepping replay")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_StepReplayResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error stepping replay")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_StepReplayResponse_Ok_:
+		default:
+			fmt.Fprintln(os.Stderr, "Error stepping replay")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	replayCmd.AddCommand(replayStepCmd)
+}
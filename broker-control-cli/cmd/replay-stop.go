@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// replayStopCmd represents the replay stop command
+var replayStopCmd = &cobra.Command{
+	Use:   "stop ROBOT",
+	Short: "Stop a replay robot",
+	Long:  `Stop playback and unregister a replay robot.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		rArgs := &pb.ControlMessage_StopReplayRequest{
+			RobotName: args[0],
+		}
+		res, err := client.StopReplay(context.Background(), rArgs)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error stopping replay")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_StopReplayResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error stopping replay")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_StopReplayResponse_Ok_:
+		default:
+			fmt.Fprintln(os.Stderr, "Error stopping replay")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	replayCmd.AddCommand(replayStopCmd)
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Control replay robots",
+	Long: `Control virtual robots which play back a session recording to a client
+in place of a robot running in Webots. A replay robot can be connected to a
+client like any other robot, and unregisters itself once playback finishes.`,
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+}
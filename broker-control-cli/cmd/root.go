@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"os"
+	"strings"
 
-	//homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
 )
 
@@ -41,33 +41,51 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	//rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.broker-control-cli.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.broker-control-cli.yaml)")
 
 	rootCmd.PersistentFlags().StringVarP(&server, "server", "s", "localhost:51512", "Erebus server to connect to")
+	rootCmd.PersistentFlags().String("admin-token", "", "token for the broker's Control and Scoreboard services (default: admin-token from the config file or $EREBUS_ADMIN_TOKEN)")
+	viper.BindPFlag("admin-token", rootCmd.PersistentFlags().Lookup("admin-token"))
+	rootCmd.PersistentFlags().String("arena", "", "arena to operate, which must be given if the broker runs several (default: arena from the config file or $EREBUS_ARENA)")
+	viper.BindPFlag("arena", rootCmd.PersistentFlags().Lookup("arena"))
+	rootCmd.PersistentFlags().String("tls-ca", "", "CA certificates to verify the broker with, enabling TLS (default: the system's CAs if TLS is enabled)")
+	rootCmd.PersistentFlags().String("tls-cert", "", "client certificate to present to the broker, enabling TLS")
+	rootCmd.PersistentFlags().String("tls-key", "", "private key of the client certificate")
+	for _, name := range []string{"tls-ca", "tls-cert", "tls-key"} {
+		viper.BindPFlag(name, rootCmd.PersistentFlags().Lookup(name))
+	}
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
-	//	if cfgFile != "" {
-	//		// Use config file from the flag.
-	//		viper.SetConfigFile(cfgFile)
-	//	} else {
-	//		// Find home directory.
-	//		home, err := homedir.Dir()
-	//		if err != nil {
-	//			fmt.Println(err)
-	//			os.Exit(1)
-	//		}
-	//
-	//		// Search config in home directory with name ".broker-control-cli" (without extension).
-	//		viper.AddConfigPath(home)
-	//		viper.SetConfigName(".broker-control-cli")
-	//	}
+	if cfgFile != "" {
+		// Use config file from the flag.
+		viper.SetConfigFile(cfgFile)
+	} else {
+		// Find home directory.
+		home, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		// Search config in home directory with name ".broker-control-cli" (without extension).
+		viper.AddConfigPath(home)
+		viper.SetConfigName(".broker-control-cli")
+	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// Read in environment variables that match, e.g. EREBUS_ADMIN_TOKEN for
+	// admin-token
+	viper.SetEnvPrefix("erebus")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	} else if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
+		fmt.Fprintf(os.Stderr, "Error reading config file \"%s\"\n", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
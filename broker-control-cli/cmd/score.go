@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+var scoreOnce bool
+
+// scoreCmd represents the score command
+var scoreCmd = &cobra.Command{
+	Use:   "score",
+	Short: "Show the scoreboard live",
+	Long: `Show the scores of teams and robots in the running Erebus instance, and
+print them again along with the game event which changed them whenever they
+change. A team scores the points of the robots its client was bound to when
+they scored.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getScoreboardClient()
+		if scoreOnce {
+			scores, err := client.GetScoreboard(context.Background(), &pb.Null{})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error getting scoreboard")
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			printScores(scores)
+			return
+		}
+		stream, err := client.SubscribeScoreboard(context.Background(), &pb.Null{})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error subscribing to scoreboard")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		for first := true; ; first = false {
+			scores, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return
+				}
+				fmt.Fprintln(os.Stderr, "Error subscribing to scoreboard")
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if !first {
+				fmt.Println()
+			}
+			if event := scores.GetLastEvent(); event != nil {
+				fmt.Println(formatGameEvent(event))
+			}
+			printScores(scores)
+		}
+	},
+}
+
+func formatGameEvent(event *pb.GameEvent) string {
+	eventType := strings.ToLower(strings.ReplaceAll(event.GetType().String(), "_", "-"))
+	fields := []string{fmt.Sprintf("[%s]", formatClock(event.GetTimestamp())), event.GetRobotName(), eventType}
+	if event.GetPoints() != 0 {
+		fields = append(fields, fmt.Sprintf("%+d", event.GetPoints()))
+	}
+	if event.GetDescription() != "" {
+		fields = append(fields, event.GetDescription())
+	}
+	return strings.Join(fields, " ")
+}
+
+func printScores(scores *pb.Scores) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TEAM\tSCORE")
+	for _, team := range scores.GetTeams() {
+		fmt.Fprintf(w, "%s\t%d\n", team.GetTeamName(), team.GetScore())
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ROBOT\tTEAM\tSCORE\tPICKED UP\tDELIVERED\tPENALTIES\tIN BASE")
+	for _, robot := range scores.GetRobots() {
+		team := robot.GetTeamName()
+		if team == "" {
+			team = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%t\n",
+			robot.GetRobotName(), team, robot.GetScore(),
+			robot.GetVictimsPickedUp(), robot.GetVictimsDelivered(), robot.GetPenalties(),
+			robot.GetInBase())
+	}
+	w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(scoreCmd)
+
+	scoreCmd.Flags().BoolVar(&scoreOnce, "once", false, "print the current scores and exit")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// shutdownCmd represents the shutdown command
+var shutdownCmd = &cobra.Command{
+	Use:   "shutdown [REASON...]",
+	Short: "Shut the broker down",
+	Long: `Shut the running Erebus instance down. Every bound robot is stopped
+and unbound, and every robot and client is told the broker is shutting down,
+along with REASON if one is given, before its session ends.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		rArgs := &pb.ControlMessage_ShutdownRequest{
+			Reason: strings.Join(args, " "),
+		}
+		res, err := client.Shutdown(context.Background(), rArgs)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error shutting down broker")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		switch res.Data.(type) {
+		case *pb.ControlMessage_ShutdownResponse_Error:
+			fmt.Fprintln(os.Stderr, "Error shutting down broker")
+			fmt.Fprintln(os.Stderr, res.GetError())
+			os.Exit(1)
+		case *pb.ControlMessage_ShutdownResponse_Ok_:
+		default:
+			fmt.Fprintln(os.Stderr, "Error shutting down broker")
+			fmt.Fprintln(os.Stderr, "Unexpected response from broker")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shutdownCmd)
+}
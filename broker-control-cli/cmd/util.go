@@ -1,20 +1,106 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"os"
 
+	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
 )
 
-func getControlClient() pb.ControlClient {
-	conn, err := grpc.Dial(server, grpc.WithInsecure())
+// adminToken authenticates each call to the Control service with the admin
+// token
+type adminToken string
+
+func (t adminToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t adminToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// arenaSelector names the arena each call to the Control and Scoreboard
+// services applies to
+type arenaSelector string
+
+func (a arenaSelector) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"arena": string(a)}, nil
+}
+
+func (a arenaSelector) RequireTransportSecurity() bool {
+	return false
+}
+
+// transportCredentials returns TLS credentials if --tls-ca or --tls-cert is
+// set, and nil otherwise
+func transportCredentials() (credentials.TransportCredentials, error) {
+	caFile := viper.GetString("tls-ca")
+	certFile := viper.GetString("tls-cert")
+	keyFile := viper.GetString("tls-key")
+	if caFile == "" && certFile == "" {
+		return nil, nil
+	}
+	config := &tls.Config{}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// dialBroker connects to the broker, presenting the admin token and naming the
+// arena on every call
+func dialBroker() *grpc.ClientConn {
+	creds, err := transportCredentials()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading TLS configuration")
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if creds != nil {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
+	if token := viper.GetString("admin-token"); token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(adminToken(token)))
+	}
+	if arena := viper.GetString("arena"); arena != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(arenaSelector(arena)))
+	}
+	conn, err := grpc.Dial(server, dialOpts...)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error connecting to server \"%s\"\n", server)
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
-	return pb.NewControlClient(conn)
+	return conn
+}
+
+func getControlClient() pb.ControlClient {
+	return pb.NewControlClient(dialBroker())
+}
+
+func getScoreboardClient() pb.ScoreboardClient {
+	return pb.NewScoreboardClient(dialBroker())
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+
+	pb "github.com/ethanwu10/erebus/broker-control-cli/gen"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch ROBOT",
+	Short: "Watch a robot's traffic",
+	Long: `Get streaming copies of the sensor data a robot sends and the commands it is
+sent by its client, and of it being bound and unbound, without affecting the
+match. Messages are skipped if this falls behind; the number skipped is shown
+on the next message.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getControlClient()
+		stream, err := client.WatchRobot(context.Background(), &pb.ControlMessage_WatchRobotRequest{RobotName: args[0]})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error watching robot")
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return
+				} else {
+					fmt.Fprintln(os.Stderr, "Error watching robot")
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+			}
+			if _, ok := res.Data.(*pb.ControlMessage_RobotTraffic_Error); ok {
+				fmt.Fprintln(os.Stderr, "Error watching robot")
+				fmt.Fprintln(os.Stderr, res.GetError())
+				os.Exit(1)
+			}
+			fmt.Println(formatTraffic(res))
+		}
+	},
+}
+
+func formatTraffic(traffic *pb.ControlMessage_RobotTraffic) string {
+	trafficTime := "[unknown]"
+	if t, err := ptypes.Timestamp(traffic.GetTime()); err == nil {
+		trafficTime = t.Local().Format(time.RFC3339Nano)
+	}
+	fields := []string{trafficTime}
+	var message proto.Message
+	switch data := traffic.Data.(type) {
+	case *pb.ControlMessage_RobotTraffic_Bound_:
+		fields = append(fields, "bound", fmt.Sprintf("sync=%t", data.Bound.GetIsSync()))
+	case *pb.ControlMessage_RobotTraffic_Unbound_:
+		fields = append(fields, "unbound")
+	case *pb.ControlMessage_RobotTraffic_SensorsData:
+		fields = append(fields, "sensors")
+		message = data.SensorsData
+	case *pb.ControlMessage_RobotTraffic_Commands:
+		fields = append(fields, "commands")
+		if traffic.GetFromPolicy() {
+			fields = append(fields, "policy")
+		}
+		message = data.Commands
+	default:
+		fields = append(fields, "unknown")
+	}
+	fields = append(fields, "client="+traffic.GetClientName())
+	if traffic.GetDropped() > 0 {
+		fields = append(fields, fmt.Sprintf("skipped=%d", traffic.GetDropped()))
+	}
+	if message != nil {
+		fields = append(fields, proto.CompactTextString(message))
+	}
+	return strings.Join(fields, " ")
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -49,7 +50,90 @@ func (x ControlMessage_SubscribeClientControllersMessage_EventType) String() str
 }
 
 func (ControlMessage_SubscribeClientControllersMessage_EventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 2, 0}
+	return fileDescriptor_0c5120591600887d, []int{0, 4, 0}
+}
+
+type ControlMessage_BrokerEvent_EventType int32
+
+const (
+	ControlMessage_BrokerEvent_UNKNOWN             ControlMessage_BrokerEvent_EventType = 0
+	ControlMessage_BrokerEvent_ROBOT_REGISTERED    ControlMessage_BrokerEvent_EventType = 1
+	ControlMessage_BrokerEvent_ROBOT_UNREGISTERED  ControlMessage_BrokerEvent_EventType = 2
+	ControlMessage_BrokerEvent_CLIENT_REGISTERED   ControlMessage_BrokerEvent_EventType = 3
+	ControlMessage_BrokerEvent_CLIENT_UNREGISTERED ControlMessage_BrokerEvent_EventType = 4
+	ControlMessage_BrokerEvent_CONNECTION_BOUND    ControlMessage_BrokerEvent_EventType = 5
+	ControlMessage_BrokerEvent_CONNECTION_UNBOUND  ControlMessage_BrokerEvent_EventType = 6
+	ControlMessage_BrokerEvent_SIM_STATE_CHANGED   ControlMessage_BrokerEvent_EventType = 7
+	ControlMessage_BrokerEvent_SHUTTING_DOWN       ControlMessage_BrokerEvent_EventType = 8
+)
+
+var ControlMessage_BrokerEvent_EventType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ROBOT_REGISTERED",
+	2: "ROBOT_UNREGISTERED",
+	3: "CLIENT_REGISTERED",
+	4: "CLIENT_UNREGISTERED",
+	5: "CONNECTION_BOUND",
+	6: "CONNECTION_UNBOUND",
+	7: "SIM_STATE_CHANGED",
+	8: "SHUTTING_DOWN",
+}
+
+var ControlMessage_BrokerEvent_EventType_value = map[string]int32{
+	"UNKNOWN":             0,
+	"ROBOT_REGISTERED":    1,
+	"ROBOT_UNREGISTERED":  2,
+	"CLIENT_REGISTERED":   3,
+	"CLIENT_UNREGISTERED": 4,
+	"CONNECTION_BOUND":    5,
+	"CONNECTION_UNBOUND":  6,
+	"SIM_STATE_CHANGED":   7,
+	"SHUTTING_DOWN":       8,
+}
+
+func (x ControlMessage_BrokerEvent_EventType) String() string {
+	return proto.EnumName(ControlMessage_BrokerEvent_EventType_name, int32(x))
+}
+
+func (ControlMessage_BrokerEvent_EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 11, 0}
+}
+
+type ControlMessage_CommandDiff_Kind int32
+
+const (
+	ControlMessage_CommandDiff_UNKNOWN  ControlMessage_CommandDiff_Kind = 0
+	ControlMessage_CommandDiff_MISSING  ControlMessage_CommandDiff_Kind = 1
+	ControlMessage_CommandDiff_EXTRA    ControlMessage_CommandDiff_Kind = 2
+	ControlMessage_CommandDiff_VELOCITY ControlMessage_CommandDiff_Kind = 3
+	ControlMessage_CommandDiff_LED      ControlMessage_CommandDiff_Kind = 4
+	ControlMessage_CommandDiff_TYPE     ControlMessage_CommandDiff_Kind = 5
+)
+
+var ControlMessage_CommandDiff_Kind_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "MISSING",
+	2: "EXTRA",
+	3: "VELOCITY",
+	4: "LED",
+	5: "TYPE",
+}
+
+var ControlMessage_CommandDiff_Kind_value = map[string]int32{
+	"UNKNOWN":  0,
+	"MISSING":  1,
+	"EXTRA":    2,
+	"VELOCITY": 3,
+	"LED":      4,
+	"TYPE":     5,
+}
+
+func (x ControlMessage_CommandDiff_Kind) String() string {
+	return proto.EnumName(ControlMessage_CommandDiff_Kind_name, int32(x))
+}
+
+func (ControlMessage_CommandDiff_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 24, 0}
 }
 
 type ControlMessage struct {
@@ -83,6 +167,100 @@ func (m *ControlMessage) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ControlMessage proto.InternalMessageInfo
 
+type ControlMessage_Arena struct {
+	Name                 string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Timestep             int32     `protobuf:"varint,2,opt,name=timestep,proto3" json:"timestep,omitempty"`
+	SimState             *SimState `protobuf:"bytes,3,opt,name=simState,proto3" json:"simState,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ControlMessage_Arena) Reset()         { *m = ControlMessage_Arena{} }
+func (m *ControlMessage_Arena) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_Arena) ProtoMessage()    {}
+func (*ControlMessage_Arena) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 0}
+}
+
+func (m *ControlMessage_Arena) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_Arena.Unmarshal(m, b)
+}
+func (m *ControlMessage_Arena) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_Arena.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_Arena) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_Arena.Merge(m, src)
+}
+func (m *ControlMessage_Arena) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_Arena.Size(m)
+}
+func (m *ControlMessage_Arena) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_Arena.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_Arena proto.InternalMessageInfo
+
+func (m *ControlMessage_Arena) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ControlMessage_Arena) GetTimestep() int32 {
+	if m != nil {
+		return m.Timestep
+	}
+	return 0
+}
+
+func (m *ControlMessage_Arena) GetSimState() *SimState {
+	if m != nil {
+		return m.SimState
+	}
+	return nil
+}
+
+type ControlMessage_GetArenasResponse struct {
+	Arenas               []*ControlMessage_Arena `protobuf:"bytes,1,rep,name=arenas,proto3" json:"arenas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ControlMessage_GetArenasResponse) Reset()         { *m = ControlMessage_GetArenasResponse{} }
+func (m *ControlMessage_GetArenasResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_GetArenasResponse) ProtoMessage()    {}
+func (*ControlMessage_GetArenasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 1}
+}
+
+func (m *ControlMessage_GetArenasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_GetArenasResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_GetArenasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_GetArenasResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_GetArenasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_GetArenasResponse.Merge(m, src)
+}
+func (m *ControlMessage_GetArenasResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_GetArenasResponse.Size(m)
+}
+func (m *ControlMessage_GetArenasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_GetArenasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_GetArenasResponse proto.InternalMessageInfo
+
+func (m *ControlMessage_GetArenasResponse) GetArenas() []*ControlMessage_Arena {
+	if m != nil {
+		return m.Arenas
+	}
+	return nil
+}
+
 type ControlMessage_GetRobotsResponse struct {
 	RobotNames           []string `protobuf:"bytes,1,rep,name=robotNames,proto3" json:"robotNames,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -94,7 +272,7 @@ func (m *ControlMessage_GetRobotsResponse) Reset()         { *m = ControlMessage
 func (m *ControlMessage_GetRobotsResponse) String() string { return proto.CompactTextString(m) }
 func (*ControlMessage_GetRobotsResponse) ProtoMessage()    {}
 func (*ControlMessage_GetRobotsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 0}
+	return fileDescriptor_0c5120591600887d, []int{0, 2}
 }
 
 func (m *ControlMessage_GetRobotsResponse) XXX_Unmarshal(b []byte) error {
@@ -137,7 +315,7 @@ func (m *ControlMessage_GetClientControllersResponse) String() string {
 }
 func (*ControlMessage_GetClientControllersResponse) ProtoMessage() {}
 func (*ControlMessage_GetClientControllersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 1}
+	return fileDescriptor_0c5120591600887d, []int{0, 3}
 }
 
 func (m *ControlMessage_GetClientControllersResponse) XXX_Unmarshal(b []byte) error {
@@ -181,7 +359,7 @@ func (m *ControlMessage_SubscribeClientControllersMessage) String() string {
 }
 func (*ControlMessage_SubscribeClientControllersMessage) ProtoMessage() {}
 func (*ControlMessage_SubscribeClientControllersMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 2}
+	return fileDescriptor_0c5120591600887d, []int{0, 4}
 }
 
 func (m *ControlMessage_SubscribeClientControllersMessage) XXX_Unmarshal(b []byte) error {
@@ -232,7 +410,7 @@ func (m *ControlMessage_ConnectClientToRobotRequest) String() string {
 }
 func (*ControlMessage_ConnectClientToRobotRequest) ProtoMessage() {}
 func (*ControlMessage_ConnectClientToRobotRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 3}
+	return fileDescriptor_0c5120591600887d, []int{0, 5}
 }
 
 func (m *ControlMessage_ConnectClientToRobotRequest) XXX_Unmarshal(b []byte) error {
@@ -285,7 +463,7 @@ func (m *ControlMessage_ConnectClientToRobotResponse) String() string {
 }
 func (*ControlMessage_ConnectClientToRobotResponse) ProtoMessage() {}
 func (*ControlMessage_ConnectClientToRobotResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 4}
+	return fileDescriptor_0c5120591600887d, []int{0, 6}
 }
 
 func (m *ControlMessage_ConnectClientToRobotResponse) XXX_Unmarshal(b []byte) error {
@@ -367,7 +545,7 @@ func (m *ControlMessage_ConnectClientToRobotResponse_Ok) String() string {
 }
 func (*ControlMessage_ConnectClientToRobotResponse_Ok) ProtoMessage() {}
 func (*ControlMessage_ConnectClientToRobotResponse_Ok) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 4, 0}
+	return fileDescriptor_0c5120591600887d, []int{0, 6, 0}
 }
 
 func (m *ControlMessage_ConnectClientToRobotResponse_Ok) XXX_Unmarshal(b []byte) error {
@@ -403,7 +581,7 @@ func (m *ControlMessage_DisconnectClientFromRobotRequest) String() string {
 }
 func (*ControlMessage_DisconnectClientFromRobotRequest) ProtoMessage() {}
 func (*ControlMessage_DisconnectClientFromRobotRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 5}
+	return fileDescriptor_0c5120591600887d, []int{0, 7}
 }
 
 func (m *ControlMessage_DisconnectClientFromRobotRequest) XXX_Unmarshal(b []byte) error {
@@ -449,7 +627,7 @@ func (m *ControlMessage_DisconnectClientFromRobotResponse) String() string {
 }
 func (*ControlMessage_DisconnectClientFromRobotResponse) ProtoMessage() {}
 func (*ControlMessage_DisconnectClientFromRobotResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 6}
+	return fileDescriptor_0c5120591600887d, []int{0, 8}
 }
 
 func (m *ControlMessage_DisconnectClientFromRobotResponse) XXX_Unmarshal(b []byte) error {
@@ -531,7 +709,7 @@ func (m *ControlMessage_DisconnectClientFromRobotResponse_Ok) String() string {
 }
 func (*ControlMessage_DisconnectClientFromRobotResponse_Ok) ProtoMessage() {}
 func (*ControlMessage_DisconnectClientFromRobotResponse_Ok) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{0, 6, 0}
+	return fileDescriptor_0c5120591600887d, []int{0, 8, 0}
 }
 
 func (m *ControlMessage_DisconnectClientFromRobotResponse_Ok) XXX_Unmarshal(b []byte) error {
@@ -552,227 +730,3009 @@ func (m *ControlMessage_DisconnectClientFromRobotResponse_Ok) XXX_DiscardUnknown
 
 var xxx_messageInfo_ControlMessage_DisconnectClientFromRobotResponse_Ok proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("erebus.ControlMessage_SubscribeClientControllersMessage_EventType", ControlMessage_SubscribeClientControllersMessage_EventType_name, ControlMessage_SubscribeClientControllersMessage_EventType_value)
-	proto.RegisterType((*ControlMessage)(nil), "erebus.ControlMessage")
-	proto.RegisterType((*ControlMessage_GetRobotsResponse)(nil), "erebus.ControlMessage.GetRobotsResponse")
-	proto.RegisterType((*ControlMessage_GetClientControllersResponse)(nil), "erebus.ControlMessage.GetClientControllersResponse")
-	proto.RegisterType((*ControlMessage_SubscribeClientControllersMessage)(nil), "erebus.ControlMessage.SubscribeClientControllersMessage")
-	proto.RegisterType((*ControlMessage_ConnectClientToRobotRequest)(nil), "erebus.ControlMessage.ConnectClientToRobotRequest")
-	proto.RegisterType((*ControlMessage_ConnectClientToRobotResponse)(nil), "erebus.ControlMessage.ConnectClientToRobotResponse")
-	proto.RegisterType((*ControlMessage_ConnectClientToRobotResponse_Ok)(nil), "erebus.ControlMessage.ConnectClientToRobotResponse.Ok")
-	proto.RegisterType((*ControlMessage_DisconnectClientFromRobotRequest)(nil), "erebus.ControlMessage.DisconnectClientFromRobotRequest")
-	proto.RegisterType((*ControlMessage_DisconnectClientFromRobotResponse)(nil), "erebus.ControlMessage.DisconnectClientFromRobotResponse")
-	proto.RegisterType((*ControlMessage_DisconnectClientFromRobotResponse_Ok)(nil), "erebus.ControlMessage.DisconnectClientFromRobotResponse.Ok")
+type ControlMessage_Connection struct {
+	ClientName           string               `protobuf:"bytes,1,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	RobotName            string               `protobuf:"bytes,2,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	IsSync               bool                 `protobuf:"varint,3,opt,name=isSync,proto3" json:"isSync,omitempty"`
+	BoundSince           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=boundSince,proto3" json:"boundSince,omitempty"`
+	RejectedCommands     uint64               `protobuf:"varint,5,opt,name=rejectedCommands,proto3" json:"rejectedCommands,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
-
-var fileDescriptor_0c5120591600887d = []byte{
-	// 533 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0xdf, 0x8e, 0xd2, 0x4e,
-	0x14, 0xc7, 0x19, 0x7e, 0xbb, 0x6c, 0x7a, 0xf8, 0x89, 0x38, 0xd9, 0x18, 0x1c, 0x89, 0x61, 0xf7,
-	0xc2, 0x70, 0xd5, 0x6c, 0xc0, 0xe8, 0x26, 0x7a, 0x23, 0x7f, 0x04, 0x35, 0x5b, 0x4c, 0xc1, 0x18,
-	0xe3, 0x8d, 0xa5, 0x1e, 0x4d, 0x43, 0xdb, 0xc1, 0x99, 0xa9, 0xc9, 0x5e, 0xf9, 0x06, 0x7b, 0xed,
-	0xd3, 0xf8, 0x06, 0x3e, 0x83, 0xaf, 0x62, 0x68, 0x4b, 0x29, 0x85, 0x42, 0xd8, 0xbb, 0x99, 0x2f,
-	0x9c, 0xcf, 0xf9, 0x33, 0xe7, 0x9b, 0xc2, 0x1d, 0x9b, 0xfb, 0x4a, 0x70, 0x57, 0x9f, 0x0b, 0xae,
-	0x38, 0x2d, 0xa1, 0xc0, 0x69, 0x20, 0x59, 0x59, 0x5d, 0xcf, 0x51, 0x46, 0x22, 0xd3, 0xa4, 0xe3,
-	0x45, 0xc7, 0xf3, 0xdf, 0x25, 0xa8, 0x74, 0xa3, 0x88, 0x2b, 0x94, 0xd2, 0xfa, 0x86, 0xac, 0x0d,
-	0xf7, 0x06, 0xa8, 0x4c, 0x3e, 0xe5, 0x4a, 0x9a, 0x28, 0xe7, 0xdc, 0x97, 0x48, 0x1f, 0x01, 0x88,
-	0x85, 0x62, 0x58, 0x1e, 0xca, 0x1a, 0x69, 0xfc, 0xd7, 0xd4, 0xcc, 0x94, 0xc2, 0x86, 0x50, 0x1f,
-	0xa0, 0xea, 0xba, 0x0e, 0xfa, 0x2a, 0xe6, 0xb9, 0x28, 0x56, 0xf1, 0x4d, 0xb8, 0x6b, 0x27, 0x72,
-	0x1a, 0x92, 0x95, 0xd9, 0x5f, 0x02, 0x67, 0xe3, 0x60, 0x2a, 0x6d, 0xe1, 0x4c, 0x71, 0x03, 0x18,
-	0x17, 0x49, 0x3f, 0x83, 0x86, 0x3f, 0xd0, 0x57, 0x93, 0xeb, 0x39, 0xd6, 0x48, 0x83, 0x34, 0x2b,
-	0xad, 0x8e, 0x1e, 0xf5, 0xaa, 0xaf, 0xf7, 0xa3, 0xef, 0x85, 0xe9, 0xfd, 0x25, 0xc9, 0x5c, 0x41,
-	0xe9, 0x63, 0xa8, 0xac, 0x97, 0x56, 0x2b, 0x36, 0x48, 0x53, 0x33, 0x33, 0xea, 0xf9, 0x05, 0x68,
-	0x49, 0x3c, 0x2d, 0xc3, 0xc9, 0x7b, 0xe3, 0xad, 0x31, 0xfa, 0x60, 0x54, 0x0b, 0x14, 0xa0, 0xf4,
-	0x66, 0xf4, 0xda, 0xe8, 0xf7, 0xaa, 0x64, 0x71, 0x7e, 0xf7, 0xd2, 0x9c, 0xf4, 0x7b, 0xd5, 0x22,
-	0xfb, 0x04, 0x0f, 0xbb, 0xdc, 0xf7, 0xd1, 0x8e, 0xe7, 0x35, 0xe1, 0xe1, 0xb0, 0x4d, 0xfc, 0x1e,
-	0xa0, 0x54, 0x8b, 0x51, 0xdb, 0xa1, 0x1e, 0x26, 0x25, 0x61, 0xd2, 0x94, 0x42, 0xeb, 0xa0, 0x25,
-	0x83, 0x8f, 0x6b, 0x5a, 0x09, 0xec, 0x86, 0x40, 0x7d, 0x3b, 0x3d, 0x7e, 0x89, 0xfb, 0x70, 0x8c,
-	0x42, 0x70, 0x11, 0x91, 0x87, 0x05, 0x33, 0xba, 0xd2, 0x21, 0x14, 0xf9, 0x2c, 0xe4, 0x95, 0x5b,
-	0x4f, 0x73, 0x46, 0xb9, 0x0b, 0xac, 0x8f, 0x66, 0xc3, 0x82, 0x59, 0xe4, 0x33, 0x76, 0x04, 0xc5,
-	0xd1, 0xac, 0x53, 0x82, 0xa3, 0x2f, 0x96, 0xb2, 0x58, 0x07, 0x1a, 0x3d, 0x47, 0xda, 0xe9, 0xc8,
-	0x57, 0x82, 0x7b, 0x87, 0xb4, 0xcc, 0x7e, 0x11, 0x38, 0xdb, 0x01, 0xd9, 0xd3, 0xd9, 0x55, 0xaa,
-	0xb3, 0xe7, 0x39, 0x9d, 0xed, 0xa5, 0xe7, 0xb4, 0xd7, 0xfa, 0x73, 0x0c, 0x27, 0x31, 0x8b, 0x76,
-	0x41, 0x4b, 0x9c, 0x43, 0xff, 0x5f, 0x66, 0x32, 0x02, 0xd7, 0x65, 0xcd, 0x9c, 0xbc, 0x9b, 0x4e,
-	0xfb, 0x08, 0xa7, 0xdb, 0x9c, 0x94, 0xe1, 0xb5, 0xf3, 0x79, 0xf9, 0x26, 0xfc, 0x0a, 0x2c, 0xdf,
-	0x0c, 0x99, 0x04, 0x97, 0xb7, 0x75, 0xd3, 0x05, 0xa1, 0x4f, 0x80, 0x0e, 0x50, 0x8d, 0x1d, 0x2f,
-	0x70, 0x2d, 0xe5, 0x70, 0x7f, 0xac, 0x2c, 0x85, 0x19, 0x7e, 0x75, 0x79, 0x1b, 0x3b, 0x5e, 0xf4,
-	0xfb, 0x0b, 0xa8, 0x25, 0xf0, 0x03, 0x63, 0xa3, 0x9c, 0xe3, 0xcd, 0x9c, 0x1b, 0xff, 0x64, 0x6b,
-	0x24, 0xfa, 0x13, 0x4e, 0xb7, 0xed, 0x34, 0x6d, 0x1d, 0x64, 0x80, 0x70, 0x89, 0x73, 0x9f, 0x64,
-	0xa7, 0x1b, 0x6f, 0x08, 0x3c, 0xc8, 0xdd, 0x3d, 0xfa, 0xec, 0xf0, 0x6d, 0x8d, 0x6a, 0xb9, 0xbc,
-	0xed, 0x9a, 0x4f, 0x4b, 0xe1, 0x77, 0xa1, 0xfd, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x7f, 0xc4, 0xaa,
-	0xf5, 0x48, 0x06, 0x00, 0x00,
+func (m *ControlMessage_Connection) Reset()         { *m = ControlMessage_Connection{} }
+func (m *ControlMessage_Connection) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_Connection) ProtoMessage()    {}
+func (*ControlMessage_Connection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 9}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+func (m *ControlMessage_Connection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_Connection.Unmarshal(m, b)
+}
+func (m *ControlMessage_Connection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_Connection.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_Connection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_Connection.Merge(m, src)
+}
+func (m *ControlMessage_Connection) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_Connection.Size(m)
+}
+func (m *ControlMessage_Connection) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_Connection.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+var xxx_messageInfo_ControlMessage_Connection proto.InternalMessageInfo
 
-// ControlClient is the client API for Control service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ControlClient interface {
-	GetRobots(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetRobotsResponse, error)
-	GetClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetClientControllersResponse, error)
-	SubscribeClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeClientControllersClient, error)
-	GetSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (*SimState, error)
-	SubscribeSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeSimulationStateClient, error)
-	SetSimulationState(ctx context.Context, in *SimState, opts ...grpc.CallOption) (*Null, error)
-	ConnectClientToRobot(ctx context.Context, in *ControlMessage_ConnectClientToRobotRequest, opts ...grpc.CallOption) (*ControlMessage_ConnectClientToRobotResponse, error)
-	DisconnectClientFromRobot(ctx context.Context, in *ControlMessage_DisconnectClientFromRobotRequest, opts ...grpc.CallOption) (*ControlMessage_DisconnectClientFromRobotResponse, error)
+func (m *ControlMessage_Connection) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
 }
 
-type controlClient struct {
-	cc grpc.ClientConnInterface
+func (m *ControlMessage_Connection) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
 }
 
-func NewControlClient(cc grpc.ClientConnInterface) ControlClient {
-	return &controlClient{cc}
+func (m *ControlMessage_Connection) GetIsSync() bool {
+	if m != nil {
+		return m.IsSync
+	}
+	return false
 }
 
-func (c *controlClient) GetRobots(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetRobotsResponse, error) {
-	out := new(ControlMessage_GetRobotsResponse)
-	err := c.cc.Invoke(ctx, "/erebus.Control/GetRobots", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ControlMessage_Connection) GetBoundSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.BoundSince
 	}
-	return out, nil
+	return nil
 }
 
-func (c *controlClient) GetClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetClientControllersResponse, error) {
-	out := new(ControlMessage_GetClientControllersResponse)
-	err := c.cc.Invoke(ctx, "/erebus.Control/GetClientControllers", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ControlMessage_Connection) GetRejectedCommands() uint64 {
+	if m != nil {
+		return m.RejectedCommands
 	}
-	return out, nil
+	return 0
 }
 
-func (c *controlClient) SubscribeClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeClientControllersClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[0], "/erebus.Control/SubscribeClientControllers", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &controlSubscribeClientControllersClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+type ControlMessage_GetConnectionsResponse struct {
+	Connections          []*ControlMessage_Connection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-type Control_SubscribeClientControllersClient interface {
-	Recv() (*ControlMessage_SubscribeClientControllersMessage, error)
-	grpc.ClientStream
+func (m *ControlMessage_GetConnectionsResponse) Reset()         { *m = ControlMessage_GetConnectionsResponse{} }
+func (m *ControlMessage_GetConnectionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_GetConnectionsResponse) ProtoMessage()    {}
+func (*ControlMessage_GetConnectionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 10}
 }
 
-type controlSubscribeClientControllersClient struct {
-	grpc.ClientStream
+func (m *ControlMessage_GetConnectionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_GetConnectionsResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_GetConnectionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_GetConnectionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_GetConnectionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_GetConnectionsResponse.Merge(m, src)
+}
+func (m *ControlMessage_GetConnectionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_GetConnectionsResponse.Size(m)
+}
+func (m *ControlMessage_GetConnectionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_GetConnectionsResponse.DiscardUnknown(m)
 }
 
-func (x *controlSubscribeClientControllersClient) Recv() (*ControlMessage_SubscribeClientControllersMessage, error) {
-	m := new(ControlMessage_SubscribeClientControllersMessage)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_ControlMessage_GetConnectionsResponse proto.InternalMessageInfo
+
+func (m *ControlMessage_GetConnectionsResponse) GetConnections() []*ControlMessage_Connection {
+	if m != nil {
+		return m.Connections
 	}
-	return m, nil
+	return nil
 }
 
-func (c *controlClient) GetSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (*SimState, error) {
-	out := new(SimState)
-	err := c.cc.Invoke(ctx, "/erebus.Control/GetSimulationState", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ControlMessage_BrokerEvent struct {
+	EventType            ControlMessage_BrokerEvent_EventType `protobuf:"varint,1,opt,name=eventType,proto3,enum=erebus.ControlMessage_BrokerEvent_EventType" json:"eventType,omitempty"`
+	Time                 *timestamp.Timestamp                 `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	RobotName            string                               `protobuf:"bytes,3,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	ClientName           string                               `protobuf:"bytes,4,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	SimState             *SimState                            `protobuf:"bytes,5,opt,name=simState,proto3" json:"simState,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
 }
 
-func (c *controlClient) SubscribeSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeSimulationStateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[1], "/erebus.Control/SubscribeSimulationState", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &controlSubscribeSimulationStateClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (m *ControlMessage_BrokerEvent) Reset()         { *m = ControlMessage_BrokerEvent{} }
+func (m *ControlMessage_BrokerEvent) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_BrokerEvent) ProtoMessage()    {}
+func (*ControlMessage_BrokerEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 11}
 }
 
-type Control_SubscribeSimulationStateClient interface {
-	Recv() (*SimState, error)
-	grpc.ClientStream
+func (m *ControlMessage_BrokerEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_BrokerEvent.Unmarshal(m, b)
+}
+func (m *ControlMessage_BrokerEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_BrokerEvent.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_BrokerEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_BrokerEvent.Merge(m, src)
+}
+func (m *ControlMessage_BrokerEvent) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_BrokerEvent.Size(m)
+}
+func (m *ControlMessage_BrokerEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_BrokerEvent.DiscardUnknown(m)
 }
 
-type controlSubscribeSimulationStateClient struct {
-	grpc.ClientStream
+var xxx_messageInfo_ControlMessage_BrokerEvent proto.InternalMessageInfo
+
+func (m *ControlMessage_BrokerEvent) GetEventType() ControlMessage_BrokerEvent_EventType {
+	if m != nil {
+		return m.EventType
+	}
+	return ControlMessage_BrokerEvent_UNKNOWN
 }
 
-func (x *controlSubscribeSimulationStateClient) Recv() (*SimState, error) {
-	m := new(SimState)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ControlMessage_BrokerEvent) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
 	}
-	return m, nil
+	return nil
 }
 
-func (c *controlClient) SetSimulationState(ctx context.Context, in *SimState, opts ...grpc.CallOption) (*Null, error) {
-	out := new(Null)
-	err := c.cc.Invoke(ctx, "/erebus.Control/SetSimulationState", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ControlMessage_BrokerEvent) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
 	}
-	return out, nil
+	return ""
 }
 
-func (c *controlClient) ConnectClientToRobot(ctx context.Context, in *ControlMessage_ConnectClientToRobotRequest, opts ...grpc.CallOption) (*ControlMessage_ConnectClientToRobotResponse, error) {
-	out := new(ControlMessage_ConnectClientToRobotResponse)
-	err := c.cc.Invoke(ctx, "/erebus.Control/ConnectClientToRobot", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ControlMessage_BrokerEvent) GetClientName() string {
+	if m != nil {
+		return m.ClientName
 	}
-	return out, nil
+	return ""
 }
 
-func (c *controlClient) DisconnectClientFromRobot(ctx context.Context, in *ControlMessage_DisconnectClientFromRobotRequest, opts ...grpc.CallOption) (*ControlMessage_DisconnectClientFromRobotResponse, error) {
-	out := new(ControlMessage_DisconnectClientFromRobotResponse)
-	err := c.cc.Invoke(ctx, "/erebus.Control/DisconnectClientFromRobot", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ControlMessage_BrokerEvent) GetSimState() *SimState {
+	if m != nil {
+		return m.SimState
 	}
-	return out, nil
+	return nil
 }
 
-// ControlServer is the server API for Control service.
-type ControlServer interface {
-	GetRobots(context.Context, *Null) (*ControlMessage_GetRobotsResponse, error)
-	GetClientControllers(context.Context, *Null) (*ControlMessage_GetClientControllersResponse, error)
-	SubscribeClientControllers(*Null, Control_SubscribeClientControllersServer) error
-	GetSimulationState(context.Context, *Null) (*SimState, error)
-	SubscribeSimulationState(*Null, Control_SubscribeSimulationStateServer) error
-	SetSimulationState(context.Context, *SimState) (*Null, error)
+type ControlMessage_StartRecordingRequest struct {
+	Directory            string   `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StartRecordingRequest) Reset()         { *m = ControlMessage_StartRecordingRequest{} }
+func (m *ControlMessage_StartRecordingRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StartRecordingRequest) ProtoMessage()    {}
+func (*ControlMessage_StartRecordingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 12}
+}
+
+func (m *ControlMessage_StartRecordingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StartRecordingRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_StartRecordingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StartRecordingRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StartRecordingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StartRecordingRequest.Merge(m, src)
+}
+func (m *ControlMessage_StartRecordingRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StartRecordingRequest.Size(m)
+}
+func (m *ControlMessage_StartRecordingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StartRecordingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StartRecordingRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_StartRecordingRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+type ControlMessage_StartRecordingResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_StartRecordingResponse_Error
+	//	*ControlMessage_StartRecordingResponse_Ok_
+	Data                 isControlMessage_StartRecordingResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
+}
+
+func (m *ControlMessage_StartRecordingResponse) Reset()         { *m = ControlMessage_StartRecordingResponse{} }
+func (m *ControlMessage_StartRecordingResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StartRecordingResponse) ProtoMessage()    {}
+func (*ControlMessage_StartRecordingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 13}
+}
+
+func (m *ControlMessage_StartRecordingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StartRecordingResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_StartRecordingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StartRecordingResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StartRecordingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StartRecordingResponse.Merge(m, src)
+}
+func (m *ControlMessage_StartRecordingResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StartRecordingResponse.Size(m)
+}
+func (m *ControlMessage_StartRecordingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StartRecordingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StartRecordingResponse proto.InternalMessageInfo
+
+type isControlMessage_StartRecordingResponse_Data interface {
+	isControlMessage_StartRecordingResponse_Data()
+}
+
+type ControlMessage_StartRecordingResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_StartRecordingResponse_Ok_ struct {
+	Ok *ControlMessage_StartRecordingResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_StartRecordingResponse_Error) isControlMessage_StartRecordingResponse_Data() {}
+
+func (*ControlMessage_StartRecordingResponse_Ok_) isControlMessage_StartRecordingResponse_Data() {}
+
+func (m *ControlMessage_StartRecordingResponse) GetData() isControlMessage_StartRecordingResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_StartRecordingResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_StartRecordingResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_StartRecordingResponse) GetOk() *ControlMessage_StartRecordingResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_StartRecordingResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_StartRecordingResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_StartRecordingResponse_Error)(nil),
+		(*ControlMessage_StartRecordingResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_StartRecordingResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StartRecordingResponse_Ok) Reset() {
+	*m = ControlMessage_StartRecordingResponse_Ok{}
+}
+func (m *ControlMessage_StartRecordingResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StartRecordingResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_StartRecordingResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 13, 0}
+}
+
+func (m *ControlMessage_StartRecordingResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StartRecordingResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_StartRecordingResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StartRecordingResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StartRecordingResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StartRecordingResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_StartRecordingResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StartRecordingResponse_Ok.Size(m)
+}
+func (m *ControlMessage_StartRecordingResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StartRecordingResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StartRecordingResponse_Ok proto.InternalMessageInfo
+
+type ControlMessage_StopRecordingResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_StopRecordingResponse_Error
+	//	*ControlMessage_StopRecordingResponse_Ok_
+	Data                 isControlMessage_StopRecordingResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *ControlMessage_StopRecordingResponse) Reset()         { *m = ControlMessage_StopRecordingResponse{} }
+func (m *ControlMessage_StopRecordingResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StopRecordingResponse) ProtoMessage()    {}
+func (*ControlMessage_StopRecordingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 14}
+}
+
+func (m *ControlMessage_StopRecordingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StopRecordingResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_StopRecordingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StopRecordingResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StopRecordingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StopRecordingResponse.Merge(m, src)
+}
+func (m *ControlMessage_StopRecordingResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StopRecordingResponse.Size(m)
+}
+func (m *ControlMessage_StopRecordingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StopRecordingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StopRecordingResponse proto.InternalMessageInfo
+
+type isControlMessage_StopRecordingResponse_Data interface {
+	isControlMessage_StopRecordingResponse_Data()
+}
+
+type ControlMessage_StopRecordingResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_StopRecordingResponse_Ok_ struct {
+	Ok *ControlMessage_StopRecordingResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_StopRecordingResponse_Error) isControlMessage_StopRecordingResponse_Data() {}
+
+func (*ControlMessage_StopRecordingResponse_Ok_) isControlMessage_StopRecordingResponse_Data() {}
+
+func (m *ControlMessage_StopRecordingResponse) GetData() isControlMessage_StopRecordingResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_StopRecordingResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_StopRecordingResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_StopRecordingResponse) GetOk() *ControlMessage_StopRecordingResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_StopRecordingResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_StopRecordingResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_StopRecordingResponse_Error)(nil),
+		(*ControlMessage_StopRecordingResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_StopRecordingResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StopRecordingResponse_Ok) Reset() {
+	*m = ControlMessage_StopRecordingResponse_Ok{}
+}
+func (m *ControlMessage_StopRecordingResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StopRecordingResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_StopRecordingResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 14, 0}
+}
+
+func (m *ControlMessage_StopRecordingResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StopRecordingResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_StopRecordingResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StopRecordingResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StopRecordingResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StopRecordingResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_StopRecordingResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StopRecordingResponse_Ok.Size(m)
+}
+func (m *ControlMessage_StopRecordingResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StopRecordingResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StopRecordingResponse_Ok proto.InternalMessageInfo
+
+type ControlMessage_StartReplayRequest struct {
+	RecordingPath        string   `protobuf:"bytes,1,opt,name=recordingPath,proto3" json:"recordingPath,omitempty"`
+	RobotName            string   `protobuf:"bytes,2,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	Speed                float64  `protobuf:"fixed64,3,opt,name=speed,proto3" json:"speed,omitempty"`
+	StepMode             bool     `protobuf:"varint,4,opt,name=stepMode,proto3" json:"stepMode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StartReplayRequest) Reset()         { *m = ControlMessage_StartReplayRequest{} }
+func (m *ControlMessage_StartReplayRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StartReplayRequest) ProtoMessage()    {}
+func (*ControlMessage_StartReplayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 15}
+}
+
+func (m *ControlMessage_StartReplayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StartReplayRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_StartReplayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StartReplayRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StartReplayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StartReplayRequest.Merge(m, src)
+}
+func (m *ControlMessage_StartReplayRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StartReplayRequest.Size(m)
+}
+func (m *ControlMessage_StartReplayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StartReplayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StartReplayRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_StartReplayRequest) GetRecordingPath() string {
+	if m != nil {
+		return m.RecordingPath
+	}
+	return ""
+}
+
+func (m *ControlMessage_StartReplayRequest) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+func (m *ControlMessage_StartReplayRequest) GetSpeed() float64 {
+	if m != nil {
+		return m.Speed
+	}
+	return 0
+}
+
+func (m *ControlMessage_StartReplayRequest) GetStepMode() bool {
+	if m != nil {
+		return m.StepMode
+	}
+	return false
+}
+
+type ControlMessage_StartReplayResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_StartReplayResponse_Error
+	//	*ControlMessage_StartReplayResponse_Ok_
+	Data                 isControlMessage_StartReplayResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *ControlMessage_StartReplayResponse) Reset()         { *m = ControlMessage_StartReplayResponse{} }
+func (m *ControlMessage_StartReplayResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StartReplayResponse) ProtoMessage()    {}
+func (*ControlMessage_StartReplayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 16}
+}
+
+func (m *ControlMessage_StartReplayResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StartReplayResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_StartReplayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StartReplayResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StartReplayResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StartReplayResponse.Merge(m, src)
+}
+func (m *ControlMessage_StartReplayResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StartReplayResponse.Size(m)
+}
+func (m *ControlMessage_StartReplayResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StartReplayResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StartReplayResponse proto.InternalMessageInfo
+
+type isControlMessage_StartReplayResponse_Data interface {
+	isControlMessage_StartReplayResponse_Data()
+}
+
+type ControlMessage_StartReplayResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_StartReplayResponse_Ok_ struct {
+	Ok *ControlMessage_StartReplayResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_StartReplayResponse_Error) isControlMessage_StartReplayResponse_Data() {}
+
+func (*ControlMessage_StartReplayResponse_Ok_) isControlMessage_StartReplayResponse_Data() {}
+
+func (m *ControlMessage_StartReplayResponse) GetData() isControlMessage_StartReplayResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_StartReplayResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_StartReplayResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_StartReplayResponse) GetOk() *ControlMessage_StartReplayResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_StartReplayResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_StartReplayResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_StartReplayResponse_Error)(nil),
+		(*ControlMessage_StartReplayResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_StartReplayResponse_Ok struct {
+	RobotName            string   `protobuf:"bytes,1,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StartReplayResponse_Ok) Reset()         { *m = ControlMessage_StartReplayResponse_Ok{} }
+func (m *ControlMessage_StartReplayResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StartReplayResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_StartReplayResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 16, 0}
+}
+
+func (m *ControlMessage_StartReplayResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StartReplayResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_StartReplayResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StartReplayResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StartReplayResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StartReplayResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_StartReplayResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StartReplayResponse_Ok.Size(m)
+}
+func (m *ControlMessage_StartReplayResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StartReplayResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StartReplayResponse_Ok proto.InternalMessageInfo
+
+func (m *ControlMessage_StartReplayResponse_Ok) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+type ControlMessage_StepReplayRequest struct {
+	RobotName            string   `protobuf:"bytes,1,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	Frames               int32    `protobuf:"varint,2,opt,name=frames,proto3" json:"frames,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StepReplayRequest) Reset()         { *m = ControlMessage_StepReplayRequest{} }
+func (m *ControlMessage_StepReplayRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StepReplayRequest) ProtoMessage()    {}
+func (*ControlMessage_StepReplayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 17}
+}
+
+func (m *ControlMessage_StepReplayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StepReplayRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_StepReplayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StepReplayRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StepReplayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StepReplayRequest.Merge(m, src)
+}
+func (m *ControlMessage_StepReplayRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StepReplayRequest.Size(m)
+}
+func (m *ControlMessage_StepReplayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StepReplayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StepReplayRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_StepReplayRequest) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+func (m *ControlMessage_StepReplayRequest) GetFrames() int32 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+type ControlMessage_StepReplayResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_StepReplayResponse_Error
+	//	*ControlMessage_StepReplayResponse_Ok_
+	Data                 isControlMessage_StepReplayResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
+}
+
+func (m *ControlMessage_StepReplayResponse) Reset()         { *m = ControlMessage_StepReplayResponse{} }
+func (m *ControlMessage_StepReplayResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StepReplayResponse) ProtoMessage()    {}
+func (*ControlMessage_StepReplayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 18}
+}
+
+func (m *ControlMessage_StepReplayResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StepReplayResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_StepReplayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StepReplayResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StepReplayResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StepReplayResponse.Merge(m, src)
+}
+func (m *ControlMessage_StepReplayResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StepReplayResponse.Size(m)
+}
+func (m *ControlMessage_StepReplayResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StepReplayResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StepReplayResponse proto.InternalMessageInfo
+
+type isControlMessage_StepReplayResponse_Data interface {
+	isControlMessage_StepReplayResponse_Data()
+}
+
+type ControlMessage_StepReplayResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_StepReplayResponse_Ok_ struct {
+	Ok *ControlMessage_StepReplayResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_StepReplayResponse_Error) isControlMessage_StepReplayResponse_Data() {}
+
+func (*ControlMessage_StepReplayResponse_Ok_) isControlMessage_StepReplayResponse_Data() {}
+
+func (m *ControlMessage_StepReplayResponse) GetData() isControlMessage_StepReplayResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_StepReplayResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_StepReplayResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_StepReplayResponse) GetOk() *ControlMessage_StepReplayResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_StepReplayResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_StepReplayResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_StepReplayResponse_Error)(nil),
+		(*ControlMessage_StepReplayResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_StepReplayResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StepReplayResponse_Ok) Reset()         { *m = ControlMessage_StepReplayResponse_Ok{} }
+func (m *ControlMessage_StepReplayResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StepReplayResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_StepReplayResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 18, 0}
+}
+
+func (m *ControlMessage_StepReplayResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StepReplayResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_StepReplayResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StepReplayResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StepReplayResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StepReplayResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_StepReplayResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StepReplayResponse_Ok.Size(m)
+}
+func (m *ControlMessage_StepReplayResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StepReplayResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StepReplayResponse_Ok proto.InternalMessageInfo
+
+type ControlMessage_StopReplayRequest struct {
+	RobotName            string   `protobuf:"bytes,1,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StopReplayRequest) Reset()         { *m = ControlMessage_StopReplayRequest{} }
+func (m *ControlMessage_StopReplayRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StopReplayRequest) ProtoMessage()    {}
+func (*ControlMessage_StopReplayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 19}
+}
+
+func (m *ControlMessage_StopReplayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StopReplayRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_StopReplayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StopReplayRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StopReplayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StopReplayRequest.Merge(m, src)
+}
+func (m *ControlMessage_StopReplayRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StopReplayRequest.Size(m)
+}
+func (m *ControlMessage_StopReplayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StopReplayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StopReplayRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_StopReplayRequest) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+type ControlMessage_StopReplayResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_StopReplayResponse_Error
+	//	*ControlMessage_StopReplayResponse_Ok_
+	Data                 isControlMessage_StopReplayResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
+}
+
+func (m *ControlMessage_StopReplayResponse) Reset()         { *m = ControlMessage_StopReplayResponse{} }
+func (m *ControlMessage_StopReplayResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StopReplayResponse) ProtoMessage()    {}
+func (*ControlMessage_StopReplayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 20}
+}
+
+func (m *ControlMessage_StopReplayResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StopReplayResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_StopReplayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StopReplayResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StopReplayResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StopReplayResponse.Merge(m, src)
+}
+func (m *ControlMessage_StopReplayResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StopReplayResponse.Size(m)
+}
+func (m *ControlMessage_StopReplayResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StopReplayResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StopReplayResponse proto.InternalMessageInfo
+
+type isControlMessage_StopReplayResponse_Data interface {
+	isControlMessage_StopReplayResponse_Data()
+}
+
+type ControlMessage_StopReplayResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_StopReplayResponse_Ok_ struct {
+	Ok *ControlMessage_StopReplayResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_StopReplayResponse_Error) isControlMessage_StopReplayResponse_Data() {}
+
+func (*ControlMessage_StopReplayResponse_Ok_) isControlMessage_StopReplayResponse_Data() {}
+
+func (m *ControlMessage_StopReplayResponse) GetData() isControlMessage_StopReplayResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_StopReplayResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_StopReplayResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_StopReplayResponse) GetOk() *ControlMessage_StopReplayResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_StopReplayResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_StopReplayResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_StopReplayResponse_Error)(nil),
+		(*ControlMessage_StopReplayResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_StopReplayResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_StopReplayResponse_Ok) Reset()         { *m = ControlMessage_StopReplayResponse_Ok{} }
+func (m *ControlMessage_StopReplayResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_StopReplayResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_StopReplayResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 20, 0}
+}
+
+func (m *ControlMessage_StopReplayResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_StopReplayResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_StopReplayResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_StopReplayResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_StopReplayResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_StopReplayResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_StopReplayResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_StopReplayResponse_Ok.Size(m)
+}
+func (m *ControlMessage_StopReplayResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_StopReplayResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_StopReplayResponse_Ok proto.InternalMessageInfo
+
+type ControlMessage_DescribeRobotRequest struct {
+	RobotName            string   `protobuf:"bytes,1,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_DescribeRobotRequest) Reset()         { *m = ControlMessage_DescribeRobotRequest{} }
+func (m *ControlMessage_DescribeRobotRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_DescribeRobotRequest) ProtoMessage()    {}
+func (*ControlMessage_DescribeRobotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 21}
+}
+
+func (m *ControlMessage_DescribeRobotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_DescribeRobotRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_DescribeRobotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_DescribeRobotRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_DescribeRobotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_DescribeRobotRequest.Merge(m, src)
+}
+func (m *ControlMessage_DescribeRobotRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_DescribeRobotRequest.Size(m)
+}
+func (m *ControlMessage_DescribeRobotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_DescribeRobotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_DescribeRobotRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_DescribeRobotRequest) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+type ControlMessage_DescribeRobotResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_DescribeRobotResponse_Error
+	//	*ControlMessage_DescribeRobotResponse_Ok_
+	Data                 isControlMessage_DescribeRobotResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *ControlMessage_DescribeRobotResponse) Reset()         { *m = ControlMessage_DescribeRobotResponse{} }
+func (m *ControlMessage_DescribeRobotResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_DescribeRobotResponse) ProtoMessage()    {}
+func (*ControlMessage_DescribeRobotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 22}
+}
+
+func (m *ControlMessage_DescribeRobotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_DescribeRobotResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_DescribeRobotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_DescribeRobotResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_DescribeRobotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_DescribeRobotResponse.Merge(m, src)
+}
+func (m *ControlMessage_DescribeRobotResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_DescribeRobotResponse.Size(m)
+}
+func (m *ControlMessage_DescribeRobotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_DescribeRobotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_DescribeRobotResponse proto.InternalMessageInfo
+
+type isControlMessage_DescribeRobotResponse_Data interface {
+	isControlMessage_DescribeRobotResponse_Data()
+}
+
+type ControlMessage_DescribeRobotResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_DescribeRobotResponse_Ok_ struct {
+	Ok *ControlMessage_DescribeRobotResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_DescribeRobotResponse_Error) isControlMessage_DescribeRobotResponse_Data() {}
+
+func (*ControlMessage_DescribeRobotResponse_Ok_) isControlMessage_DescribeRobotResponse_Data() {}
+
+func (m *ControlMessage_DescribeRobotResponse) GetData() isControlMessage_DescribeRobotResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_DescribeRobotResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_DescribeRobotResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_DescribeRobotResponse) GetOk() *ControlMessage_DescribeRobotResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_DescribeRobotResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_DescribeRobotResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_DescribeRobotResponse_Error)(nil),
+		(*ControlMessage_DescribeRobotResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_DescribeRobotResponse_Ok struct {
+	RobotInfo            *RobotInfo `protobuf:"bytes,1,opt,name=robotInfo,proto3" json:"robotInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ControlMessage_DescribeRobotResponse_Ok) Reset() {
+	*m = ControlMessage_DescribeRobotResponse_Ok{}
+}
+func (m *ControlMessage_DescribeRobotResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_DescribeRobotResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_DescribeRobotResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 22, 0}
+}
+
+func (m *ControlMessage_DescribeRobotResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_DescribeRobotResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_DescribeRobotResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_DescribeRobotResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_DescribeRobotResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_DescribeRobotResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_DescribeRobotResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_DescribeRobotResponse_Ok.Size(m)
+}
+func (m *ControlMessage_DescribeRobotResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_DescribeRobotResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_DescribeRobotResponse_Ok proto.InternalMessageInfo
+
+func (m *ControlMessage_DescribeRobotResponse_Ok) GetRobotInfo() *RobotInfo {
+	if m != nil {
+		return m.RobotInfo
+	}
+	return nil
+}
+
+type ControlMessage_RegressRequest struct {
+	RecordingPath        string   `protobuf:"bytes,1,opt,name=recordingPath,proto3" json:"recordingPath,omitempty"`
+	ClientName           string   `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	RobotName            string   `protobuf:"bytes,3,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	ClientTimeout        float64  `protobuf:"fixed64,4,opt,name=clientTimeout,proto3" json:"clientTimeout,omitempty"`
+	VelocityTolerance    float64  `protobuf:"fixed64,5,opt,name=velocityTolerance,proto3" json:"velocityTolerance,omitempty"`
+	LatencyTolerance     float64  `protobuf:"fixed64,6,opt,name=latencyTolerance,proto3" json:"latencyTolerance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_RegressRequest) Reset()         { *m = ControlMessage_RegressRequest{} }
+func (m *ControlMessage_RegressRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RegressRequest) ProtoMessage()    {}
+func (*ControlMessage_RegressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 23}
+}
+
+func (m *ControlMessage_RegressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RegressRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_RegressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RegressRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RegressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RegressRequest.Merge(m, src)
+}
+func (m *ControlMessage_RegressRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RegressRequest.Size(m)
+}
+func (m *ControlMessage_RegressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RegressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RegressRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_RegressRequest) GetRecordingPath() string {
+	if m != nil {
+		return m.RecordingPath
+	}
+	return ""
+}
+
+func (m *ControlMessage_RegressRequest) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+func (m *ControlMessage_RegressRequest) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+func (m *ControlMessage_RegressRequest) GetClientTimeout() float64 {
+	if m != nil {
+		return m.ClientTimeout
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressRequest) GetVelocityTolerance() float64 {
+	if m != nil {
+		return m.VelocityTolerance
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressRequest) GetLatencyTolerance() float64 {
+	if m != nil {
+		return m.LatencyTolerance
+	}
+	return 0
+}
+
+type ControlMessage_CommandDiff struct {
+	Kind                 ControlMessage_CommandDiff_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=erebus.ControlMessage_CommandDiff_Kind" json:"kind,omitempty"`
+	Device               string                          `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	Expected             *Command                        `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual               *Command                        `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`
+	VelocityDelta        float64                         `protobuf:"fixed64,5,opt,name=velocityDelta,proto3" json:"velocityDelta,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ControlMessage_CommandDiff) Reset()         { *m = ControlMessage_CommandDiff{} }
+func (m *ControlMessage_CommandDiff) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_CommandDiff) ProtoMessage()    {}
+func (*ControlMessage_CommandDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 24}
+}
+
+func (m *ControlMessage_CommandDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_CommandDiff.Unmarshal(m, b)
+}
+func (m *ControlMessage_CommandDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_CommandDiff.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_CommandDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_CommandDiff.Merge(m, src)
+}
+func (m *ControlMessage_CommandDiff) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_CommandDiff.Size(m)
+}
+func (m *ControlMessage_CommandDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_CommandDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_CommandDiff proto.InternalMessageInfo
+
+func (m *ControlMessage_CommandDiff) GetKind() ControlMessage_CommandDiff_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return ControlMessage_CommandDiff_UNKNOWN
+}
+
+func (m *ControlMessage_CommandDiff) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *ControlMessage_CommandDiff) GetExpected() *Command {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+func (m *ControlMessage_CommandDiff) GetActual() *Command {
+	if m != nil {
+		return m.Actual
+	}
+	return nil
+}
+
+func (m *ControlMessage_CommandDiff) GetVelocityDelta() float64 {
+	if m != nil {
+		return m.VelocityDelta
+	}
+	return 0
+}
+
+type ControlMessage_RegressionFrame struct {
+	Index                int32                         `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Timestamp            float64                       `protobuf:"fixed64,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MissedReply          bool                          `protobuf:"varint,3,opt,name=missedReply,proto3" json:"missedReply,omitempty"`
+	ExpectedLatency      float64                       `protobuf:"fixed64,4,opt,name=expectedLatency,proto3" json:"expectedLatency,omitempty"`
+	ActualLatency        float64                       `protobuf:"fixed64,5,opt,name=actualLatency,proto3" json:"actualLatency,omitempty"`
+	LatencyExceeded      bool                          `protobuf:"varint,6,opt,name=latencyExceeded,proto3" json:"latencyExceeded,omitempty"`
+	CommandDiffs         []*ControlMessage_CommandDiff `protobuf:"bytes,7,rep,name=commandDiffs,proto3" json:"commandDiffs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *ControlMessage_RegressionFrame) Reset()         { *m = ControlMessage_RegressionFrame{} }
+func (m *ControlMessage_RegressionFrame) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RegressionFrame) ProtoMessage()    {}
+func (*ControlMessage_RegressionFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 25}
+}
+
+func (m *ControlMessage_RegressionFrame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RegressionFrame.Unmarshal(m, b)
+}
+func (m *ControlMessage_RegressionFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RegressionFrame.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RegressionFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RegressionFrame.Merge(m, src)
+}
+func (m *ControlMessage_RegressionFrame) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RegressionFrame.Size(m)
+}
+func (m *ControlMessage_RegressionFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RegressionFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RegressionFrame proto.InternalMessageInfo
+
+func (m *ControlMessage_RegressionFrame) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressionFrame) GetTimestamp() float64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressionFrame) GetMissedReply() bool {
+	if m != nil {
+		return m.MissedReply
+	}
+	return false
+}
+
+func (m *ControlMessage_RegressionFrame) GetExpectedLatency() float64 {
+	if m != nil {
+		return m.ExpectedLatency
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressionFrame) GetActualLatency() float64 {
+	if m != nil {
+		return m.ActualLatency
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressionFrame) GetLatencyExceeded() bool {
+	if m != nil {
+		return m.LatencyExceeded
+	}
+	return false
+}
+
+func (m *ControlMessage_RegressionFrame) GetCommandDiffs() []*ControlMessage_CommandDiff {
+	if m != nil {
+		return m.CommandDiffs
+	}
+	return nil
+}
+
+type ControlMessage_RegressResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_RegressResponse_Error
+	//	*ControlMessage_RegressResponse_Ok_
+	Data                 isControlMessage_RegressResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *ControlMessage_RegressResponse) Reset()         { *m = ControlMessage_RegressResponse{} }
+func (m *ControlMessage_RegressResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RegressResponse) ProtoMessage()    {}
+func (*ControlMessage_RegressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 26}
+}
+
+func (m *ControlMessage_RegressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RegressResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_RegressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RegressResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RegressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RegressResponse.Merge(m, src)
+}
+func (m *ControlMessage_RegressResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RegressResponse.Size(m)
+}
+func (m *ControlMessage_RegressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RegressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RegressResponse proto.InternalMessageInfo
+
+type isControlMessage_RegressResponse_Data interface {
+	isControlMessage_RegressResponse_Data()
+}
+
+type ControlMessage_RegressResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_RegressResponse_Ok_ struct {
+	Ok *ControlMessage_RegressResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_RegressResponse_Error) isControlMessage_RegressResponse_Data() {}
+
+func (*ControlMessage_RegressResponse_Ok_) isControlMessage_RegressResponse_Data() {}
+
+func (m *ControlMessage_RegressResponse) GetData() isControlMessage_RegressResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_RegressResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_RegressResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_RegressResponse) GetOk() *ControlMessage_RegressResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_RegressResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_RegressResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_RegressResponse_Error)(nil),
+		(*ControlMessage_RegressResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_RegressResponse_Ok struct {
+	RobotName            string                            `protobuf:"bytes,1,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	Frames               int32                             `protobuf:"varint,2,opt,name=frames,proto3" json:"frames,omitempty"`
+	Failures             []*ControlMessage_RegressionFrame `protobuf:"bytes,3,rep,name=failures,proto3" json:"failures,omitempty"`
+	MaxVelocityDelta     float64                           `protobuf:"fixed64,4,opt,name=maxVelocityDelta,proto3" json:"maxVelocityDelta,omitempty"`
+	MeanExpectedLatency  float64                           `protobuf:"fixed64,5,opt,name=meanExpectedLatency,proto3" json:"meanExpectedLatency,omitempty"`
+	MeanActualLatency    float64                           `protobuf:"fixed64,6,opt,name=meanActualLatency,proto3" json:"meanActualLatency,omitempty"`
+	MaxActualLatency     float64                           `protobuf:"fixed64,7,opt,name=maxActualLatency,proto3" json:"maxActualLatency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *ControlMessage_RegressResponse_Ok) Reset()         { *m = ControlMessage_RegressResponse_Ok{} }
+func (m *ControlMessage_RegressResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RegressResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_RegressResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 26, 0}
+}
+
+func (m *ControlMessage_RegressResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RegressResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_RegressResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RegressResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RegressResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RegressResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_RegressResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RegressResponse_Ok.Size(m)
+}
+func (m *ControlMessage_RegressResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RegressResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RegressResponse_Ok proto.InternalMessageInfo
+
+func (m *ControlMessage_RegressResponse_Ok) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+func (m *ControlMessage_RegressResponse_Ok) GetFrames() int32 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressResponse_Ok) GetFailures() []*ControlMessage_RegressionFrame {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+func (m *ControlMessage_RegressResponse_Ok) GetMaxVelocityDelta() float64 {
+	if m != nil {
+		return m.MaxVelocityDelta
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressResponse_Ok) GetMeanExpectedLatency() float64 {
+	if m != nil {
+		return m.MeanExpectedLatency
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressResponse_Ok) GetMeanActualLatency() float64 {
+	if m != nil {
+		return m.MeanActualLatency
+	}
+	return 0
+}
+
+func (m *ControlMessage_RegressResponse_Ok) GetMaxActualLatency() float64 {
+	if m != nil {
+		return m.MaxActualLatency
+	}
+	return 0
+}
+
+type ControlMessage_MatchStatus struct {
+	Duration             float64  `protobuf:"fixed64,1,opt,name=duration,proto3" json:"duration,omitempty"`
+	Elapsed              float64  `protobuf:"fixed64,2,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	Remaining            float64  `protobuf:"fixed64,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Running              bool     `protobuf:"varint,4,opt,name=running,proto3" json:"running,omitempty"`
+	Expired              bool     `protobuf:"varint,5,opt,name=expired,proto3" json:"expired,omitempty"`
+	SimTimed             bool     `protobuf:"varint,6,opt,name=simTimed,proto3" json:"simTimed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_MatchStatus) Reset()         { *m = ControlMessage_MatchStatus{} }
+func (m *ControlMessage_MatchStatus) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_MatchStatus) ProtoMessage()    {}
+func (*ControlMessage_MatchStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 27}
+}
+
+func (m *ControlMessage_MatchStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_MatchStatus.Unmarshal(m, b)
+}
+func (m *ControlMessage_MatchStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_MatchStatus.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_MatchStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_MatchStatus.Merge(m, src)
+}
+func (m *ControlMessage_MatchStatus) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_MatchStatus.Size(m)
+}
+func (m *ControlMessage_MatchStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_MatchStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_MatchStatus proto.InternalMessageInfo
+
+func (m *ControlMessage_MatchStatus) GetDuration() float64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *ControlMessage_MatchStatus) GetElapsed() float64 {
+	if m != nil {
+		return m.Elapsed
+	}
+	return 0
+}
+
+func (m *ControlMessage_MatchStatus) GetRemaining() float64 {
+	if m != nil {
+		return m.Remaining
+	}
+	return 0
+}
+
+func (m *ControlMessage_MatchStatus) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+func (m *ControlMessage_MatchStatus) GetExpired() bool {
+	if m != nil {
+		return m.Expired
+	}
+	return false
+}
+
+func (m *ControlMessage_MatchStatus) GetSimTimed() bool {
+	if m != nil {
+		return m.SimTimed
+	}
+	return false
+}
+
+type ControlMessage_PairingRuleStatus struct {
+	Rule                 string                       `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Pairs                []*ControlMessage_Connection `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	WaitingClients       []string                     `protobuf:"bytes,3,rep,name=waitingClients,proto3" json:"waitingClients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ControlMessage_PairingRuleStatus) Reset()         { *m = ControlMessage_PairingRuleStatus{} }
+func (m *ControlMessage_PairingRuleStatus) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_PairingRuleStatus) ProtoMessage()    {}
+func (*ControlMessage_PairingRuleStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 28}
+}
+
+func (m *ControlMessage_PairingRuleStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_PairingRuleStatus.Unmarshal(m, b)
+}
+func (m *ControlMessage_PairingRuleStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_PairingRuleStatus.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_PairingRuleStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_PairingRuleStatus.Merge(m, src)
+}
+func (m *ControlMessage_PairingRuleStatus) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_PairingRuleStatus.Size(m)
+}
+func (m *ControlMessage_PairingRuleStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_PairingRuleStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_PairingRuleStatus proto.InternalMessageInfo
+
+func (m *ControlMessage_PairingRuleStatus) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *ControlMessage_PairingRuleStatus) GetPairs() []*ControlMessage_Connection {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+func (m *ControlMessage_PairingRuleStatus) GetWaitingClients() []string {
+	if m != nil {
+		return m.WaitingClients
+	}
+	return nil
+}
+
+type ControlMessage_GetPairingRulesResponse struct {
+	Rules                []*ControlMessage_PairingRuleStatus `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *ControlMessage_GetPairingRulesResponse) Reset() {
+	*m = ControlMessage_GetPairingRulesResponse{}
+}
+func (m *ControlMessage_GetPairingRulesResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_GetPairingRulesResponse) ProtoMessage()    {}
+func (*ControlMessage_GetPairingRulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 29}
+}
+
+func (m *ControlMessage_GetPairingRulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_GetPairingRulesResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_GetPairingRulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_GetPairingRulesResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_GetPairingRulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_GetPairingRulesResponse.Merge(m, src)
+}
+func (m *ControlMessage_GetPairingRulesResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_GetPairingRulesResponse.Size(m)
+}
+func (m *ControlMessage_GetPairingRulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_GetPairingRulesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_GetPairingRulesResponse proto.InternalMessageInfo
+
+func (m *ControlMessage_GetPairingRulesResponse) GetRules() []*ControlMessage_PairingRuleStatus {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type ControlMessage_AddPairingRuleRequest struct {
+	Rule                 string   `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_AddPairingRuleRequest) Reset()         { *m = ControlMessage_AddPairingRuleRequest{} }
+func (m *ControlMessage_AddPairingRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_AddPairingRuleRequest) ProtoMessage()    {}
+func (*ControlMessage_AddPairingRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 30}
+}
+
+func (m *ControlMessage_AddPairingRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_AddPairingRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_AddPairingRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_AddPairingRuleRequest.Merge(m, src)
+}
+func (m *ControlMessage_AddPairingRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleRequest.Size(m)
+}
+func (m *ControlMessage_AddPairingRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_AddPairingRuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_AddPairingRuleRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_AddPairingRuleRequest) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+type ControlMessage_AddPairingRuleResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_AddPairingRuleResponse_Error
+	//	*ControlMessage_AddPairingRuleResponse_Ok_
+	Data                 isControlMessage_AddPairingRuleResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
+}
+
+func (m *ControlMessage_AddPairingRuleResponse) Reset()         { *m = ControlMessage_AddPairingRuleResponse{} }
+func (m *ControlMessage_AddPairingRuleResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_AddPairingRuleResponse) ProtoMessage()    {}
+func (*ControlMessage_AddPairingRuleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 31}
+}
+
+func (m *ControlMessage_AddPairingRuleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_AddPairingRuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_AddPairingRuleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_AddPairingRuleResponse.Merge(m, src)
+}
+func (m *ControlMessage_AddPairingRuleResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleResponse.Size(m)
+}
+func (m *ControlMessage_AddPairingRuleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_AddPairingRuleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_AddPairingRuleResponse proto.InternalMessageInfo
+
+type isControlMessage_AddPairingRuleResponse_Data interface {
+	isControlMessage_AddPairingRuleResponse_Data()
+}
+
+type ControlMessage_AddPairingRuleResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_AddPairingRuleResponse_Ok_ struct {
+	Ok *ControlMessage_AddPairingRuleResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_AddPairingRuleResponse_Error) isControlMessage_AddPairingRuleResponse_Data() {}
+
+func (*ControlMessage_AddPairingRuleResponse_Ok_) isControlMessage_AddPairingRuleResponse_Data() {}
+
+func (m *ControlMessage_AddPairingRuleResponse) GetData() isControlMessage_AddPairingRuleResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_AddPairingRuleResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_AddPairingRuleResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_AddPairingRuleResponse) GetOk() *ControlMessage_AddPairingRuleResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_AddPairingRuleResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_AddPairingRuleResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_AddPairingRuleResponse_Error)(nil),
+		(*ControlMessage_AddPairingRuleResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_AddPairingRuleResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_AddPairingRuleResponse_Ok) Reset() {
+	*m = ControlMessage_AddPairingRuleResponse_Ok{}
+}
+func (m *ControlMessage_AddPairingRuleResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_AddPairingRuleResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_AddPairingRuleResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 31, 0}
+}
+
+func (m *ControlMessage_AddPairingRuleResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_AddPairingRuleResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_AddPairingRuleResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_AddPairingRuleResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_AddPairingRuleResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_AddPairingRuleResponse_Ok.Size(m)
+}
+func (m *ControlMessage_AddPairingRuleResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_AddPairingRuleResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_AddPairingRuleResponse_Ok proto.InternalMessageInfo
+
+type ControlMessage_RemovePairingRuleRequest struct {
+	Rule                 string   `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_RemovePairingRuleRequest) Reset() {
+	*m = ControlMessage_RemovePairingRuleRequest{}
+}
+func (m *ControlMessage_RemovePairingRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RemovePairingRuleRequest) ProtoMessage()    {}
+func (*ControlMessage_RemovePairingRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 32}
+}
+
+func (m *ControlMessage_RemovePairingRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_RemovePairingRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RemovePairingRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RemovePairingRuleRequest.Merge(m, src)
+}
+func (m *ControlMessage_RemovePairingRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleRequest.Size(m)
+}
+func (m *ControlMessage_RemovePairingRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RemovePairingRuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RemovePairingRuleRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_RemovePairingRuleRequest) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+type ControlMessage_RemovePairingRuleResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_RemovePairingRuleResponse_Error
+	//	*ControlMessage_RemovePairingRuleResponse_Ok_
+	Data                 isControlMessage_RemovePairingRuleResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
+	XXX_unrecognized     []byte                                          `json:"-"`
+	XXX_sizecache        int32                                           `json:"-"`
+}
+
+func (m *ControlMessage_RemovePairingRuleResponse) Reset() {
+	*m = ControlMessage_RemovePairingRuleResponse{}
+}
+func (m *ControlMessage_RemovePairingRuleResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RemovePairingRuleResponse) ProtoMessage()    {}
+func (*ControlMessage_RemovePairingRuleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 33}
+}
+
+func (m *ControlMessage_RemovePairingRuleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_RemovePairingRuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RemovePairingRuleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RemovePairingRuleResponse.Merge(m, src)
+}
+func (m *ControlMessage_RemovePairingRuleResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleResponse.Size(m)
+}
+func (m *ControlMessage_RemovePairingRuleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RemovePairingRuleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RemovePairingRuleResponse proto.InternalMessageInfo
+
+type isControlMessage_RemovePairingRuleResponse_Data interface {
+	isControlMessage_RemovePairingRuleResponse_Data()
+}
+
+type ControlMessage_RemovePairingRuleResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_RemovePairingRuleResponse_Ok_ struct {
+	Ok *ControlMessage_RemovePairingRuleResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_RemovePairingRuleResponse_Error) isControlMessage_RemovePairingRuleResponse_Data() {
+}
+
+func (*ControlMessage_RemovePairingRuleResponse_Ok_) isControlMessage_RemovePairingRuleResponse_Data() {
+}
+
+func (m *ControlMessage_RemovePairingRuleResponse) GetData() isControlMessage_RemovePairingRuleResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_RemovePairingRuleResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_RemovePairingRuleResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_RemovePairingRuleResponse) GetOk() *ControlMessage_RemovePairingRuleResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_RemovePairingRuleResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_RemovePairingRuleResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_RemovePairingRuleResponse_Error)(nil),
+		(*ControlMessage_RemovePairingRuleResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_RemovePairingRuleResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_RemovePairingRuleResponse_Ok) Reset() {
+	*m = ControlMessage_RemovePairingRuleResponse_Ok{}
+}
+func (m *ControlMessage_RemovePairingRuleResponse_Ok) String() string {
+	return proto.CompactTextString(m)
+}
+func (*ControlMessage_RemovePairingRuleResponse_Ok) ProtoMessage() {}
+func (*ControlMessage_RemovePairingRuleResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 33, 0}
+}
+
+func (m *ControlMessage_RemovePairingRuleResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_RemovePairingRuleResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RemovePairingRuleResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RemovePairingRuleResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_RemovePairingRuleResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RemovePairingRuleResponse_Ok.Size(m)
+}
+func (m *ControlMessage_RemovePairingRuleResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RemovePairingRuleResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RemovePairingRuleResponse_Ok proto.InternalMessageInfo
+
+type ControlMessage_WatchRobotRequest struct {
+	RobotName            string   `protobuf:"bytes,1,opt,name=robotName,proto3" json:"robotName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_WatchRobotRequest) Reset()         { *m = ControlMessage_WatchRobotRequest{} }
+func (m *ControlMessage_WatchRobotRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_WatchRobotRequest) ProtoMessage()    {}
+func (*ControlMessage_WatchRobotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 34}
+}
+
+func (m *ControlMessage_WatchRobotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_WatchRobotRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_WatchRobotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_WatchRobotRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_WatchRobotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_WatchRobotRequest.Merge(m, src)
+}
+func (m *ControlMessage_WatchRobotRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_WatchRobotRequest.Size(m)
+}
+func (m *ControlMessage_WatchRobotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_WatchRobotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_WatchRobotRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_WatchRobotRequest) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+type ControlMessage_RobotTraffic struct {
+	Time       *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	ClientName string               `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	Dropped    uint64               `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	FromPolicy bool                 `protobuf:"varint,9,opt,name=fromPolicy,proto3" json:"fromPolicy,omitempty"`
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_RobotTraffic_Error
+	//	*ControlMessage_RobotTraffic_Bound_
+	//	*ControlMessage_RobotTraffic_Unbound_
+	//	*ControlMessage_RobotTraffic_SensorsData
+	//	*ControlMessage_RobotTraffic_Commands
+	Data                 isControlMessage_RobotTraffic_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *ControlMessage_RobotTraffic) Reset()         { *m = ControlMessage_RobotTraffic{} }
+func (m *ControlMessage_RobotTraffic) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RobotTraffic) ProtoMessage()    {}
+func (*ControlMessage_RobotTraffic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 35}
+}
+
+func (m *ControlMessage_RobotTraffic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RobotTraffic.Unmarshal(m, b)
+}
+func (m *ControlMessage_RobotTraffic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RobotTraffic.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RobotTraffic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RobotTraffic.Merge(m, src)
+}
+func (m *ControlMessage_RobotTraffic) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RobotTraffic.Size(m)
+}
+func (m *ControlMessage_RobotTraffic) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RobotTraffic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RobotTraffic proto.InternalMessageInfo
+
+func (m *ControlMessage_RobotTraffic) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *ControlMessage_RobotTraffic) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+func (m *ControlMessage_RobotTraffic) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+func (m *ControlMessage_RobotTraffic) GetFromPolicy() bool {
+	if m != nil {
+		return m.FromPolicy
+	}
+	return false
+}
+
+type isControlMessage_RobotTraffic_Data interface {
+	isControlMessage_RobotTraffic_Data()
+}
+
+type ControlMessage_RobotTraffic_Error struct {
+	Error string `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_RobotTraffic_Bound_ struct {
+	Bound *ControlMessage_RobotTraffic_Bound `protobuf:"bytes,5,opt,name=bound,proto3,oneof"`
+}
+
+type ControlMessage_RobotTraffic_Unbound_ struct {
+	Unbound *ControlMessage_RobotTraffic_Unbound `protobuf:"bytes,6,opt,name=unbound,proto3,oneof"`
+}
+
+type ControlMessage_RobotTraffic_SensorsData struct {
+	SensorsData *SensorsData `protobuf:"bytes,7,opt,name=sensorsData,proto3,oneof"`
+}
+
+type ControlMessage_RobotTraffic_Commands struct {
+	Commands *Commands `protobuf:"bytes,8,opt,name=commands,proto3,oneof"`
+}
+
+func (*ControlMessage_RobotTraffic_Error) isControlMessage_RobotTraffic_Data() {}
+
+func (*ControlMessage_RobotTraffic_Bound_) isControlMessage_RobotTraffic_Data() {}
+
+func (*ControlMessage_RobotTraffic_Unbound_) isControlMessage_RobotTraffic_Data() {}
+
+func (*ControlMessage_RobotTraffic_SensorsData) isControlMessage_RobotTraffic_Data() {}
+
+func (*ControlMessage_RobotTraffic_Commands) isControlMessage_RobotTraffic_Data() {}
+
+func (m *ControlMessage_RobotTraffic) GetData() isControlMessage_RobotTraffic_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_RobotTraffic) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_RobotTraffic_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_RobotTraffic) GetBound() *ControlMessage_RobotTraffic_Bound {
+	if x, ok := m.GetData().(*ControlMessage_RobotTraffic_Bound_); ok {
+		return x.Bound
+	}
+	return nil
+}
+
+func (m *ControlMessage_RobotTraffic) GetUnbound() *ControlMessage_RobotTraffic_Unbound {
+	if x, ok := m.GetData().(*ControlMessage_RobotTraffic_Unbound_); ok {
+		return x.Unbound
+	}
+	return nil
+}
+
+func (m *ControlMessage_RobotTraffic) GetSensorsData() *SensorsData {
+	if x, ok := m.GetData().(*ControlMessage_RobotTraffic_SensorsData); ok {
+		return x.SensorsData
+	}
+	return nil
+}
+
+func (m *ControlMessage_RobotTraffic) GetCommands() *Commands {
+	if x, ok := m.GetData().(*ControlMessage_RobotTraffic_Commands); ok {
+		return x.Commands
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_RobotTraffic) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_RobotTraffic_Error)(nil),
+		(*ControlMessage_RobotTraffic_Bound_)(nil),
+		(*ControlMessage_RobotTraffic_Unbound_)(nil),
+		(*ControlMessage_RobotTraffic_SensorsData)(nil),
+		(*ControlMessage_RobotTraffic_Commands)(nil),
+	}
+}
+
+type ControlMessage_RobotTraffic_Bound struct {
+	IsSync               bool     `protobuf:"varint,1,opt,name=isSync,proto3" json:"isSync,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_RobotTraffic_Bound) Reset()         { *m = ControlMessage_RobotTraffic_Bound{} }
+func (m *ControlMessage_RobotTraffic_Bound) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RobotTraffic_Bound) ProtoMessage()    {}
+func (*ControlMessage_RobotTraffic_Bound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 35, 0}
+}
+
+func (m *ControlMessage_RobotTraffic_Bound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RobotTraffic_Bound.Unmarshal(m, b)
+}
+func (m *ControlMessage_RobotTraffic_Bound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RobotTraffic_Bound.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RobotTraffic_Bound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RobotTraffic_Bound.Merge(m, src)
+}
+func (m *ControlMessage_RobotTraffic_Bound) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RobotTraffic_Bound.Size(m)
+}
+func (m *ControlMessage_RobotTraffic_Bound) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RobotTraffic_Bound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RobotTraffic_Bound proto.InternalMessageInfo
+
+func (m *ControlMessage_RobotTraffic_Bound) GetIsSync() bool {
+	if m != nil {
+		return m.IsSync
+	}
+	return false
+}
+
+type ControlMessage_RobotTraffic_Unbound struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_RobotTraffic_Unbound) Reset()         { *m = ControlMessage_RobotTraffic_Unbound{} }
+func (m *ControlMessage_RobotTraffic_Unbound) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_RobotTraffic_Unbound) ProtoMessage()    {}
+func (*ControlMessage_RobotTraffic_Unbound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 35, 1}
+}
+
+func (m *ControlMessage_RobotTraffic_Unbound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_RobotTraffic_Unbound.Unmarshal(m, b)
+}
+func (m *ControlMessage_RobotTraffic_Unbound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_RobotTraffic_Unbound.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_RobotTraffic_Unbound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_RobotTraffic_Unbound.Merge(m, src)
+}
+func (m *ControlMessage_RobotTraffic_Unbound) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_RobotTraffic_Unbound.Size(m)
+}
+func (m *ControlMessage_RobotTraffic_Unbound) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_RobotTraffic_Unbound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_RobotTraffic_Unbound proto.InternalMessageInfo
+
+type ControlMessage_ShutdownRequest struct {
+	Reason               string   `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_ShutdownRequest) Reset()         { *m = ControlMessage_ShutdownRequest{} }
+func (m *ControlMessage_ShutdownRequest) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_ShutdownRequest) ProtoMessage()    {}
+func (*ControlMessage_ShutdownRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 36}
+}
+
+func (m *ControlMessage_ShutdownRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_ShutdownRequest.Unmarshal(m, b)
+}
+func (m *ControlMessage_ShutdownRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_ShutdownRequest.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_ShutdownRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_ShutdownRequest.Merge(m, src)
+}
+func (m *ControlMessage_ShutdownRequest) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_ShutdownRequest.Size(m)
+}
+func (m *ControlMessage_ShutdownRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_ShutdownRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_ShutdownRequest proto.InternalMessageInfo
+
+func (m *ControlMessage_ShutdownRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ControlMessage_ShutdownResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*ControlMessage_ShutdownResponse_Error
+	//	*ControlMessage_ShutdownResponse_Ok_
+	Data                 isControlMessage_ShutdownResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
+	XXX_unrecognized     []byte                                 `json:"-"`
+	XXX_sizecache        int32                                  `json:"-"`
+}
+
+func (m *ControlMessage_ShutdownResponse) Reset()         { *m = ControlMessage_ShutdownResponse{} }
+func (m *ControlMessage_ShutdownResponse) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_ShutdownResponse) ProtoMessage()    {}
+func (*ControlMessage_ShutdownResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 37}
+}
+
+func (m *ControlMessage_ShutdownResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_ShutdownResponse.Unmarshal(m, b)
+}
+func (m *ControlMessage_ShutdownResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_ShutdownResponse.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_ShutdownResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_ShutdownResponse.Merge(m, src)
+}
+func (m *ControlMessage_ShutdownResponse) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_ShutdownResponse.Size(m)
+}
+func (m *ControlMessage_ShutdownResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_ShutdownResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_ShutdownResponse proto.InternalMessageInfo
+
+type isControlMessage_ShutdownResponse_Data interface {
+	isControlMessage_ShutdownResponse_Data()
+}
+
+type ControlMessage_ShutdownResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type ControlMessage_ShutdownResponse_Ok_ struct {
+	Ok *ControlMessage_ShutdownResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*ControlMessage_ShutdownResponse_Error) isControlMessage_ShutdownResponse_Data() {}
+
+func (*ControlMessage_ShutdownResponse_Ok_) isControlMessage_ShutdownResponse_Data() {}
+
+func (m *ControlMessage_ShutdownResponse) GetData() isControlMessage_ShutdownResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ControlMessage_ShutdownResponse) GetError() string {
+	if x, ok := m.GetData().(*ControlMessage_ShutdownResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage_ShutdownResponse) GetOk() *ControlMessage_ShutdownResponse_Ok {
+	if x, ok := m.GetData().(*ControlMessage_ShutdownResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControlMessage_ShutdownResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControlMessage_ShutdownResponse_Error)(nil),
+		(*ControlMessage_ShutdownResponse_Ok_)(nil),
+	}
+}
+
+type ControlMessage_ShutdownResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ControlMessage_ShutdownResponse_Ok) Reset()         { *m = ControlMessage_ShutdownResponse_Ok{} }
+func (m *ControlMessage_ShutdownResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ControlMessage_ShutdownResponse_Ok) ProtoMessage()    {}
+func (*ControlMessage_ShutdownResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0, 37, 0}
+}
+
+func (m *ControlMessage_ShutdownResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlMessage_ShutdownResponse_Ok.Unmarshal(m, b)
+}
+func (m *ControlMessage_ShutdownResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlMessage_ShutdownResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *ControlMessage_ShutdownResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlMessage_ShutdownResponse_Ok.Merge(m, src)
+}
+func (m *ControlMessage_ShutdownResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ControlMessage_ShutdownResponse_Ok.Size(m)
+}
+func (m *ControlMessage_ShutdownResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlMessage_ShutdownResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlMessage_ShutdownResponse_Ok proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("erebus.ControlMessage_SubscribeClientControllersMessage_EventType", ControlMessage_SubscribeClientControllersMessage_EventType_name, ControlMessage_SubscribeClientControllersMessage_EventType_value)
+	proto.RegisterEnum("erebus.ControlMessage_BrokerEvent_EventType", ControlMessage_BrokerEvent_EventType_name, ControlMessage_BrokerEvent_EventType_value)
+	proto.RegisterEnum("erebus.ControlMessage_CommandDiff_Kind", ControlMessage_CommandDiff_Kind_name, ControlMessage_CommandDiff_Kind_value)
+	proto.RegisterType((*ControlMessage)(nil), "erebus.ControlMessage")
+	proto.RegisterType((*ControlMessage_Arena)(nil), "erebus.ControlMessage.Arena")
+	proto.RegisterType((*ControlMessage_GetArenasResponse)(nil), "erebus.ControlMessage.GetArenasResponse")
+	proto.RegisterType((*ControlMessage_GetRobotsResponse)(nil), "erebus.ControlMessage.GetRobotsResponse")
+	proto.RegisterType((*ControlMessage_GetClientControllersResponse)(nil), "erebus.ControlMessage.GetClientControllersResponse")
+	proto.RegisterType((*ControlMessage_SubscribeClientControllersMessage)(nil), "erebus.ControlMessage.SubscribeClientControllersMessage")
+	proto.RegisterType((*ControlMessage_ConnectClientToRobotRequest)(nil), "erebus.ControlMessage.ConnectClientToRobotRequest")
+	proto.RegisterType((*ControlMessage_ConnectClientToRobotResponse)(nil), "erebus.ControlMessage.ConnectClientToRobotResponse")
+	proto.RegisterType((*ControlMessage_ConnectClientToRobotResponse_Ok)(nil), "erebus.ControlMessage.ConnectClientToRobotResponse.Ok")
+	proto.RegisterType((*ControlMessage_DisconnectClientFromRobotRequest)(nil), "erebus.ControlMessage.DisconnectClientFromRobotRequest")
+	proto.RegisterType((*ControlMessage_DisconnectClientFromRobotResponse)(nil), "erebus.ControlMessage.DisconnectClientFromRobotResponse")
+	proto.RegisterType((*ControlMessage_DisconnectClientFromRobotResponse_Ok)(nil), "erebus.ControlMessage.DisconnectClientFromRobotResponse.Ok")
+	proto.RegisterType((*ControlMessage_Connection)(nil), "erebus.ControlMessage.Connection")
+	proto.RegisterType((*ControlMessage_GetConnectionsResponse)(nil), "erebus.ControlMessage.GetConnectionsResponse")
+	proto.RegisterType((*ControlMessage_BrokerEvent)(nil), "erebus.ControlMessage.BrokerEvent")
+	proto.RegisterType((*ControlMessage_StartRecordingRequest)(nil), "erebus.ControlMessage.StartRecordingRequest")
+	proto.RegisterType((*ControlMessage_StartRecordingResponse)(nil), "erebus.ControlMessage.StartRecordingResponse")
+	proto.RegisterType((*ControlMessage_StartRecordingResponse_Ok)(nil), "erebus.ControlMessage.StartRecordingResponse.Ok")
+	proto.RegisterType((*ControlMessage_StopRecordingResponse)(nil), "erebus.ControlMessage.StopRecordingResponse")
+	proto.RegisterType((*ControlMessage_StopRecordingResponse_Ok)(nil), "erebus.ControlMessage.StopRecordingResponse.Ok")
+	proto.RegisterType((*ControlMessage_StartReplayRequest)(nil), "erebus.ControlMessage.StartReplayRequest")
+	proto.RegisterType((*ControlMessage_StartReplayResponse)(nil), "erebus.ControlMessage.StartReplayResponse")
+	proto.RegisterType((*ControlMessage_StartReplayResponse_Ok)(nil), "erebus.ControlMessage.StartReplayResponse.Ok")
+	proto.RegisterType((*ControlMessage_StepReplayRequest)(nil), "erebus.ControlMessage.StepReplayRequest")
+	proto.RegisterType((*ControlMessage_StepReplayResponse)(nil), "erebus.ControlMessage.StepReplayResponse")
+	proto.RegisterType((*ControlMessage_StepReplayResponse_Ok)(nil), "erebus.ControlMessage.StepReplayResponse.Ok")
+	proto.RegisterType((*ControlMessage_StopReplayRequest)(nil), "erebus.ControlMessage.StopReplayRequest")
+	proto.RegisterType((*ControlMessage_StopReplayResponse)(nil), "erebus.ControlMessage.StopReplayResponse")
+	proto.RegisterType((*ControlMessage_StopReplayResponse_Ok)(nil), "erebus.ControlMessage.StopReplayResponse.Ok")
+	proto.RegisterType((*ControlMessage_DescribeRobotRequest)(nil), "erebus.ControlMessage.DescribeRobotRequest")
+	proto.RegisterType((*ControlMessage_DescribeRobotResponse)(nil), "erebus.ControlMessage.DescribeRobotResponse")
+	proto.RegisterType((*ControlMessage_DescribeRobotResponse_Ok)(nil), "erebus.ControlMessage.DescribeRobotResponse.Ok")
+	proto.RegisterType((*ControlMessage_RegressRequest)(nil), "erebus.ControlMessage.RegressRequest")
+	proto.RegisterType((*ControlMessage_CommandDiff)(nil), "erebus.ControlMessage.CommandDiff")
+	proto.RegisterType((*ControlMessage_RegressionFrame)(nil), "erebus.ControlMessage.RegressionFrame")
+	proto.RegisterType((*ControlMessage_RegressResponse)(nil), "erebus.ControlMessage.RegressResponse")
+	proto.RegisterType((*ControlMessage_RegressResponse_Ok)(nil), "erebus.ControlMessage.RegressResponse.Ok")
+	proto.RegisterType((*ControlMessage_MatchStatus)(nil), "erebus.ControlMessage.MatchStatus")
+	proto.RegisterType((*ControlMessage_PairingRuleStatus)(nil), "erebus.ControlMessage.PairingRuleStatus")
+	proto.RegisterType((*ControlMessage_GetPairingRulesResponse)(nil), "erebus.ControlMessage.GetPairingRulesResponse")
+	proto.RegisterType((*ControlMessage_AddPairingRuleRequest)(nil), "erebus.ControlMessage.AddPairingRuleRequest")
+	proto.RegisterType((*ControlMessage_AddPairingRuleResponse)(nil), "erebus.ControlMessage.AddPairingRuleResponse")
+	proto.RegisterType((*ControlMessage_AddPairingRuleResponse_Ok)(nil), "erebus.ControlMessage.AddPairingRuleResponse.Ok")
+	proto.RegisterType((*ControlMessage_RemovePairingRuleRequest)(nil), "erebus.ControlMessage.RemovePairingRuleRequest")
+	proto.RegisterType((*ControlMessage_RemovePairingRuleResponse)(nil), "erebus.ControlMessage.RemovePairingRuleResponse")
+	proto.RegisterType((*ControlMessage_RemovePairingRuleResponse_Ok)(nil), "erebus.ControlMessage.RemovePairingRuleResponse.Ok")
+	proto.RegisterType((*ControlMessage_WatchRobotRequest)(nil), "erebus.ControlMessage.WatchRobotRequest")
+	proto.RegisterType((*ControlMessage_RobotTraffic)(nil), "erebus.ControlMessage.RobotTraffic")
+	proto.RegisterType((*ControlMessage_RobotTraffic_Bound)(nil), "erebus.ControlMessage.RobotTraffic.Bound")
+	proto.RegisterType((*ControlMessage_RobotTraffic_Unbound)(nil), "erebus.ControlMessage.RobotTraffic.Unbound")
+	proto.RegisterType((*ControlMessage_ShutdownRequest)(nil), "erebus.ControlMessage.ShutdownRequest")
+	proto.RegisterType((*ControlMessage_ShutdownResponse)(nil), "erebus.ControlMessage.ShutdownResponse")
+	proto.RegisterType((*ControlMessage_ShutdownResponse_Ok)(nil), "erebus.ControlMessage.ShutdownResponse.Ok")
+}
+
+func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
+
+var fileDescriptor_0c5120591600887d = []byte{
+	// 2228 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x72, 0x1b, 0xc7,
+	0x11, 0xe6, 0x82, 0xf8, 0x6d, 0x88, 0x24, 0x38, 0x92, 0x68, 0x78, 0xa3, 0x8a, 0x69, 0xc6, 0x91,
+	0x28, 0x89, 0x82, 0x18, 0x4a, 0x8e, 0x5c, 0x91, 0xcb, 0x29, 0x12, 0x84, 0x40, 0x58, 0x22, 0xa0,
+	0x1a, 0x80, 0xb6, 0x55, 0x2e, 0x97, 0xb2, 0xdc, 0x1d, 0x50, 0x6b, 0x02, 0x3b, 0xc8, 0xee, 0x82,
+	0x16, 0x73, 0x49, 0x2a, 0x27, 0x57, 0x0e, 0x3a, 0xa7, 0xf2, 0x02, 0xa9, 0x54, 0xe5, 0xe2, 0x4b,
+	0x1e, 0x21, 0x87, 0x1c, 0x72, 0xcf, 0x29, 0x87, 0xbc, 0x42, 0x1e, 0x20, 0x35, 0x3f, 0xfb, 0x0b,
+	0x2c, 0xb0, 0x50, 0x74, 0xdb, 0xe9, 0xed, 0xfe, 0xa6, 0x7f, 0x67, 0x7a, 0x1a, 0x56, 0x74, 0x6a,
+	0xb9, 0x36, 0x1d, 0xd4, 0x46, 0x36, 0x75, 0x29, 0xca, 0x13, 0x9b, 0x9c, 0x8e, 0x1d, 0xf5, 0x83,
+	0x33, 0x4a, 0xcf, 0x06, 0xe4, 0x3e, 0xa7, 0x9e, 0x8e, 0xfb, 0xf7, 0x5d, 0x73, 0x48, 0x1c, 0x57,
+	0x1b, 0x8e, 0x04, 0xa3, 0x5a, 0x76, 0x2f, 0x47, 0xc4, 0x91, 0x8b, 0x92, 0x63, 0x0e, 0xc5, 0xe7,
+	0xd6, 0x7f, 0x76, 0x60, 0xb5, 0x2e, 0x20, 0x8f, 0x89, 0xe3, 0x68, 0x67, 0x44, 0x25, 0x90, 0xdb,
+	0xb7, 0x89, 0xa5, 0x21, 0x04, 0x59, 0x4b, 0x1b, 0x92, 0xaa, 0xb2, 0xa9, 0x6c, 0x97, 0x30, 0xff,
+	0x46, 0x2a, 0x14, 0x05, 0x34, 0x19, 0x55, 0x33, 0x9b, 0xca, 0x76, 0x0e, 0xfb, 0x6b, 0xb4, 0x03,
+	0x45, 0xc7, 0x1c, 0x76, 0x5d, 0xcd, 0x25, 0xd5, 0xe5, 0x4d, 0x65, 0xbb, 0xbc, 0x57, 0xa9, 0x09,
+	0xfd, 0x6a, 0x5d, 0x49, 0xc7, 0x3e, 0x87, 0xda, 0x82, 0xf5, 0x26, 0x71, 0xf9, 0x4e, 0x0e, 0x26,
+	0xce, 0x88, 0x5a, 0x0e, 0x41, 0x0f, 0x21, 0xaf, 0x71, 0x4a, 0x55, 0xd9, 0x5c, 0xde, 0x2e, 0xef,
+	0xdd, 0xf0, 0x00, 0xa2, 0x3a, 0xd6, 0xb8, 0x18, 0x96, 0xbc, 0xea, 0x03, 0x0e, 0x85, 0xe9, 0x29,
+	0x75, 0x03, 0xa8, 0x1f, 0x03, 0xd8, 0x8c, 0xd2, 0xd6, 0x86, 0x44, 0xc0, 0x95, 0x70, 0x88, 0xa2,
+	0x1e, 0xc1, 0x8d, 0x26, 0x71, 0xeb, 0x03, 0x93, 0x58, 0xae, 0x44, 0x1f, 0x10, 0x3b, 0x90, 0xdf,
+	0x86, 0x35, 0xdd, 0x27, 0x87, 0x41, 0xe2, 0x64, 0xf5, 0xdf, 0x0a, 0x7c, 0xd8, 0x1d, 0x9f, 0x3a,
+	0xba, 0x6d, 0x9e, 0x92, 0x09, 0x40, 0xa9, 0x32, 0xfa, 0x15, 0x94, 0xc8, 0x05, 0xb1, 0xdc, 0xde,
+	0xe5, 0x48, 0xb8, 0x74, 0x75, 0xef, 0x20, 0xc1, 0xba, 0xb9, 0x60, 0xb5, 0x86, 0x87, 0x84, 0x03,
+	0x50, 0x74, 0x13, 0x56, 0xa3, 0xaa, 0xf1, 0x08, 0x95, 0x70, 0x8c, 0xba, 0xb5, 0x0b, 0x25, 0x5f,
+	0x1e, 0x95, 0xa1, 0x70, 0xd2, 0x7e, 0xda, 0xee, 0x7c, 0xd9, 0xae, 0x2c, 0x21, 0x80, 0xfc, 0xe7,
+	0x9d, 0x56, 0xbb, 0x71, 0x58, 0x51, 0xd8, 0xf7, 0xf3, 0x7d, 0xdc, 0x6b, 0x1c, 0x56, 0x32, 0xea,
+	0xd7, 0xf0, 0xa3, 0x3a, 0xb5, 0x2c, 0xa2, 0x4b, 0x7f, 0xf5, 0x28, 0x77, 0x36, 0x26, 0xbf, 0x1e,
+	0x13, 0xc7, 0x65, 0xae, 0xd6, 0x39, 0xbd, 0x1d, 0xa4, 0x4b, 0x88, 0x82, 0x6e, 0x40, 0xc9, 0x77,
+	0xbc, 0xd4, 0x29, 0x20, 0xa8, 0x6f, 0x14, 0xb8, 0x31, 0x1d, 0x5d, 0x46, 0x62, 0x03, 0x72, 0xc4,
+	0xb6, 0xa9, 0x2d, 0x90, 0x8f, 0x96, 0xb0, 0x58, 0xa2, 0x23, 0xc8, 0xd0, 0x73, 0x8e, 0x57, 0xde,
+	0xfb, 0x79, 0x82, 0x2b, 0x67, 0x01, 0xd7, 0x3a, 0xe7, 0x47, 0x4b, 0x38, 0x43, 0xcf, 0xd5, 0x2c,
+	0x64, 0x3a, 0xe7, 0x07, 0x79, 0xc8, 0x1a, 0x9a, 0xab, 0xa9, 0x07, 0xb0, 0x79, 0x68, 0x3a, 0x7a,
+	0x58, 0xf2, 0x89, 0x4d, 0x87, 0x8b, 0x98, 0xac, 0xfe, 0x51, 0x81, 0x0f, 0x67, 0x80, 0xcc, 0xb1,
+	0xec, 0x38, 0x64, 0xd9, 0xe3, 0x04, 0xcb, 0xe6, 0xa2, 0x27, 0x99, 0xf7, 0x0f, 0x05, 0x40, 0xba,
+	0xc5, 0xa4, 0xd6, 0xff, 0x17, 0x3c, 0xb4, 0x01, 0x79, 0xd3, 0xe9, 0x5e, 0x5a, 0x3a, 0xaf, 0xf8,
+	0x22, 0x96, 0x2b, 0xf4, 0x0b, 0x80, 0x53, 0x3a, 0xb6, 0x8c, 0xae, 0x69, 0xe9, 0xa4, 0x9a, 0xe5,
+	0x96, 0xa8, 0x35, 0x71, 0x4a, 0xd5, 0xbc, 0x53, 0xaa, 0xd6, 0xf3, 0x4e, 0x29, 0x1c, 0xe2, 0x46,
+	0x77, 0xa0, 0x62, 0x93, 0x6f, 0x89, 0xee, 0x12, 0xa3, 0x4e, 0x87, 0x43, 0xcd, 0x32, 0x9c, 0x6a,
+	0x6e, 0x53, 0xd9, 0xce, 0xe2, 0x09, 0xba, 0xfa, 0x0d, 0x6c, 0xb0, 0x2a, 0xf6, 0xcd, 0x09, 0xea,
+	0xb7, 0x0e, 0x65, 0x3d, 0x20, 0xcb, 0xf3, 0xe4, 0xc3, 0xd9, 0x69, 0x62, 0x52, 0x0b, 0x87, 0xa5,
+	0xd4, 0xbf, 0x2f, 0x43, 0xf9, 0xc0, 0xa6, 0xe7, 0xc4, 0xe6, 0x15, 0x83, 0x3e, 0x9f, 0x2c, 0xe2,
+	0x9d, 0x04, 0xc8, 0x90, 0xd8, 0xf4, 0x72, 0xad, 0x41, 0xd6, 0x35, 0xa5, 0x4f, 0x67, 0x3b, 0x87,
+	0xf3, 0x45, 0x03, 0xb1, 0x1c, 0x0f, 0x44, 0x34, 0x8c, 0xd9, 0x89, 0x30, 0x86, 0x0f, 0xe7, 0xdc,
+	0xbc, 0xc3, 0x79, 0xeb, 0x9f, 0x4a, 0xe2, 0x19, 0x71, 0x0d, 0x2a, 0xb8, 0x73, 0xd0, 0xe9, 0xbd,
+	0xc4, 0x8d, 0x66, 0xab, 0xdb, 0x6b, 0x60, 0x7e, 0x5a, 0x6c, 0x00, 0x12, 0xd4, 0x93, 0x76, 0x88,
+	0x9e, 0x41, 0xd7, 0x61, 0xbd, 0xfe, 0xac, 0xd5, 0x68, 0x47, 0xd8, 0x97, 0xd1, 0x7b, 0x70, 0x55,
+	0x92, 0x23, 0xfc, 0x59, 0x86, 0x5e, 0xef, 0xb4, 0xdb, 0x8d, 0x7a, 0xaf, 0xd5, 0x69, 0xbf, 0x3c,
+	0xe8, 0x9c, 0xb4, 0x0f, 0x2b, 0x39, 0x86, 0x1e, 0xa2, 0x9e, 0xb4, 0x05, 0x3d, 0xcf, 0xd0, 0xbb,
+	0xad, 0xe3, 0x97, 0xdd, 0xde, 0x7e, 0xaf, 0xf1, 0xb2, 0x7e, 0xb4, 0xdf, 0x6e, 0x36, 0x0e, 0x2b,
+	0x05, 0xb4, 0x0e, 0x2b, 0xdd, 0xa3, 0x93, 0x5e, 0xaf, 0xd5, 0x6e, 0xbe, 0x3c, 0x64, 0x5a, 0x17,
+	0xd5, 0x8f, 0xe1, 0x7a, 0xd7, 0xd5, 0x6c, 0x17, 0x13, 0x9d, 0xda, 0x86, 0x69, 0x9d, 0x79, 0x85,
+	0x7c, 0x03, 0x4a, 0x86, 0x69, 0x13, 0xdd, 0xa5, 0xf6, 0xa5, 0xcc, 0xfe, 0x80, 0xa0, 0xfe, 0x5e,
+	0x81, 0x8d, 0xb8, 0xdc, 0x9c, 0xda, 0x3d, 0x08, 0xd5, 0xee, 0x6e, 0xd2, 0x01, 0x3f, 0x15, 0x32,
+	0xa9, 0x60, 0x7f, 0xa7, 0x30, 0xe5, 0xe9, 0x28, 0xbd, 0x0e, 0xfb, 0x21, 0x1d, 0xee, 0x27, 0xea,
+	0x40, 0x47, 0xa9, 0x55, 0xf8, 0x83, 0x02, 0x48, 0x2a, 0x3d, 0x1a, 0x68, 0x97, 0x9e, 0xf3, 0x3e,
+	0x82, 0x15, 0xdb, 0x83, 0x78, 0xae, 0xb9, 0xaf, 0xa4, 0x03, 0xa3, 0xc4, 0x39, 0x27, 0xc8, 0x35,
+	0xc8, 0x39, 0x23, 0x42, 0x0c, 0x9e, 0xd2, 0x0a, 0x16, 0x0b, 0xd6, 0x67, 0x38, 0x2e, 0x19, 0x1d,
+	0x53, 0x43, 0x24, 0x73, 0x11, 0xfb, 0x6b, 0xf5, 0x4f, 0x0a, 0x5c, 0x8d, 0x28, 0x33, 0xc7, 0x1b,
+	0xbf, 0x0c, 0x79, 0xe3, 0xde, 0xec, 0x88, 0x84, 0xf1, 0x02, 0x5f, 0x6c, 0x31, 0x5f, 0x44, 0xcd,
+	0x50, 0x62, 0x66, 0xf8, 0x9e, 0x6a, 0xc1, 0x7a, 0xd7, 0x25, 0xa3, 0xa8, 0x9f, 0x66, 0x8a, 0xb2,
+	0x33, 0xb4, 0x6f, 0xf3, 0x06, 0x43, 0x74, 0x54, 0x72, 0xa5, 0xfe, 0x06, 0x50, 0x18, 0x6a, 0x8e,
+	0x95, 0x9f, 0x85, 0xac, 0xdc, 0x49, 0xb4, 0x92, 0x8c, 0x92, 0x8c, 0x8c, 0x06, 0xfc, 0x67, 0xb0,
+	0x2e, 0x12, 0x24, 0xb5, 0x19, 0x42, 0x5d, 0xfa, 0x6e, 0xd5, 0xa5, 0x29, 0xd5, 0x7d, 0x08, 0xd7,
+	0x0e, 0x89, 0xe8, 0x99, 0x22, 0xd7, 0xf4, 0x6c, 0x8d, 0x7f, 0x50, 0xe0, 0x7a, 0x4c, 0xec, 0x1d,
+	0x14, 0xd6, 0x54, 0xc4, 0x40, 0xf1, 0x8f, 0x79, 0x32, 0xdd, 0x97, 0x8a, 0xb5, 0xac, 0x3e, 0xe5,
+	0x9b, 0x94, 0xf7, 0xd6, 0x3d, 0x3c, 0xec, 0xfd, 0xc0, 0x01, 0x8f, 0x6f, 0xe9, 0x7f, 0x15, 0x58,
+	0xc5, 0xe4, 0xcc, 0x26, 0x8e, 0xb3, 0x58, 0x15, 0x46, 0x2f, 0x88, 0xcc, 0xec, 0x7b, 0x7e, 0xe2,
+	0x7a, 0xf9, 0x08, 0x56, 0x04, 0x2f, 0xbb, 0x95, 0xe8, 0xd8, 0xe5, 0x45, 0xa9, 0xe0, 0x28, 0x11,
+	0xed, 0xc0, 0xfa, 0x05, 0x19, 0x50, 0xdd, 0x74, 0x2f, 0x7b, 0x74, 0x40, 0x6c, 0xcd, 0xd2, 0xc5,
+	0x6d, 0xa3, 0xe0, 0xc9, 0x1f, 0xec, 0x9e, 0x1f, 0x68, 0x2e, 0xb1, 0xf4, 0x10, 0x73, 0x9e, 0x33,
+	0x4f, 0xd0, 0xd5, 0xbf, 0x66, 0xa0, 0x2c, 0x2f, 0xfd, 0x43, 0xb3, 0xdf, 0x47, 0x8f, 0x21, 0x7b,
+	0x6e, 0x5a, 0x86, 0xbc, 0x83, 0x6f, 0x25, 0x5e, 0xeb, 0xbe, 0x44, 0xed, 0xa9, 0x69, 0x19, 0x98,
+	0x0b, 0xb1, 0x82, 0x33, 0xc8, 0x85, 0xa9, 0x7b, 0x6e, 0x90, 0x2b, 0x74, 0x17, 0x8a, 0xe4, 0xf5,
+	0x88, 0x37, 0x18, 0xf2, 0x01, 0xb3, 0x16, 0x00, 0x73, 0x24, 0xec, 0x33, 0xa0, 0x5b, 0x90, 0xd7,
+	0x74, 0x77, 0xac, 0x0d, 0xaa, 0xd9, 0xe9, 0xac, 0xf2, 0x37, 0x73, 0x9d, 0x67, 0xfb, 0x21, 0x19,
+	0xb8, 0x9a, 0x74, 0x48, 0x94, 0xb8, 0xf5, 0x0c, 0xb2, 0x4c, 0xc3, 0xe8, 0x5d, 0x5b, 0x86, 0xc2,
+	0x71, 0xab, 0xdb, 0x6d, 0xb5, 0x9b, 0x15, 0x05, 0x95, 0x20, 0xd7, 0xf8, 0xaa, 0x87, 0xf7, 0x2b,
+	0x19, 0x74, 0x05, 0x8a, 0x5f, 0x34, 0x9e, 0x75, 0xea, 0xad, 0xde, 0x8b, 0xca, 0x32, 0x2a, 0xc0,
+	0xf2, 0x33, 0x7e, 0x79, 0x16, 0x21, 0xdb, 0x7b, 0xf1, 0xbc, 0x51, 0xc9, 0xa9, 0x7f, 0xc9, 0xc0,
+	0x9a, 0xcc, 0x12, 0x93, 0x5a, 0x4f, 0x6c, 0x79, 0xd0, 0x9a, 0x96, 0x41, 0x5e, 0x73, 0x9f, 0xe5,
+	0xb0, 0x58, 0xb0, 0xb0, 0xfb, 0x6f, 0x45, 0xee, 0x0e, 0x05, 0x07, 0x04, 0xb4, 0x09, 0xe5, 0xa1,
+	0xe9, 0x38, 0xc4, 0x60, 0x65, 0x78, 0x29, 0x7b, 0xbc, 0x30, 0x89, 0x3d, 0x93, 0x3c, 0x97, 0x3c,
+	0x13, 0x41, 0x93, 0xa9, 0x11, 0x27, 0x33, 0x3f, 0x08, 0x8f, 0x78, 0x7c, 0xd2, 0x0f, 0x11, 0x22,
+	0xc3, 0x93, 0xc1, 0x6f, 0xbc, 0xd6, 0x09, 0x31, 0x88, 0xc1, 0x73, 0xa2, 0x88, 0xe3, 0x64, 0xf4,
+	0x04, 0xae, 0xe8, 0x41, 0x7c, 0x9d, 0x6a, 0x81, 0x77, 0x78, 0x5b, 0xf3, 0x53, 0x01, 0x47, 0xe4,
+	0xd4, 0x3f, 0x2f, 0xfb, 0xbe, 0x9a, 0x5b, 0xff, 0x8f, 0x43, 0xf5, 0x7f, 0x3b, 0x61, 0xa7, 0x18,
+	0x56, 0x50, 0xf9, 0x7f, 0xcb, 0xcc, 0xbf, 0x47, 0x92, 0x2e, 0x03, 0x74, 0x00, 0xc5, 0xbe, 0x66,
+	0x0e, 0xc6, 0x36, 0x71, 0xaa, 0xcb, 0xdc, 0xd2, 0x9b, 0xb3, 0xf7, 0xf7, 0xe2, 0x8e, 0x7d, 0x39,
+	0x56, 0x70, 0x43, 0xed, 0xf5, 0x17, 0x91, 0x64, 0x14, 0xc1, 0x9a, 0xa0, 0xa3, 0x5d, 0xb8, 0x3a,
+	0x24, 0x9a, 0xd5, 0x88, 0xc5, 0x56, 0xc4, 0x6c, 0xda, 0x2f, 0x56, 0xfc, 0x8c, 0xbc, 0x1f, 0x89,
+	0xb1, 0xa8, 0xe7, 0xc9, 0x1f, 0x52, 0x97, 0x28, 0x73, 0xc1, 0xd7, 0x25, 0x42, 0xf7, 0xcf, 0xbe,
+	0x1f, 0x14, 0x28, 0x1f, 0x6b, 0xae, 0xfe, 0x8a, 0x35, 0xa9, 0x63, 0x87, 0x35, 0x09, 0xc6, 0xd8,
+	0xd6, 0x58, 0xab, 0xce, 0x1d, 0xa9, 0x60, 0x7f, 0x8d, 0xaa, 0x50, 0x20, 0x03, 0x6d, 0xe4, 0x10,
+	0x43, 0x66, 0xb5, 0xb7, 0xe4, 0xfe, 0x27, 0x43, 0xcd, 0xb4, 0x4c, 0xeb, 0x4c, 0x36, 0x1d, 0x01,
+	0x81, 0xc9, 0xd9, 0x63, 0x8b, 0xff, 0x13, 0x7d, 0x87, 0xb7, 0xe4, 0x88, 0xaf, 0x47, 0xa6, 0x4d,
+	0x0c, 0xee, 0x85, 0x22, 0xf6, 0x96, 0x4c, 0x0f, 0xc7, 0x1c, 0xb2, 0x43, 0xd0, 0x4b, 0x56, 0x7f,
+	0xad, 0x7e, 0xaf, 0xc0, 0xfa, 0x73, 0xcd, 0xb4, 0x59, 0x93, 0x35, 0x1e, 0x10, 0xa9, 0x39, 0x82,
+	0xac, 0x3d, 0x1e, 0xf8, 0xa3, 0x15, 0xf6, 0x8d, 0x1e, 0x41, 0x6e, 0xa4, 0x99, 0x36, 0x0b, 0x7c,
+	0xca, 0xa7, 0x8a, 0xe0, 0x67, 0xef, 0xfe, 0xef, 0x34, 0xd3, 0x35, 0xad, 0x33, 0xf1, 0x12, 0x14,
+	0x09, 0x52, 0xc2, 0x31, 0xaa, 0xfa, 0x02, 0xde, 0x6b, 0x12, 0x37, 0xa4, 0x4c, 0x90, 0xef, 0x9f,
+	0x41, 0x8e, 0xe9, 0xe0, 0x3d, 0x93, 0xb6, 0x13, 0xf6, 0x9e, 0x30, 0x04, 0x0b, 0x31, 0xf5, 0x2e,
+	0x5c, 0xdf, 0x37, 0x8c, 0xd0, 0x6f, 0xef, 0x6e, 0x9a, 0x62, 0x28, 0x6f, 0xaa, 0xe3, 0xdc, 0xef,
+	0xa0, 0xa9, 0x9e, 0x0e, 0x99, 0xd4, 0x31, 0xd4, 0xa0, 0x8a, 0xc9, 0x90, 0x5e, 0x90, 0x94, 0x4a,
+	0x7f, 0xaf, 0xc0, 0xfb, 0x53, 0x04, 0xe6, 0xe8, 0xdd, 0x08, 0xe9, 0xfd, 0x20, 0xb1, 0x5e, 0x13,
+	0x50, 0x67, 0xf4, 0x66, 0x5f, 0xb2, 0x2a, 0x58, 0xa0, 0xd3, 0xf9, 0xd7, 0x32, 0x5c, 0xe1, 0xec,
+	0x3d, 0x5b, 0xeb, 0xf7, 0x4d, 0xdd, 0x7f, 0x7c, 0x2a, 0x29, 0x1f, 0x9f, 0xf3, 0xba, 0x87, 0x2a,
+	0x14, 0x0c, 0x9b, 0x8e, 0x46, 0xf2, 0xe6, 0xcc, 0x62, 0x6f, 0xc9, 0x24, 0xfb, 0x36, 0x1d, 0x3e,
+	0xa7, 0x03, 0x53, 0xbf, 0xac, 0x96, 0x78, 0x79, 0x84, 0x28, 0x81, 0xeb, 0xb2, 0xf1, 0x56, 0x2b,
+	0xc7, 0x67, 0x02, 0xd5, 0xdc, 0xec, 0xd3, 0x36, 0x64, 0x55, 0xed, 0x80, 0x09, 0x30, 0x08, 0x2e,
+	0x89, 0x9a, 0x50, 0x18, 0x5b, 0x02, 0x24, 0xcf, 0x41, 0xee, 0xa6, 0x01, 0x39, 0x11, 0x22, 0x47,
+	0x4b, 0xd8, 0x93, 0x46, 0x8f, 0xa0, 0xec, 0x10, 0xcb, 0xa1, 0xb6, 0x73, 0xa8, 0xb9, 0x1a, 0x3f,
+	0xa7, 0xca, 0x7b, 0x57, 0xfd, 0xf7, 0x73, 0xf0, 0xeb, 0x68, 0x09, 0x87, 0x39, 0x51, 0x0d, 0x8a,
+	0xba, 0x37, 0xc2, 0x28, 0x46, 0x5f, 0xdd, 0xde, 0x08, 0xe3, 0x68, 0x09, 0xfb, 0x3c, 0xea, 0x07,
+	0x90, 0xe3, 0x36, 0x84, 0xe6, 0x2a, 0x4a, 0x78, 0xae, 0xa2, 0x96, 0xa0, 0x20, 0xf5, 0xf3, 0xd3,
+	0xe1, 0x36, 0xac, 0x75, 0x5f, 0x8d, 0x5d, 0x83, 0x7e, 0x67, 0x79, 0xc9, 0xb0, 0x01, 0x79, 0x9b,
+	0x68, 0x8e, 0x3c, 0x16, 0x4b, 0x58, 0xae, 0xd4, 0x0b, 0xa8, 0x04, 0xac, 0x73, 0x52, 0xf7, 0xd3,
+	0x50, 0xea, 0xde, 0x49, 0x6a, 0xd0, 0x63, 0x60, 0x09, 0x19, 0xbb, 0xf7, 0x66, 0x1d, 0x0a, 0x52,
+	0x14, 0xd5, 0xa1, 0xe4, 0xcf, 0x7d, 0xd1, 0x15, 0x0f, 0xb8, 0x3d, 0x1e, 0x0c, 0xd4, 0xa4, 0x63,
+	0x67, 0x72, 0x4e, 0x2c, 0x40, 0x78, 0xd8, 0x16, 0x00, 0x89, 0x4d, 0x88, 0xbf, 0x85, 0x95, 0x48,
+	0xaf, 0x8e, 0xee, 0xa6, 0xeb, 0xe8, 0xb9, 0x8f, 0xd5, 0x9d, 0x45, 0xda, 0x7f, 0xf4, 0x02, 0xae,
+	0x4d, 0x9b, 0x36, 0xc7, 0x74, 0x7f, 0x90, 0xac, 0x7b, 0xf2, 0xa0, 0xba, 0x0f, 0x6a, 0xf2, 0xc0,
+	0x38, 0xb6, 0xc1, 0x27, 0x6f, 0x3b, 0x71, 0xde, 0x55, 0xd0, 0x43, 0x40, 0x4d, 0xe2, 0x76, 0xcd,
+	0xe1, 0x78, 0xc0, 0xaf, 0x58, 0x3e, 0x29, 0x8a, 0xe1, 0x4f, 0xcc, 0x94, 0xd0, 0xa7, 0x50, 0xf5,
+	0xc1, 0x17, 0x94, 0x15, 0x7b, 0x76, 0x27, 0xf7, 0x9c, 0xe0, 0x54, 0x23, 0x48, 0xe8, 0x00, 0x56,
+	0x9b, 0xc4, 0x0d, 0x77, 0x0a, 0xd1, 0x9d, 0x92, 0x7a, 0xc4, 0xb0, 0xc4, 0x6f, 0xe1, 0xda, 0xb4,
+	0xd9, 0x31, 0xda, 0x5b, 0x68, 0xd0, 0x2c, 0x52, 0xe5, 0xc1, 0x5b, 0x0c, 0xa7, 0xd1, 0x1b, 0x05,
+	0xde, 0x4f, 0x9c, 0xf1, 0xa2, 0x47, 0x8b, 0x4f, 0x85, 0x85, 0x2e, 0x9f, 0xbc, 0xed, 0x38, 0x19,
+	0x1d, 0x73, 0xaf, 0x86, 0x46, 0xad, 0x31, 0xaf, 0xde, 0x9b, 0x91, 0xbc, 0x53, 0xe6, 0xb3, 0x0d,
+	0x58, 0xf3, 0x13, 0x83, 0x8f, 0x1a, 0xd3, 0x46, 0x29, 0x34, 0x58, 0xdd, 0x55, 0xd0, 0x10, 0x56,
+	0xa3, 0xd3, 0x34, 0xb4, 0x93, 0x72, 0xe8, 0x26, 0xfc, 0x71, 0x6f, 0xa1, 0x11, 0x1d, 0x7a, 0x0a,
+	0x2b, 0x91, 0xc1, 0x59, 0x4c, 0xe7, 0x9d, 0x45, 0x86, 0x6d, 0xc8, 0x80, 0x72, 0x68, 0xee, 0x84,
+	0x6e, 0xa7, 0x99, 0x4d, 0x09, 0xad, 0xef, 0xa4, 0x1f, 0x63, 0x21, 0x0d, 0x20, 0x98, 0xfb, 0xa0,
+	0xed, 0x14, 0xa3, 0x21, 0xb1, 0xc7, 0xed, 0xd4, 0x43, 0x24, 0xb1, 0x05, 0x9d, 0xbf, 0x05, 0x4d,
+	0xbd, 0xc5, 0xc4, 0x1c, 0xe9, 0x2b, 0x28, 0xc8, 0x87, 0x0d, 0xfa, 0xe9, 0xbc, 0x87, 0x97, 0x00,
+	0xbf, 0x99, 0xee, 0x7d, 0x86, 0x3a, 0xb0, 0x16, 0x6b, 0x8b, 0x63, 0x41, 0xad, 0x25, 0x27, 0xf6,
+	0xd4, 0x66, 0x7a, 0x08, 0xab, 0xd1, 0x5e, 0x34, 0x31, 0x25, 0xa7, 0xf6, 0xcc, 0xea, 0xbd, 0x94,
+	0xdc, 0x72, 0xbb, 0x0b, 0x58, 0x9f, 0x68, 0x21, 0xd1, 0xfd, 0xf4, 0xcd, 0xa6, 0xd8, 0x74, 0x77,
+	0xd1, 0xee, 0x14, 0x7d, 0x03, 0x10, 0xb4, 0xa1, 0x89, 0x41, 0x9f, 0xe8, 0x54, 0xd5, 0x9f, 0xa4,
+	0x68, 0xc2, 0x76, 0x15, 0xf4, 0x35, 0x14, 0xbd, 0xf6, 0x02, 0xdd, 0x9c, 0xdb, 0x7f, 0x08, 0xe8,
+	0x5b, 0x29, 0xfb, 0x94, 0xd3, 0x3c, 0x6f, 0x74, 0x1f, 0xfc, 0x6f, 0x00, 0x24, 0x28, 0x27, 0xc8,
+	0x4f, 0x1f, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ControlClient is the client API for Control service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControlClient interface {
+	GetArenas(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetArenasResponse, error)
+	GetRobots(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetRobotsResponse, error)
+	DescribeRobot(ctx context.Context, in *ControlMessage_DescribeRobotRequest, opts ...grpc.CallOption) (*ControlMessage_DescribeRobotResponse, error)
+	GetClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetClientControllersResponse, error)
+	SubscribeClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeClientControllersClient, error)
+	GetSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (*SimState, error)
+	SubscribeSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeSimulationStateClient, error)
+	SetSimulationState(ctx context.Context, in *SimState, opts ...grpc.CallOption) (*Null, error)
+	GetMatchStatus(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_MatchStatus, error)
+	ConnectClientToRobot(ctx context.Context, in *ControlMessage_ConnectClientToRobotRequest, opts ...grpc.CallOption) (*ControlMessage_ConnectClientToRobotResponse, error)
+	DisconnectClientFromRobot(ctx context.Context, in *ControlMessage_DisconnectClientFromRobotRequest, opts ...grpc.CallOption) (*ControlMessage_DisconnectClientFromRobotResponse, error)
+	GetConnections(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetConnectionsResponse, error)
+	SubscribeEvents(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeEventsClient, error)
+	StartRecording(ctx context.Context, in *ControlMessage_StartRecordingRequest, opts ...grpc.CallOption) (*ControlMessage_StartRecordingResponse, error)
+	StopRecording(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_StopRecordingResponse, error)
+	StartReplay(ctx context.Context, in *ControlMessage_StartReplayRequest, opts ...grpc.CallOption) (*ControlMessage_StartReplayResponse, error)
+	StepReplay(ctx context.Context, in *ControlMessage_StepReplayRequest, opts ...grpc.CallOption) (*ControlMessage_StepReplayResponse, error)
+	StopReplay(ctx context.Context, in *ControlMessage_StopReplayRequest, opts ...grpc.CallOption) (*ControlMessage_StopReplayResponse, error)
+	Regress(ctx context.Context, in *ControlMessage_RegressRequest, opts ...grpc.CallOption) (*ControlMessage_RegressResponse, error)
+	GetPairingRules(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetPairingRulesResponse, error)
+	AddPairingRule(ctx context.Context, in *ControlMessage_AddPairingRuleRequest, opts ...grpc.CallOption) (*ControlMessage_AddPairingRuleResponse, error)
+	RemovePairingRule(ctx context.Context, in *ControlMessage_RemovePairingRuleRequest, opts ...grpc.CallOption) (*ControlMessage_RemovePairingRuleResponse, error)
+	WatchRobot(ctx context.Context, in *ControlMessage_WatchRobotRequest, opts ...grpc.CallOption) (Control_WatchRobotClient, error)
+	Shutdown(ctx context.Context, in *ControlMessage_ShutdownRequest, opts ...grpc.CallOption) (*ControlMessage_ShutdownResponse, error)
+}
+
+type controlClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewControlClient(cc grpc.ClientConnInterface) ControlClient {
+	return &controlClient{cc}
+}
+
+func (c *controlClient) GetArenas(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetArenasResponse, error) {
+	out := new(ControlMessage_GetArenasResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/GetArenas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetRobots(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetRobotsResponse, error) {
+	out := new(ControlMessage_GetRobotsResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/GetRobots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) DescribeRobot(ctx context.Context, in *ControlMessage_DescribeRobotRequest, opts ...grpc.CallOption) (*ControlMessage_DescribeRobotResponse, error) {
+	out := new(ControlMessage_DescribeRobotResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/DescribeRobot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetClientControllersResponse, error) {
+	out := new(ControlMessage_GetClientControllersResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/GetClientControllers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SubscribeClientControllers(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeClientControllersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[0], "/erebus.Control/SubscribeClientControllers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlSubscribeClientControllersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_SubscribeClientControllersClient interface {
+	Recv() (*ControlMessage_SubscribeClientControllersMessage, error)
+	grpc.ClientStream
+}
+
+type controlSubscribeClientControllersClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlSubscribeClientControllersClient) Recv() (*ControlMessage_SubscribeClientControllersMessage, error) {
+	m := new(ControlMessage_SubscribeClientControllersMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controlClient) GetSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (*SimState, error) {
+	out := new(SimState)
+	err := c.cc.Invoke(ctx, "/erebus.Control/GetSimulationState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SubscribeSimulationState(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeSimulationStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[1], "/erebus.Control/SubscribeSimulationState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlSubscribeSimulationStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_SubscribeSimulationStateClient interface {
+	Recv() (*SimState, error)
+	grpc.ClientStream
+}
+
+type controlSubscribeSimulationStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlSubscribeSimulationStateClient) Recv() (*SimState, error) {
+	m := new(SimState)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controlClient) SetSimulationState(ctx context.Context, in *SimState, opts ...grpc.CallOption) (*Null, error) {
+	out := new(Null)
+	err := c.cc.Invoke(ctx, "/erebus.Control/SetSimulationState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetMatchStatus(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_MatchStatus, error) {
+	out := new(ControlMessage_MatchStatus)
+	err := c.cc.Invoke(ctx, "/erebus.Control/GetMatchStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ConnectClientToRobot(ctx context.Context, in *ControlMessage_ConnectClientToRobotRequest, opts ...grpc.CallOption) (*ControlMessage_ConnectClientToRobotResponse, error) {
+	out := new(ControlMessage_ConnectClientToRobotResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/ConnectClientToRobot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) DisconnectClientFromRobot(ctx context.Context, in *ControlMessage_DisconnectClientFromRobotRequest, opts ...grpc.CallOption) (*ControlMessage_DisconnectClientFromRobotResponse, error) {
+	out := new(ControlMessage_DisconnectClientFromRobotResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/DisconnectClientFromRobot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetConnections(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetConnectionsResponse, error) {
+	out := new(ControlMessage_GetConnectionsResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/GetConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SubscribeEvents(ctx context.Context, in *Null, opts ...grpc.CallOption) (Control_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[2], "/erebus.Control/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_SubscribeEventsClient interface {
+	Recv() (*ControlMessage_BrokerEvent, error)
+	grpc.ClientStream
+}
+
+type controlSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlSubscribeEventsClient) Recv() (*ControlMessage_BrokerEvent, error) {
+	m := new(ControlMessage_BrokerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controlClient) StartRecording(ctx context.Context, in *ControlMessage_StartRecordingRequest, opts ...grpc.CallOption) (*ControlMessage_StartRecordingResponse, error) {
+	out := new(ControlMessage_StartRecordingResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/StartRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StopRecording(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_StopRecordingResponse, error) {
+	out := new(ControlMessage_StopRecordingResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/StopRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StartReplay(ctx context.Context, in *ControlMessage_StartReplayRequest, opts ...grpc.CallOption) (*ControlMessage_StartReplayResponse, error) {
+	out := new(ControlMessage_StartReplayResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/StartReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StepReplay(ctx context.Context, in *ControlMessage_StepReplayRequest, opts ...grpc.CallOption) (*ControlMessage_StepReplayResponse, error) {
+	out := new(ControlMessage_StepReplayResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/StepReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StopReplay(ctx context.Context, in *ControlMessage_StopReplayRequest, opts ...grpc.CallOption) (*ControlMessage_StopReplayResponse, error) {
+	out := new(ControlMessage_StopReplayResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/StopReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Regress(ctx context.Context, in *ControlMessage_RegressRequest, opts ...grpc.CallOption) (*ControlMessage_RegressResponse, error) {
+	out := new(ControlMessage_RegressResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/Regress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetPairingRules(ctx context.Context, in *Null, opts ...grpc.CallOption) (*ControlMessage_GetPairingRulesResponse, error) {
+	out := new(ControlMessage_GetPairingRulesResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/GetPairingRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) AddPairingRule(ctx context.Context, in *ControlMessage_AddPairingRuleRequest, opts ...grpc.CallOption) (*ControlMessage_AddPairingRuleResponse, error) {
+	out := new(ControlMessage_AddPairingRuleResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/AddPairingRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) RemovePairingRule(ctx context.Context, in *ControlMessage_RemovePairingRuleRequest, opts ...grpc.CallOption) (*ControlMessage_RemovePairingRuleResponse, error) {
+	out := new(ControlMessage_RemovePairingRuleResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/RemovePairingRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) WatchRobot(ctx context.Context, in *ControlMessage_WatchRobotRequest, opts ...grpc.CallOption) (Control_WatchRobotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[3], "/erebus.Control/WatchRobot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlWatchRobotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_WatchRobotClient interface {
+	Recv() (*ControlMessage_RobotTraffic, error)
+	grpc.ClientStream
+}
+
+type controlWatchRobotClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlWatchRobotClient) Recv() (*ControlMessage_RobotTraffic, error) {
+	m := new(ControlMessage_RobotTraffic)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controlClient) Shutdown(ctx context.Context, in *ControlMessage_ShutdownRequest, opts ...grpc.CallOption) (*ControlMessage_ShutdownResponse, error) {
+	out := new(ControlMessage_ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Control/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlServer is the server API for Control service.
+type ControlServer interface {
+	GetArenas(context.Context, *Null) (*ControlMessage_GetArenasResponse, error)
+	GetRobots(context.Context, *Null) (*ControlMessage_GetRobotsResponse, error)
+	DescribeRobot(context.Context, *ControlMessage_DescribeRobotRequest) (*ControlMessage_DescribeRobotResponse, error)
+	GetClientControllers(context.Context, *Null) (*ControlMessage_GetClientControllersResponse, error)
+	SubscribeClientControllers(*Null, Control_SubscribeClientControllersServer) error
+	GetSimulationState(context.Context, *Null) (*SimState, error)
+	SubscribeSimulationState(*Null, Control_SubscribeSimulationStateServer) error
+	SetSimulationState(context.Context, *SimState) (*Null, error)
+	GetMatchStatus(context.Context, *Null) (*ControlMessage_MatchStatus, error)
 	ConnectClientToRobot(context.Context, *ControlMessage_ConnectClientToRobotRequest) (*ControlMessage_ConnectClientToRobotResponse, error)
 	DisconnectClientFromRobot(context.Context, *ControlMessage_DisconnectClientFromRobotRequest) (*ControlMessage_DisconnectClientFromRobotResponse, error)
+	GetConnections(context.Context, *Null) (*ControlMessage_GetConnectionsResponse, error)
+	SubscribeEvents(*Null, Control_SubscribeEventsServer) error
+	StartRecording(context.Context, *ControlMessage_StartRecordingRequest) (*ControlMessage_StartRecordingResponse, error)
+	StopRecording(context.Context, *Null) (*ControlMessage_StopRecordingResponse, error)
+	StartReplay(context.Context, *ControlMessage_StartReplayRequest) (*ControlMessage_StartReplayResponse, error)
+	StepReplay(context.Context, *ControlMessage_StepReplayRequest) (*ControlMessage_StepReplayResponse, error)
+	StopReplay(context.Context, *ControlMessage_StopReplayRequest) (*ControlMessage_StopReplayResponse, error)
+	Regress(context.Context, *ControlMessage_RegressRequest) (*ControlMessage_RegressResponse, error)
+	GetPairingRules(context.Context, *Null) (*ControlMessage_GetPairingRulesResponse, error)
+	AddPairingRule(context.Context, *ControlMessage_AddPairingRuleRequest) (*ControlMessage_AddPairingRuleResponse, error)
+	RemovePairingRule(context.Context, *ControlMessage_RemovePairingRuleRequest) (*ControlMessage_RemovePairingRuleResponse, error)
+	WatchRobot(*ControlMessage_WatchRobotRequest, Control_WatchRobotServer) error
+	Shutdown(context.Context, *ControlMessage_ShutdownRequest) (*ControlMessage_ShutdownResponse, error)
 }
 
 // UnimplementedControlServer can be embedded to have forward compatible implementations.
 type UnimplementedControlServer struct {
 }
 
+func (*UnimplementedControlServer) GetArenas(ctx context.Context, req *Null) (*ControlMessage_GetArenasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArenas not implemented")
+}
 func (*UnimplementedControlServer) GetRobots(ctx context.Context, req *Null) (*ControlMessage_GetRobotsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRobots not implemented")
 }
+func (*UnimplementedControlServer) DescribeRobot(ctx context.Context, req *ControlMessage_DescribeRobotRequest) (*ControlMessage_DescribeRobotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeRobot not implemented")
+}
 func (*UnimplementedControlServer) GetClientControllers(ctx context.Context, req *Null) (*ControlMessage_GetClientControllersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClientControllers not implemented")
 }
@@ -785,20 +3745,80 @@ func (*UnimplementedControlServer) GetSimulationState(ctx context.Context, req *
 func (*UnimplementedControlServer) SubscribeSimulationState(req *Null, srv Control_SubscribeSimulationStateServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeSimulationState not implemented")
 }
-func (*UnimplementedControlServer) SetSimulationState(ctx context.Context, req *SimState) (*Null, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetSimulationState not implemented")
+func (*UnimplementedControlServer) SetSimulationState(ctx context.Context, req *SimState) (*Null, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSimulationState not implemented")
+}
+func (*UnimplementedControlServer) GetMatchStatus(ctx context.Context, req *Null) (*ControlMessage_MatchStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchStatus not implemented")
+}
+func (*UnimplementedControlServer) ConnectClientToRobot(ctx context.Context, req *ControlMessage_ConnectClientToRobotRequest) (*ControlMessage_ConnectClientToRobotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectClientToRobot not implemented")
+}
+func (*UnimplementedControlServer) DisconnectClientFromRobot(ctx context.Context, req *ControlMessage_DisconnectClientFromRobotRequest) (*ControlMessage_DisconnectClientFromRobotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectClientFromRobot not implemented")
+}
+func (*UnimplementedControlServer) GetConnections(ctx context.Context, req *Null) (*ControlMessage_GetConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnections not implemented")
+}
+func (*UnimplementedControlServer) SubscribeEvents(req *Null, srv Control_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (*UnimplementedControlServer) StartRecording(ctx context.Context, req *ControlMessage_StartRecordingRequest) (*ControlMessage_StartRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRecording not implemented")
+}
+func (*UnimplementedControlServer) StopRecording(ctx context.Context, req *Null) (*ControlMessage_StopRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopRecording not implemented")
+}
+func (*UnimplementedControlServer) StartReplay(ctx context.Context, req *ControlMessage_StartReplayRequest) (*ControlMessage_StartReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartReplay not implemented")
+}
+func (*UnimplementedControlServer) StepReplay(ctx context.Context, req *ControlMessage_StepReplayRequest) (*ControlMessage_StepReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StepReplay not implemented")
+}
+func (*UnimplementedControlServer) StopReplay(ctx context.Context, req *ControlMessage_StopReplayRequest) (*ControlMessage_StopReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopReplay not implemented")
+}
+func (*UnimplementedControlServer) Regress(ctx context.Context, req *ControlMessage_RegressRequest) (*ControlMessage_RegressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Regress not implemented")
+}
+func (*UnimplementedControlServer) GetPairingRules(ctx context.Context, req *Null) (*ControlMessage_GetPairingRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPairingRules not implemented")
+}
+func (*UnimplementedControlServer) AddPairingRule(ctx context.Context, req *ControlMessage_AddPairingRuleRequest) (*ControlMessage_AddPairingRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPairingRule not implemented")
+}
+func (*UnimplementedControlServer) RemovePairingRule(ctx context.Context, req *ControlMessage_RemovePairingRuleRequest) (*ControlMessage_RemovePairingRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePairingRule not implemented")
 }
-func (*UnimplementedControlServer) ConnectClientToRobot(ctx context.Context, req *ControlMessage_ConnectClientToRobotRequest) (*ControlMessage_ConnectClientToRobotResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ConnectClientToRobot not implemented")
+func (*UnimplementedControlServer) WatchRobot(req *ControlMessage_WatchRobotRequest, srv Control_WatchRobotServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRobot not implemented")
 }
-func (*UnimplementedControlServer) DisconnectClientFromRobot(ctx context.Context, req *ControlMessage_DisconnectClientFromRobotRequest) (*ControlMessage_DisconnectClientFromRobotResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DisconnectClientFromRobot not implemented")
+func (*UnimplementedControlServer) Shutdown(ctx context.Context, req *ControlMessage_ShutdownRequest) (*ControlMessage_ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
 }
 
 func RegisterControlServer(s *grpc.Server, srv ControlServer) {
 	s.RegisterService(&_Control_serviceDesc, srv)
 }
 
+func _Control_GetArenas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetArenas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/GetArenas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetArenas(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Control_GetRobots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Null)
 	if err := dec(in); err != nil {
@@ -817,6 +3837,24 @@ func _Control_GetRobots_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Control_DescribeRobot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_DescribeRobotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).DescribeRobot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/DescribeRobot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).DescribeRobot(ctx, req.(*ControlMessage_DescribeRobotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Control_GetClientControllers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Null)
 	if err := dec(in); err != nil {
@@ -913,6 +3951,24 @@ func _Control_SetSimulationState_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Control_GetMatchStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetMatchStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/GetMatchStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetMatchStatus(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Control_ConnectClientToRobot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ControlMessage_ConnectClientToRobotRequest)
 	if err := dec(in); err != nil {
@@ -949,14 +4005,262 @@ func _Control_DisconnectClientFromRobot_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Control_GetConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/GetConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetConnections(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Null)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).SubscribeEvents(m, &controlSubscribeEventsServer{stream})
+}
+
+type Control_SubscribeEventsServer interface {
+	Send(*ControlMessage_BrokerEvent) error
+	grpc.ServerStream
+}
+
+type controlSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlSubscribeEventsServer) Send(m *ControlMessage_BrokerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Control_StartRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_StartRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StartRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/StartRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StartRecording(ctx, req.(*ControlMessage_StartRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StopRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StopRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/StopRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StopRecording(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StartReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_StartReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StartReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/StartReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StartReplay(ctx, req.(*ControlMessage_StartReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StepReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_StepReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StepReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/StepReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StepReplay(ctx, req.(*ControlMessage_StepReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StopReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_StopReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StopReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/StopReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StopReplay(ctx, req.(*ControlMessage_StopReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Regress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_RegressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Regress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/Regress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Regress(ctx, req.(*ControlMessage_RegressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GetPairingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetPairingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/GetPairingRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetPairingRules(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_AddPairingRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_AddPairingRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).AddPairingRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/AddPairingRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).AddPairingRule(ctx, req.(*ControlMessage_AddPairingRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_RemovePairingRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_RemovePairingRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RemovePairingRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/RemovePairingRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RemovePairingRule(ctx, req.(*ControlMessage_RemovePairingRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_WatchRobot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ControlMessage_WatchRobotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).WatchRobot(m, &controlWatchRobotServer{stream})
+}
+
+type Control_WatchRobotServer interface {
+	Send(*ControlMessage_RobotTraffic) error
+	grpc.ServerStream
+}
+
+type controlWatchRobotServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlWatchRobotServer) Send(m *ControlMessage_RobotTraffic) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Control_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlMessage_ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Control/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Shutdown(ctx, req.(*ControlMessage_ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Control_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "erebus.Control",
 	HandlerType: (*ControlServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetArenas",
+			Handler:    _Control_GetArenas_Handler,
+		},
 		{
 			MethodName: "GetRobots",
 			Handler:    _Control_GetRobots_Handler,
 		},
+		{
+			MethodName: "DescribeRobot",
+			Handler:    _Control_DescribeRobot_Handler,
+		},
 		{
 			MethodName: "GetClientControllers",
 			Handler:    _Control_GetClientControllers_Handler,
@@ -969,6 +4273,10 @@ var _Control_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetSimulationState",
 			Handler:    _Control_SetSimulationState_Handler,
 		},
+		{
+			MethodName: "GetMatchStatus",
+			Handler:    _Control_GetMatchStatus_Handler,
+		},
 		{
 			MethodName: "ConnectClientToRobot",
 			Handler:    _Control_ConnectClientToRobot_Handler,
@@ -977,6 +4285,50 @@ var _Control_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DisconnectClientFromRobot",
 			Handler:    _Control_DisconnectClientFromRobot_Handler,
 		},
+		{
+			MethodName: "GetConnections",
+			Handler:    _Control_GetConnections_Handler,
+		},
+		{
+			MethodName: "StartRecording",
+			Handler:    _Control_StartRecording_Handler,
+		},
+		{
+			MethodName: "StopRecording",
+			Handler:    _Control_StopRecording_Handler,
+		},
+		{
+			MethodName: "StartReplay",
+			Handler:    _Control_StartReplay_Handler,
+		},
+		{
+			MethodName: "StepReplay",
+			Handler:    _Control_StepReplay_Handler,
+		},
+		{
+			MethodName: "StopReplay",
+			Handler:    _Control_StopReplay_Handler,
+		},
+		{
+			MethodName: "Regress",
+			Handler:    _Control_Regress_Handler,
+		},
+		{
+			MethodName: "GetPairingRules",
+			Handler:    _Control_GetPairingRules_Handler,
+		},
+		{
+			MethodName: "AddPairingRule",
+			Handler:    _Control_AddPairingRule_Handler,
+		},
+		{
+			MethodName: "RemovePairingRule",
+			Handler:    _Control_RemovePairingRule_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _Control_Shutdown_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -989,6 +4341,16 @@ var _Control_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Control_SubscribeSimulationState_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _Control_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchRobot",
+			Handler:       _Control_WatchRobot_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "control.proto",
 }
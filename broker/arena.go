@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata key which Control and Scoreboard calls name their arena with
+const arenaMetadataKey = "arena"
+
+// ArenaSpec configures an arena
+type ArenaSpec struct {
+	Name string
+	// Simulation timestep in ms, or 0 for the broker's default
+	Timestep int
+}
+
+// ParseArenaSpec parses an arena given as NAME, or NAME:TIMESTEP to give it a
+// timestep of its own
+func ParseArenaSpec(spec string) (ArenaSpec, error) {
+	name, timestep := spec, ""
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		name, timestep = spec[:i], spec[i+1:]
+	}
+	if name == "" {
+		return ArenaSpec{}, fmt.Errorf("arena \"%s\" has no name", spec)
+	}
+	if strings.ContainsAny(name, "/\\") {
+		return ArenaSpec{}, fmt.Errorf("arena name \"%s\" must not contain slashes", name)
+	}
+	parsed := ArenaSpec{Name: name}
+	if timestep != "" {
+		var err error
+		if parsed.Timestep, err = strconv.Atoi(timestep); err != nil || parsed.Timestep < 1 {
+			return ArenaSpec{}, fmt.Errorf("arena \"%s\" has an invalid timestep \"%s\"", name, timestep)
+		}
+	}
+	return parsed, nil
+}
+
+// arenaPath returns the path of an arena's own copy of a file: path itself for
+// an unnamed arena, and otherwise path with the arena's name added before its
+// extension, e.g. state.field1.json
+func arenaPath(path string, arena string) string {
+	if arena == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + arena + ext
+}
+
+// Arena is one field run by the broker: a simulation with its own robots,
+// clients, simulation state and timestep, and everything operating its
+// matches. Clients may only be connected to robots in the same arena, and
+// names only need to be unique within an arena.
+type Arena struct {
+	Name       string
+	broker     *Broker
+	recorder   *Recorder
+	replayer   *Replayer
+	pairer     *Pairer
+	clock      *MatchClock
+	scoreboard *Scoreboard
+
+	robots  *WbControllerServer
+	clients *ClientControllerServer
+	control *ControlServer
+	scores  *ScoreboardServer
+}
+
+// NewArena creates the servers of an arena around its broker and what operates
+// it. Clients must present their team's token from tokens, unless tokens is
+// nil.
+func NewArena(name string, broker *Broker, recorder *Recorder, replayer *Replayer, pairer *Pairer, clock *MatchClock, scoreboard *Scoreboard, tokens *TokenStore) *Arena {
+	return &Arena{
+		Name:       name,
+		broker:     broker,
+		recorder:   recorder,
+		replayer:   replayer,
+		pairer:     pairer,
+		clock:      clock,
+		scoreboard: scoreboard,
+		robots:     NewWbControllerServer(broker),
+		clients:    NewClientControllerServer(broker, tokens),
+		control:    NewControlServer(broker, recorder, replayer, pairer, clock),
+		scores:     NewScoreboardServer(scoreboard),
+	}
+}
+
+// Arenas holds the arenas run by the broker, which all shut down together
+type Arenas struct {
+	// In the order they were configured
+	list   []*Arena
+	byName map[string]*Arena
+}
+
+// NewArenas holds arenas, which must have distinct names. An unnamed arena
+// may only be run on its own.
+func NewArenas(arenas ...*Arena) (*Arenas, error) {
+	if len(arenas) == 0 {
+		return nil, errors.New("No arenas")
+	}
+	a := &Arenas{list: arenas, byName: make(map[string]*Arena)}
+	for _, arena := range arenas {
+		if arena.Name == "" && len(arenas) > 1 {
+			return nil, errors.New("Every arena must be named when there are several")
+		}
+		if _, ok := a.byName[arena.Name]; ok {
+			return nil, fmt.Errorf("Duplicate arena \"%s\"", arena.Name)
+		}
+		a.byName[arena.Name] = arena
+	}
+	return a, nil
+}
+
+// Select returns the named arena. An empty name selects the only arena, if
+// the broker runs just one.
+func (a *Arenas) Select(name string) (*Arena, error) {
+	if name == "" {
+		if len(a.list) == 1 {
+			return a.list[0], nil
+		}
+		return nil, errors.New("Arena required, as the broker runs several")
+	}
+	arena, ok := a.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown arena \"%s\"", name)
+	}
+	return arena, nil
+}
+
+// fromContext selects the arena named by the arena metadata of a call
+func (a *Arenas) fromContext(ctx context.Context) (*Arena, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	name := ""
+	if values := md.Get(arenaMetadataKey); len(values) > 0 {
+		name = values[0]
+	}
+	arena, err := a.Select(name)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return arena, nil
+}
+
+// List returns every arena, in the order they were configured
+func (a *Arenas) List() []*Arena {
+	return a.list
+}
+
+// Shutdown starts shutting down every arena, as Broker.Shutdown does. It
+// returns an error if the broker is already shutting down.
+func (a *Arenas) Shutdown(reason string) error {
+	for _, arena := range a.list {
+		if err := arena.broker.Shutdown(reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShuttingDown returns a channel which is closed once the broker starts
+// shutting down
+func (a *Arenas) ShuttingDown() <-chan struct{} {
+	return a.list[0].broker.ShuttingDown()
+}
+
+// WaitSessions waits up to timeout for the sessions of every arena to end once
+// the broker is shutting down, returning whether they all did
+func (a *Arenas) WaitSessions(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for _, arena := range a.list {
+		if !arena.broker.WaitSessions(time.Until(deadline)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Metrics returns the metrics of the broker, which every arena's are served
+// with
+func (a *Arenas) Metrics() *Metrics {
+	return a.list[0].broker.metrics
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// ArenaWbControllerServer serves robot sessions, handing each to the server
+// of the arena named in its handshake
+type ArenaWbControllerServer struct {
+	pb.UnimplementedWbControllerServer
+
+	arenas *Arenas
+}
+
+func NewArenaWbControllerServer(arenas *Arenas) *ArenaWbControllerServer {
+	return &ArenaWbControllerServer{arenas: arenas}
+}
+
+func (s *ArenaWbControllerServer) Session(srv pb.WbController_SessionServer) error {
+	for {
+		msg, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		handshake := msg.GetWbControllerHandshake()
+		if handshake == nil {
+			continue
+		}
+		arena, err := s.arenas.Select(handshake.GetArena())
+		if err != nil {
+			srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerHandshakeResponse{
+				WbControllerHandshakeResponse: &pb.WbControllerHandshakeResponse{Data: &pb.WbControllerHandshakeResponse_Error{
+					Error: err.Error(),
+				}},
+			}})
+			log.WithFields(logrus.Fields{
+				"robot": handshake.GetRobotName(),
+				"arena": handshake.GetArena(),
+			}).Infof("Robot rejected: %s", err.Error())
+			return nil
+		}
+		return arena.robots.Session(&wbHandshakeStream{WbController_SessionServer: srv, handshake: msg})
+	}
+}
+
+// wbHandshakeStream passes the handshake read by ArenaWbControllerServer on to
+// the arena's server, ahead of the rest of the stream
+type wbHandshakeStream struct {
+	pb.WbController_SessionServer
+	handshake *pb.WbControllerMessage_ClientMessage
+}
+
+func (s *wbHandshakeStream) Recv() (*pb.WbControllerMessage_ClientMessage, error) {
+	if msg := s.handshake; msg != nil {
+		s.handshake = nil
+		return msg, nil
+	}
+	return s.WbController_SessionServer.Recv()
+}
+
+// ArenaClientControllerServer serves client sessions, handing each to the
+// server of the arena named in its handshake
+type ArenaClientControllerServer struct {
+	pb.UnimplementedClientControllerServer
+
+	arenas *Arenas
+}
+
+func NewArenaClientControllerServer(arenas *Arenas) *ArenaClientControllerServer {
+	return &ArenaClientControllerServer{arenas: arenas}
+}
+
+func (s *ArenaClientControllerServer) Session(srv pb.ClientController_SessionServer) error {
+	for {
+		msg, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		handshake := msg.GetClientControllerHandshake()
+		if handshake == nil {
+			continue
+		}
+		arena, err := s.arenas.Select(handshake.GetArena())
+		if err != nil {
+			srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerHandshakeResponse{
+				ClientControllerHandshakeResponse: &pb.ClientControllerHandshakeResponse{Data: &pb.ClientControllerHandshakeResponse_Error{
+					Error: err.Error(),
+				}},
+			}})
+			log.WithFields(logrus.Fields{
+				"client": handshake.GetClientName(),
+				"arena":  handshake.GetArena(),
+			}).Infof("Client rejected: %s", err.Error())
+			return nil
+		}
+		return arena.clients.Session(&clientHandshakeStream{ClientController_SessionServer: srv, handshake: msg})
+	}
+}
+
+// clientHandshakeStream passes the handshake read by
+// ArenaClientControllerServer on to the arena's server, ahead of the rest of
+// the stream
+type clientHandshakeStream struct {
+	pb.ClientController_SessionServer
+	handshake *pb.ClientControllerMessage_ControllerMessage
+}
+
+func (s *clientHandshakeStream) Recv() (*pb.ClientControllerMessage_ControllerMessage, error) {
+	if msg := s.handshake; msg != nil {
+		s.handshake = nil
+		return msg, nil
+	}
+	return s.ClientController_SessionServer.Recv()
+}
+
+// ArenaControlServer serves the Control service, answering each call with the
+// ControlServer of the arena named by the call's metadata. Shutdown shuts
+// every arena down.
+type ArenaControlServer struct {
+	arenas *Arenas
+}
+
+func NewArenaControlServer(arenas *Arenas) *ArenaControlServer {
+	return &ArenaControlServer{arenas: arenas}
+}
+
+func (s *ArenaControlServer) GetArenas(context.Context, *pb.Null) (*pb.ControlMessage_GetArenasResponse, error) {
+	res := &pb.ControlMessage_GetArenasResponse{}
+	for _, arena := range s.arenas.List() {
+		simState := arena.broker.GetSimState()
+		res.Arenas = append(res.Arenas, &pb.ControlMessage_Arena{
+			Name:     arena.Name,
+			Timestep: int32(arena.broker.simInfo.timestep),
+			SimState: &simState,
+		})
+	}
+	return res, nil
+}
+
+func (s *ArenaControlServer) GetRobots(ctx context.Context, req *pb.Null) (*pb.ControlMessage_GetRobotsResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.GetRobots(ctx, req)
+}
+
+func (s *ArenaControlServer) DescribeRobot(ctx context.Context, req *pb.ControlMessage_DescribeRobotRequest) (*pb.ControlMessage_DescribeRobotResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.DescribeRobot(ctx, req)
+}
+
+func (s *ArenaControlServer) GetClientControllers(ctx context.Context, req *pb.Null) (*pb.ControlMessage_GetClientControllersResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.GetClientControllers(ctx, req)
+}
+
+func (s *ArenaControlServer) SubscribeClientControllers(req *pb.Null, srv pb.Control_SubscribeClientControllersServer) error {
+	arena, err := s.arenas.fromContext(srv.Context())
+	if err != nil {
+		return err
+	}
+	return arena.control.SubscribeClientControllers(req, srv)
+}
+
+func (s *ArenaControlServer) GetSimulationState(ctx context.Context, req *pb.Null) (*pb.SimState, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.GetSimulationState(ctx, req)
+}
+
+func (s *ArenaControlServer) SubscribeSimulationState(req *pb.Null, srv pb.Control_SubscribeSimulationStateServer) error {
+	arena, err := s.arenas.fromContext(srv.Context())
+	if err != nil {
+		return err
+	}
+	return arena.control.SubscribeSimulationState(req, srv)
+}
+
+func (s *ArenaControlServer) SetSimulationState(ctx context.Context, req *pb.SimState) (*pb.Null, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.SetSimulationState(ctx, req)
+}
+
+func (s *ArenaControlServer) GetMatchStatus(ctx context.Context, req *pb.Null) (*pb.ControlMessage_MatchStatus, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.GetMatchStatus(ctx, req)
+}
+
+func (s *ArenaControlServer) ConnectClientToRobot(ctx context.Context, req *pb.ControlMessage_ConnectClientToRobotRequest) (*pb.ControlMessage_ConnectClientToRobotResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.ConnectClientToRobot(ctx, req)
+}
+
+func (s *ArenaControlServer) DisconnectClientFromRobot(ctx context.Context, req *pb.ControlMessage_DisconnectClientFromRobotRequest) (*pb.ControlMessage_DisconnectClientFromRobotResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.DisconnectClientFromRobot(ctx, req)
+}
+
+func (s *ArenaControlServer) GetConnections(ctx context.Context, req *pb.Null) (*pb.ControlMessage_GetConnectionsResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.GetConnections(ctx, req)
+}
+
+func (s *ArenaControlServer) SubscribeEvents(req *pb.Null, srv pb.Control_SubscribeEventsServer) error {
+	arena, err := s.arenas.fromContext(srv.Context())
+	if err != nil {
+		return err
+	}
+	return arena.control.SubscribeEvents(req, srv)
+}
+
+func (s *ArenaControlServer) StartRecording(ctx context.Context, req *pb.ControlMessage_StartRecordingRequest) (*pb.ControlMessage_StartRecordingResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.StartRecording(ctx, req)
+}
+
+func (s *ArenaControlServer) StopRecording(ctx context.Context, req *pb.Null) (*pb.ControlMessage_StopRecordingResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.StopRecording(ctx, req)
+}
+
+func (s *ArenaControlServer) StartReplay(ctx context.Context, req *pb.ControlMessage_StartReplayRequest) (*pb.ControlMessage_StartReplayResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.StartReplay(ctx, req)
+}
+
+func (s *ArenaControlServer) StepReplay(ctx context.Context, req *pb.ControlMessage_StepReplayRequest) (*pb.ControlMessage_StepReplayResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.StepReplay(ctx, req)
+}
+
+func (s *ArenaControlServer) StopReplay(ctx context.Context, req *pb.ControlMessage_StopReplayRequest) (*pb.ControlMessage_StopReplayResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.StopReplay(ctx, req)
+}
+
+func (s *ArenaControlServer) Regress(ctx context.Context, req *pb.ControlMessage_RegressRequest) (*pb.ControlMessage_RegressResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.Regress(ctx, req)
+}
+
+func (s *ArenaControlServer) GetPairingRules(ctx context.Context, req *pb.Null) (*pb.ControlMessage_GetPairingRulesResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.GetPairingRules(ctx, req)
+}
+
+func (s *ArenaControlServer) AddPairingRule(ctx context.Context, req *pb.ControlMessage_AddPairingRuleRequest) (*pb.ControlMessage_AddPairingRuleResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.AddPairingRule(ctx, req)
+}
+
+func (s *ArenaControlServer) RemovePairingRule(ctx context.Context, req *pb.ControlMessage_RemovePairingRuleRequest) (*pb.ControlMessage_RemovePairingRuleResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.control.RemovePairingRule(ctx, req)
+}
+
+func (s *ArenaControlServer) WatchRobot(req *pb.ControlMessage_WatchRobotRequest, srv pb.Control_WatchRobotServer) error {
+	arena, err := s.arenas.fromContext(srv.Context())
+	if err != nil {
+		return err
+	}
+	return arena.control.WatchRobot(req, srv)
+}
+
+func (s *ArenaControlServer) Shutdown(_ context.Context, req *pb.ControlMessage_ShutdownRequest) (*pb.ControlMessage_ShutdownResponse, error) {
+	return shutdownResponse(req, s.arenas.Shutdown), nil
+}
+
+// ArenaScoreboardServer serves the Scoreboard service, answering each call
+// with the scoreboard of the arena named by the call's metadata
+type ArenaScoreboardServer struct {
+	arenas *Arenas
+}
+
+func NewArenaScoreboardServer(arenas *Arenas) *ArenaScoreboardServer {
+	return &ArenaScoreboardServer{arenas: arenas}
+}
+
+func (s *ArenaScoreboardServer) PostGameEvent(ctx context.Context, event *pb.GameEvent) (*pb.PostGameEventResponse, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.scores.PostGameEvent(ctx, event)
+}
+
+func (s *ArenaScoreboardServer) GetScoreboard(ctx context.Context, req *pb.Null) (*pb.Scores, error) {
+	arena, err := s.arenas.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return arena.scores.GetScoreboard(ctx, req)
+}
+
+func (s *ArenaScoreboardServer) SubscribeScoreboard(req *pb.Null, srv pb.Scoreboard_SubscribeScoreboardServer) error {
+	arena, err := s.arenas.fromContext(srv.Context())
+	if err != nil {
+		return err
+	}
+	return arena.scores.SubscribeScoreboard(req, srv)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type ArenaSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	arenas         *Arenas
+	server         *grpc.Server
+	conn           *grpc.ClientConn
+}
+
+func (suite *ArenaSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	var err error
+	suite.arenas, err = NewArenas(suite.arena("field1", 32), suite.arena("field2", 16))
+	suite.Require().NoError(err)
+	suite.server = grpc.NewServer()
+	pb.RegisterWbControllerServer(suite.server, NewArenaWbControllerServer(suite.arenas))
+	pb.RegisterClientControllerServer(suite.server, NewArenaClientControllerServer(suite.arenas))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	go suite.server.Serve(lis)
+	suite.conn, err = grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	suite.Require().NoError(err)
+}
+
+func (suite *ArenaSuite) TearDownTest() {
+	suite.conn.Close()
+	suite.server.Stop()
+	suite.globalCtxClose()
+}
+
+func (suite *ArenaSuite) arena(name string, timestep int) *Arena {
+	broker := NewBroker(suite.globalCtx, SimInfo{timestep: timestep})
+	return NewArena(name, broker, nil, nil, NewPairer(broker, nil), nil, NewScoreboard(broker), nil)
+}
+
+func (suite *ArenaSuite) broker(name string) *Broker {
+	arena, err := suite.arenas.Select(name)
+	suite.Require().NoError(err)
+	return arena.broker
+}
+
+// robotHandshake starts a robot session in arena, returning the handshake
+// response
+func (suite *ArenaSuite) robotHandshake(arena string, name string) *pb.WbControllerHandshakeResponse {
+	session, err := pb.NewWbControllerClient(suite.conn).Session(suite.globalCtx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(session.Send(&pb.WbControllerMessage_ClientMessage{Message: &pb.WbControllerMessage_ClientMessage_WbControllerHandshake{
+		WbControllerHandshake: &pb.WbControllerHandshake{RobotName: name, Arena: arena},
+	}}))
+	msg, err := session.Recv()
+	suite.Require().NoError(err)
+	return msg.GetWbControllerHandshakeResponse()
+}
+
+func (suite *ArenaSuite) clientHandshake(arena string, name string) *pb.ClientControllerHandshakeResponse {
+	session, err := pb.NewClientControllerClient(suite.conn).Session(suite.globalCtx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(session.Send(&pb.ClientControllerMessage_ControllerMessage{Message: &pb.ClientControllerMessage_ControllerMessage_ClientControllerHandshake{
+		ClientControllerHandshake: &pb.ClientControllerHandshake{ClientName: name, Arena: arena},
+	}}))
+	msg, err := session.Recv()
+	suite.Require().NoError(err)
+	return msg.GetClientControllerHandshakeResponse()
+}
+
+// control returns a context naming arena in its metadata, as a call to the
+// Control service would
+func (suite *ArenaSuite) control(arena string) context.Context {
+	return metadata.NewIncomingContext(suite.globalCtx, metadata.Pairs(arenaMetadataKey, arena))
+}
+
+func (suite *ArenaSuite) TestParseArenaSpec() {
+	spec, err := ParseArenaSpec("field1")
+	suite.Require().NoError(err)
+	suite.Equal(ArenaSpec{Name: "field1"}, spec)
+	spec, err = ParseArenaSpec("field2:16")
+	suite.Require().NoError(err)
+	suite.Equal(ArenaSpec{Name: "field2", Timestep: 16}, spec)
+	for _, invalid := range []string{"", ":16", "field:0", "field:x", "fields/1"} {
+		_, err := ParseArenaSpec(invalid)
+		suite.Error(err, invalid)
+	}
+}
+
+func (suite *ArenaSuite) TestArenaPath() {
+	suite.Equal("state.json", arenaPath("state.json", ""))
+	suite.Equal("dir/state.field1.json", arenaPath("dir/state.json", "field1"))
+	suite.Equal("state.field1", arenaPath("state", "field1"))
+}
+
+func (suite *ArenaSuite) TestSelect() {
+	_, err := suite.arenas.Select("")
+	suite.Error(err)
+	_, err = suite.arenas.Select("field3")
+	suite.Error(err)
+
+	// A broker running a single arena needn't be told which
+	single, err := NewArenas(suite.arena("", 32))
+	suite.Require().NoError(err)
+	arena, err := single.Select("")
+	suite.Require().NoError(err)
+	suite.Equal("", arena.Name)
+
+	_, err = NewArenas(suite.arena("field1", 32), suite.arena("field1", 32))
+	suite.Error(err)
+	_, err = NewArenas(suite.arena("", 32), suite.arena("field1", 32))
+	suite.Error(err)
+}
+
+func (suite *ArenaSuite) TestSessionsJoinTheirArena() {
+	res := suite.robotHandshake("field1", "robot")
+	suite.Require().NotNil(res.GetOk())
+	suite.Equal(int32(32), res.GetOk().GetTimestep())
+	// Names only need to be unique within an arena
+	res = suite.robotHandshake("field2", "robot")
+	suite.Require().NotNil(res.GetOk())
+	suite.Equal(int32(16), res.GetOk().GetTimestep())
+	suite.Require().NotNil(suite.clientHandshake("field2", "client").GetOk())
+
+	suite.Equal([]string{"robot"}, suite.broker("field1").GetRobotNames())
+	suite.Empty(suite.broker("field1").GetClientNames())
+	suite.Equal([]string{"client"}, suite.broker("field2").GetClientNames())
+
+	// Clients can only be connected to robots in their arena
+	suite.Error(suite.broker("field1").ConnectClientToRobot("client", "robot"))
+
+	suite.NotEmpty(suite.robotHandshake("", "robot").GetError())
+	suite.NotEmpty(suite.robotHandshake("field3", "robot").GetError())
+	suite.NotEmpty(suite.clientHandshake("field3", "client").GetError())
+}
+
+func (suite *ArenaSuite) TestControlSelectsArena() {
+	control := NewArenaControlServer(suite.arenas)
+	_, err := control.SetSimulationState(suite.control("field2"), &pb.SimState{State: pb.SimState_START})
+	suite.Require().NoError(err)
+	state, err := control.GetSimulationState(suite.control("field1"), &pb.Null{})
+	suite.Require().NoError(err)
+	suite.Equal(pb.SimState_RESET, state.GetState())
+	state, err = control.GetSimulationState(suite.control("field2"), &pb.Null{})
+	suite.Require().NoError(err)
+	suite.Equal(pb.SimState_START, state.GetState())
+
+	arenas, err := control.GetArenas(suite.globalCtx, &pb.Null{})
+	suite.Require().NoError(err)
+	suite.Require().Len(arenas.GetArenas(), 2)
+	suite.Equal("field1", arenas.GetArenas()[0].GetName())
+	suite.Equal(int32(16), arenas.GetArenas()[1].GetTimestep())
+	suite.Equal(pb.SimState_START, arenas.GetArenas()[1].GetSimState().GetState())
+
+	_, err = control.GetRobots(suite.globalCtx, &pb.Null{})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = NewArenaScoreboardServer(suite.arenas).GetScoreboard(suite.control("field3"), &pb.Null{})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ArenaSuite) TestShutdownStopsEveryArena() {
+	res, err := NewArenaControlServer(suite.arenas).Shutdown(suite.control("field1"), &pb.ControlMessage_ShutdownRequest{})
+	suite.Require().NoError(err)
+	suite.Require().NotNil(res.GetOk())
+	for _, arena := range suite.arenas.List() {
+		select {
+		case <-arena.broker.ShuttingDown():
+		default:
+			suite.Failf("Arena isn't shutting down", arena.Name)
+		}
+	}
+}
+
+func TestArenaSuite(t *testing.T) {
+	suite.Run(t, new(ArenaSuite))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenStore maps team names to the secret each team's client must present in
+// its handshake. A nil TokenStore accepts every client.
+type TokenStore struct {
+	tokens map[string]string
+}
+
+// LoadTokens reads a tokens file, which has a team name and its token on each
+// line, separated by whitespace. Blank lines and lines starting with # are
+// ignored.
+func LoadTokens(path string) (*TokenStore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseTokens(f)
+}
+
+func parseTokens(r io.Reader) (*TokenStore, error) {
+	store := &TokenStore{tokens: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a team name and a token", line)
+		}
+		if _, ok := store.tokens[fields[0]]; ok {
+			return nil, fmt.Errorf("line %d: duplicate team \"%s\"", line, fields[0])
+		}
+		store.tokens[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Check returns an error unless token is the secret of the named team
+func (s *TokenStore) Check(name, token string) error {
+	if s == nil {
+		return nil
+	}
+	expected, ok := s.tokens[name]
+	if !ok {
+		return fmt.Errorf("unknown team \"%s\"", name)
+	}
+	if !tokensEqual(expected, token) {
+		return fmt.Errorf("invalid token for team \"%s\"", name)
+	}
+	return nil
+}
+
+func tokensEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// adminMethodPrefixes prefix the full method names of the RPCs which require
+// the admin token: every Control and Scoreboard RPC
+var adminMethodPrefixes = []string{"/erebus.Control/", "/erebus.Scoreboard/"}
+
+// adminAuth requires the admin token on calls to the Control and Scoreboard
+// services, sent as "authorization: Bearer <token>" metadata. Other services
+// are left to authenticate their own callers. An empty token disables the
+// check.
+type adminAuth struct {
+	token string
+}
+
+func (a adminAuth) authorize(ctx context.Context, method string) error {
+	if a.token == "" || !isAdminMethod(method) {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if token := strings.TrimPrefix(value, "Bearer "); token != value && tokensEqual(a.token, token) {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "admin token required")
+}
+
+func isAdminMethod(method string) bool {
+	for _, prefix := range adminMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a adminAuth) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a adminAuth) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
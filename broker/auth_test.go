@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type AuthSuite struct {
+	suite.Suite
+}
+
+func (suite *AuthSuite) TestParseTokens() {
+	tokens, err := parseTokens(strings.NewReader(`
+# Team tokens
+red   s3cret
+
+blue	hunter2
+`))
+	suite.Require().NoError(err)
+	suite.NoError(tokens.Check("red", "s3cret"))
+	suite.NoError(tokens.Check("blue", "hunter2"))
+	suite.Error(tokens.Check("red", "hunter2"))
+	suite.Error(tokens.Check("red", ""))
+	suite.Error(tokens.Check("green", "s3cret"))
+}
+
+func (suite *AuthSuite) TestParseTokensInvalid() {
+	_, err := parseTokens(strings.NewReader("red\n"))
+	suite.Error(err)
+	_, err = parseTokens(strings.NewReader("red a b\n"))
+	suite.Error(err)
+	_, err = parseTokens(strings.NewReader("red a\nred b\n"))
+	suite.Error(err)
+}
+
+func (suite *AuthSuite) TestNilStoreAcceptsAll() {
+	var tokens *TokenStore
+	suite.NoError(tokens.Check("anyone", ""))
+}
+
+func (suite *AuthSuite) TestAdminAuth() {
+	admin := adminAuth{token: "admin"}
+	withAuth := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+	}
+	suite.NoError(admin.authorize(withAuth("Bearer admin"), "/erebus.Control/GetRobots"))
+	suite.Equal(codes.Unauthenticated, status.Code(admin.authorize(withAuth("Bearer nope"), "/erebus.Control/GetRobots")))
+	suite.Equal(codes.Unauthenticated, status.Code(admin.authorize(withAuth("admin"), "/erebus.Control/GetRobots")))
+	suite.Equal(codes.Unauthenticated, status.Code(admin.authorize(context.Background(), "/erebus.Control/GetRobots")))
+	suite.Equal(codes.Unauthenticated, status.Code(admin.authorize(context.Background(), "/erebus.Scoreboard/PostGameEvent")))
+	// Sessions authenticate in their handshakes instead
+	suite.NoError(admin.authorize(context.Background(), "/erebus.ClientController/Session"))
+	// No token means no admin authentication
+	suite.NoError(adminAuth{}.authorize(context.Background(), "/erebus.Control/GetRobots"))
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
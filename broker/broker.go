@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -18,24 +20,75 @@ type Broker struct {
 	simInfo  SimInfo
 	simState pb.SimState
 
-	connections        []connectionIdentifier
-	connectionContexts map[string]connectionContext
+	// Active connections, keyed by client name
+	connections map[string]*connection
 
-	simStateListeners map[chan<- *pb.SimState]struct{}
+	// Sim state listeners, and the contexts which end them
+	simStateListeners map[chan<- *pb.SimState]context.Context
+	eventListeners    map[chan<- Event]struct{}
+	eventQueues       map[*eventQueue]struct{}
+
+	interceptors []namedInterceptorFactory
+	watchers     *robotWatchers
+
+	// Closed once the broker starts shutting down, with the reason given
+	shuttingDown   chan struct{}
+	shutdownReason string
+	// Robot and client sessions which have not ended yet
+	sessions sync.WaitGroup
+
+	metrics *Metrics
 }
 
-type connectionIdentifier struct {
+type connection struct {
 	robotName  string
 	clientName string
+	robotInfo  *pb.RobotInfo
+	validator  *CommandValidator
+	isSync     bool
+	boundSince time.Time
+	bound      bool
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (c *connection) info() ConnectionInfo {
+	return ConnectionInfo{
+		ClientName: c.clientName,
+		RobotName:  c.robotName,
+		RobotInfo:  c.robotInfo,
+		IsSync:     c.isSync,
+		BoundSince: c.boundSince,
+
+		RejectedCommands: c.validator.Rejected(),
+	}
 }
 
-type connectionContext struct {
-	ctx    context.Context
-	cancel context.CancelFunc
+// ConnectionInfo describes an active connection between a client and a robot
+type ConnectionInfo struct {
+	ClientName string
+	RobotName  string
+	RobotInfo  *pb.RobotInfo
+	IsSync     bool
+	BoundSince time.Time
+	// Number of invalid commands from the client which were not forwarded
+	RejectedCommands uint64
 }
 
 type SimInfo struct {
 	timestep int
+	// Time a client in lockstep mode has to answer a sensor frame
+	syncTimeout time.Duration
+	// What to send to a robot in lockstep mode when its client is late
+	syncPolicy SyncPolicy
+	// Time between pings to each robot and client; 0 disables heartbeats
+	heartbeatInterval time.Duration
+	// Number of pings in a row a peer may leave unanswered before it is
+	// evicted
+	heartbeatMisses int
+	// Time a robot or client whose session stream dropped may resume it in;
+	// 0 disables resuming sessions
+	resumeGrace time.Duration
 }
 
 // RobotHandle represents a connected robot to the broker
@@ -43,7 +96,11 @@ type RobotHandle struct {
 	ctx      context.Context
 	cancel   context.CancelFunc
 	broker   *Broker
+	info     *pb.RobotInfo
 	connBind chan RobotConnection
+	session  sessionState
+	// Connection the robot is bound in, if any
+	current *RobotConnection
 }
 
 // ClientHandle represents a connected client to the broker
@@ -53,6 +110,12 @@ type ClientHandle struct {
 	broker       *Broker
 	requestsSync bool
 	connBind     chan ClientConnection
+	session      sessionState
+	// Connection the client is bound in, if any
+	current *ClientConnection
+	// Set once the client is disconnected by hand, which keeps pairing rules
+	// from binding it again until it is connected some other way
+	heldUnbound bool
 }
 
 // RobotConnection represents an active connection with a robot
@@ -62,6 +125,8 @@ type RobotConnection struct {
 	CmdIn          <-chan *pb.Commands
 	SimStateChange <-chan *pb.SimState
 	IsSync         bool
+	SensorSampling *SensorSampling // Sampling periods requested by the client
+	Metrics        *ConnectionMetrics
 }
 
 // ClientConnection represents an active connection with a client
@@ -71,28 +136,48 @@ type ClientConnection struct {
 	CmdOut         chan<- *pb.Commands
 	SimStateChange <-chan *pb.SimState
 	IsSync         bool
+	RobotInfo      *pb.RobotInfo // Devices of the robot the client is bound to
+	SensorSampling *SensorSampling
+	// Checks commands against the robot's devices before they are sent
+	CommandValidator *CommandValidator
+	Metrics          *ConnectionMetrics
 }
 
 // NewBroker creates a new broker instance
 func NewBroker(ctx context.Context, info SimInfo) *Broker {
+	return newBroker(ctx, info, newMetrics())
+}
+
+// newBroker creates a broker recording its metrics in metrics, which may be
+// shared with the brokers of other arenas
+func newBroker(ctx context.Context, info SimInfo, metrics *Metrics) *Broker {
 	return &Broker{
-		ctx:                ctx,
-		simInfo:            info,
-		robots:             make(map[string]*RobotHandle),
-		clients:            make(map[string]*ClientHandle),
-		simState:           pb.SimState{State: pb.SimState_RESET},
-		connectionContexts: make(map[string]connectionContext),
-		simStateListeners:  make(map[chan<- *pb.SimState]struct{}),
+		ctx:               ctx,
+		simInfo:           info,
+		robots:            make(map[string]*RobotHandle),
+		clients:           make(map[string]*ClientHandle),
+		simState:          pb.SimState{State: pb.SimState_RESET},
+		connections:       make(map[string]*connection),
+		simStateListeners: make(map[chan<- *pb.SimState]context.Context),
+		eventListeners:    make(map[chan<- Event]struct{}),
+		eventQueues:       make(map[*eventQueue]struct{}),
+		watchers:          newRobotWatchers(),
+		shuttingDown:      make(chan struct{}),
+		metrics:           metrics,
 	}
 }
 
-// RegisterRobot registers a new robot with the given name
-func (b *Broker) RegisterRobot(name string, ctx context.Context) *RobotHandle {
+// RegisterRobot registers a new robot with the given name, and the devices it
+// declared in its handshake
+func (b *Broker) RegisterRobot(name string, ctx context.Context, info *pb.RobotInfo) *RobotHandle {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	if _, ok := b.robots[name]; ok {
 		return nil
 	}
+	if info == nil {
+		info = &pb.RobotInfo{}
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	connBind := make(chan RobotConnection)
 	handle := RobotHandle{
@@ -100,6 +185,8 @@ func (b *Broker) RegisterRobot(name string, ctx context.Context) *RobotHandle {
 		cancel:   cancel,
 		connBind: connBind,
 		broker:   b,
+		info:     info,
+		session:  b.newSessionState(),
 	}
 	b.robots[name] = &handle
 	go func() {
@@ -107,10 +194,12 @@ func (b *Broker) RegisterRobot(name string, ctx context.Context) *RobotHandle {
 		b.mu.Lock()
 		defer b.mu.Unlock()
 		delete(b.robots, name)
+		b.publishEvent(Event{Type: EventRobotUnregistered, RobotName: name})
 		log.WithFields(logrus.Fields{
 			"robot": name,
 		}).Info("Robot unregistered")
 	}()
+	b.publishEvent(Event{Type: EventRobotRegistered, RobotName: name})
 	log.WithFields(logrus.Fields{
 		"robot": name,
 	}).Info("Robot registered")
@@ -143,6 +232,7 @@ func (b *Broker) RegisterClient(name string, ctx context.Context, requestsSync b
 		broker:       b,
 		requestsSync: requestsSync,
 		connBind:     connBind,
+		session:      b.newSessionState(),
 	}
 	b.clients[name] = &handle
 	go func() {
@@ -150,10 +240,12 @@ func (b *Broker) RegisterClient(name string, ctx context.Context, requestsSync b
 		b.mu.Lock()
 		defer b.mu.Unlock()
 		delete(b.clients, name)
+		b.publishEvent(Event{Type: EventClientUnregistered, ClientName: name})
 		log.WithFields(logrus.Fields{
 			"client": name,
 		}).Info("Client unregistered")
 	}()
+	b.publishEvent(Event{Type: EventClientRegistered, ClientName: name})
 	log.WithFields(logrus.Fields{
 		"client": name,
 	}).Info("Client registered")
@@ -171,7 +263,9 @@ func (b *Broker) UnregisterClient(name string) error {
 	return nil
 }
 
-func (b *Broker) ConnectClientToRobot(clientName string, robotName string, isSync bool) error {
+// ConnectClientToRobot binds a registered client to a registered robot. The
+// connection runs in lockstep if the client requested sync in its handshake.
+func (b *Broker) ConnectClientToRobot(clientName string, robotName string) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	robot, ok := b.robots[robotName]
@@ -182,6 +276,20 @@ func (b *Broker) ConnectClientToRobot(clientName string, robotName string, isSyn
 	if !ok {
 		return errors.New("Client not found")
 	}
+	if _, ok := b.connections[clientName]; ok {
+		return errors.New("Client already connected")
+	}
+	if robot.session.isDetached() {
+		return errors.New("Robot disconnected, awaiting resume")
+	}
+	if client.session.isDetached() {
+		return errors.New("Client disconnected, awaiting resume")
+	}
+	for _, conn := range b.connections {
+		if conn.robotName == robotName {
+			return errors.New("Robot already connected")
+		}
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
 		select {
@@ -192,60 +300,155 @@ func (b *Broker) ConnectClientToRobot(clientName string, robotName string, isSyn
 			cancel()
 		}
 	}()
-	b.connections = append(b.connections,
-		connectionIdentifier{clientName: clientName, robotName: robotName},
-	)
+	isSync := client.requestsSync
+	conn := &connection{
+		robotName:  robotName,
+		clientName: clientName,
+		robotInfo:  robot.info,
+		validator:  newCommandValidator(robot.info),
+		isSync:     isSync,
+		boundSince: time.Now(),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	b.connections[clientName] = conn
+	client.heldUnbound = false
 	go func() {
 		<-ctx.Done()
-		// TODO: remove entry from b.connections
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		// The entry may already have been replaced by a newer connection
+		if b.connections[clientName] == conn {
+			delete(b.connections, clientName)
+		}
+		if robot.current != nil && robot.current.Ctx == ctx {
+			robot.current = nil
+		}
+		if client.current != nil && client.current.Ctx == ctx {
+			client.current = nil
+		}
+		if !conn.bound {
+			return
+		}
+		b.publishEvent(Event{Type: EventConnectionUnbound, RobotName: robotName, ClientName: clientName})
+		b.watchers.publish(robotName, Traffic{Type: TrafficUnbound, ClientName: clientName})
+		log.WithFields(logrus.Fields{
+			"robot":  robotName,
+			"client": clientName,
+		}).Info("Connection unbound")
 	}()
-	b.connectionContexts[clientName] = connectionContext{ctx: ctx, cancel: cancel}
-	// TODO: handle sync
-	// TODO: MITM channels for instrumentation
-	sdChan := make(chan *pb.SensorsData)
-	cmdChan := make(chan *pb.Commands)
+	robotSdChan := make(chan *pb.SensorsData)
+	robotCmdChan := make(chan *pb.Commands)
+	clientSdChan := make(chan *pb.SensorsData)
+	clientCmdChan := make(chan *pb.Commands)
+	sampling := newSensorSampling(robot.info, b.simInfo.timestep)
+	metrics := b.metrics.connection(robotName, clientName)
 	b.mu.Unlock()
+	r := &relay{
+		ctx: ctx,
+		logger: log.WithFields(logrus.Fields{
+			"robot":  robotName,
+			"client": clientName,
+		}),
+		chain:    b.buildInterceptorChain(ctx, conn.info()),
+		sampling: sampling,
+		tap:      &trafficTap{watchers: b.watchers, robotName: robotName, clientName: clientName},
+		sdIn:     robotSdChan,
+		sdOut:    clientSdChan,
+		cmdIn:    clientCmdChan,
+		cmdOut:   robotCmdChan,
+	}
+	if isSync {
+		go r.runSync(b.simInfo.syncTimeout, b.simInfo.syncPolicy)
+	} else {
+		go r.runAsync()
+	}
 	rConnSSC := b.GetSimStateListener(ctx)
-	b.mu.Lock()
-	robot.connBind <- RobotConnection{
+	cConnSSC := b.GetSimStateListener(ctx)
+	robotConn := RobotConnection{
 		Ctx:            ctx,
-		SdOut:          sdChan,
-		CmdIn:          cmdChan,
+		SdOut:          robotSdChan,
+		CmdIn:          robotCmdChan,
 		SimStateChange: rConnSSC,
 		IsSync:         isSync,
+		SensorSampling: sampling,
+		Metrics:        metrics,
 	}
-	b.mu.Unlock()
-	cConnSSC := b.GetSimStateListener(ctx)
-	b.mu.Lock()
-	client.connBind <- ClientConnection{
+	clientConn := ClientConnection{
 		Ctx:            ctx,
-		SdIn:           sdChan,
-		CmdOut:         cmdChan,
+		SdIn:           clientSdChan,
+		CmdOut:         clientCmdChan,
 		SimStateChange: cConnSSC,
 		IsSync:         isSync,
+		RobotInfo:      robot.info,
+		SensorSampling: sampling,
+
+		CommandValidator: conn.validator,
+		Metrics:          metrics,
+	}
+	// The connections are handed to the sessions without holding b.mu, as a
+	// session may take a while to pick its connection up
+	handedOff := false
+	select {
+	case robot.connBind <- robotConn:
+		handedOff = true
+	case <-ctx.Done():
+	}
+	b.mu.Lock()
+	if !handedOff || ctx.Err() != nil {
+		return errors.New("Robot disconnected while binding")
+	}
+	robot.current = &robotConn
+	b.mu.Unlock()
+	handedOff = false
+	select {
+	case client.connBind <- clientConn:
+		handedOff = true
+	case <-ctx.Done():
 	}
+	b.mu.Lock()
+	if !handedOff || ctx.Err() != nil {
+		return errors.New("Client disconnected while binding")
+	}
+	client.current = &clientConn
+	conn.bound = true
+	b.publishEvent(Event{Type: EventConnectionBound, RobotName: robotName, ClientName: clientName})
+	b.watchers.publish(robotName, Traffic{Type: TrafficBound, ClientName: clientName, IsSync: isSync})
+	log.WithFields(logrus.Fields{
+		"robot":  robotName,
+		"client": clientName,
+		"sync":   isSync,
+	}).Info("Connection bound")
 	return nil
 }
 
+// DisconnectClientFromRobot unbinds a client from the robot it is connected
+// to. The client is left unbound by the pairing rules until it registers again
+// or is connected by hand.
 func (b *Broker) DisconnectClientFromRobot(clientName string) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	connCtx, ok := b.connectionContexts[clientName]
+	conn, ok := b.connections[clientName]
 	if !ok {
 		return errors.New("Client not connected")
 	}
-	connCtx.cancel()
+	if client, ok := b.clients[clientName]; ok {
+		client.heldUnbound = true
+	}
+	conn.cancel()
+	delete(b.connections, clientName)
 	return nil
 }
 
+// GetSimStateListener returns a channel which receives every sim state change
+// until ctx is done
 func (b *Broker) GetSimStateListener(ctx context.Context) <-chan *pb.SimState {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	ch := make(chan *pb.SimState)
-	b.simStateListeners[ch] = struct{}{}
+	b.simStateListeners[ch] = ctx
 	go func() {
 		<-ctx.Done()
-		close(ch)
 		b.mu.Lock()
 		defer b.mu.Unlock()
 		delete(b.simStateListeners, ch)
@@ -264,6 +467,17 @@ func (b *Broker) GetRobotNames() []string {
 	return names
 }
 
+// GetRobotInfo returns the devices declared by the robot with the given name
+func (b *Broker) GetRobotInfo(name string) (*pb.RobotInfo, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	robot, ok := b.robots[name]
+	if !ok {
+		return nil, errors.New("Robot not found")
+	}
+	return robot.info, nil
+}
+
 // GetClientNames returns the names of all registered clients
 func (b *Broker) GetClientNames() []string {
 	b.mu.RLock()
@@ -275,6 +489,32 @@ func (b *Broker) GetClientNames() []string {
 	return names
 }
 
+// clientRequestsSync returns whether the named client requested lockstep mode,
+// and whether it is registered
+func (b *Broker) clientRequestsSync(name string) (requestsSync bool, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	client, ok := b.clients[name]
+	if !ok {
+		return false, false
+	}
+	return client.requestsSync, true
+}
+
+// GetConnections returns all active connections, ordered by client name
+func (b *Broker) GetConnections() []ConnectionInfo {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	conns := make([]ConnectionInfo, 0, len(b.connections))
+	for _, conn := range b.connections {
+		conns = append(conns, conn.info())
+	}
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].ClientName < conns[j].ClientName
+	})
+	return conns
+}
+
 // GetSimState gets the current simulation state
 func (b *Broker) GetSimState() pb.SimState {
 	b.mu.RLock()
@@ -287,10 +527,14 @@ func (b *Broker) SetSimState(state pb.SimState) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	b.simState = state
-	for listener := range b.simStateListeners {
-		go func(listener chan<- *pb.SimState) {
-			listener <- &state
-		}(listener)
+	b.publishEvent(Event{Type: EventSimStateChanged, SimState: &state})
+	for listener, ctx := range b.simStateListeners {
+		go func(listener chan<- *pb.SimState, ctx context.Context) {
+			select {
+			case listener <- &state:
+			case <-ctx.Done():
+			}
+		}(listener, ctx)
 	}
 }
 
@@ -34,8 +34,8 @@ func (suite *BrokerSuite) SetupTest() {
 
 func (suite *BrokerSuite) TestRegisterDuplicateRobot() {
 	robotEnclCtx, robotEnclCtxClose := context.WithCancel(context.Background())
-	suite.Require().NotNil(suite.broker.RegisterRobot("robot", robotEnclCtx))
-	suite.Nil(suite.broker.RegisterRobot("robot", robotEnclCtx))
+	suite.Require().NotNil(suite.broker.RegisterRobot("robot", robotEnclCtx, nil))
+	suite.Nil(suite.broker.RegisterRobot("robot", robotEnclCtx, nil))
 	robotEnclCtxClose()
 	suite.globalCtxClose()
 }
@@ -47,7 +47,7 @@ func (suite *BrokerSuite) TestUnregisterNonexistantRobot() {
 
 func (suite *BrokerSuite) TestUnregisterRobot() {
 	robotEnclCtx, robotEnclCtxClose := context.WithCancel(context.Background())
-	handle := suite.broker.RegisterRobot("robot", robotEnclCtx)
+	handle := suite.broker.RegisterRobot("robot", robotEnclCtx, nil)
 	suite.Require().NotNil(handle)
 	suite.Require().NoError(suite.broker.UnregisterRobot("robot"))
 	<-handle.ctx.Done()
@@ -60,7 +60,7 @@ func (suite *BrokerSuite) TestUnregisterRobot() {
 
 func (suite *BrokerSuite) TestRobotAutoUnregister() {
 	robotEnclCtx, robotEnclCtxClose := context.WithCancel(context.Background())
-	suite.Require().NotNil(suite.broker.RegisterRobot("robot", robotEnclCtx))
+	suite.Require().NotNil(suite.broker.RegisterRobot("robot", robotEnclCtx, nil))
 	robotEnclCtxClose()
 	time.Sleep(closeTimeout)
 	robots := suite.broker.GetRobotNames()
@@ -115,6 +115,169 @@ func (suite *BrokerSuite) TestSimStateListener() {
 	suite.globalCtxClose()
 }
 
+func (suite *BrokerSuite) TestEventListener() {
+	listenerCtx, listenerCtxClose := context.WithCancel(context.Background())
+	events := suite.broker.GetEventListener(listenerCtx)
+	robotConns, _ := suite.bindPeers("robot", "client")
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	suite.broker.SetSimState(pb.SimState{State: pb.SimState_START})
+	suite.Require().NoError(suite.broker.DisconnectClientFromRobot("client"))
+	<-(<-robotConns).Ctx.Done()
+	expected := []Event{
+		{Type: EventRobotRegistered, RobotName: "robot"},
+		{Type: EventClientRegistered, ClientName: "client"},
+		{Type: EventConnectionBound, RobotName: "robot", ClientName: "client"},
+		{Type: EventSimStateChanged},
+		{Type: EventConnectionUnbound, RobotName: "robot", ClientName: "client"},
+	}
+	for _, exp := range expected {
+		event := <-events
+		suite.Equal(exp.Type, event.Type)
+		suite.Equal(exp.RobotName, event.RobotName)
+		suite.Equal(exp.ClientName, event.ClientName)
+	}
+	listenerCtxClose()
+	suite.globalCtxClose()
+}
+
+func (suite *BrokerSuite) TestEventQueueKeepsEveryEvent() {
+	listenerCtx, listenerCtxClose := context.WithCancel(context.Background())
+	defer listenerCtxClose()
+	queue := suite.broker.GetEventQueue(listenerCtx)
+	listener := suite.broker.GetEventListener(listenerCtx)
+	// More events than a listener buffers, none of which are received yet
+	count := 2 * eventListenerBuffer
+	for i := 0; i < count; i++ {
+		state := pb.SimState_START
+		if i%2 == 1 {
+			state = pb.SimState_STOP
+		}
+		suite.broker.SetSimState(pb.SimState{State: state})
+	}
+	for i := 0; i < count; i++ {
+		suite.Equal(EventSimStateChanged, (<-queue).Type)
+	}
+	suite.Len(listener, eventListenerBuffer)
+	listenerCtxClose()
+	_, ok := <-queue
+	suite.False(ok)
+	suite.globalCtxClose()
+}
+
+func (suite *BrokerSuite) TestClientListener() {
+	listenerCtx, listenerCtxClose := context.WithCancel(context.Background())
+	defer listenerCtxClose()
+	suite.Require().NotNil(suite.broker.RegisterClient("client0", suite.globalCtx, false))
+	names, events := suite.broker.GetClientListener(listenerCtx)
+	suite.Equal([]string{"client0"}, names)
+	suite.Require().NotNil(suite.broker.RegisterClient("client1", suite.globalCtx, false))
+	event := <-events
+	suite.Equal(EventClientRegistered, event.Type)
+	suite.Equal("client1", event.ClientName)
+	suite.Empty(events)
+	suite.globalCtxClose()
+}
+
+// bindPeers registers a robot and a client, and returns channels which receive
+// their connections once they are bound
+func (suite *BrokerSuite) bindPeers(robotName string, clientName string) (<-chan RobotConnection, <-chan ClientConnection) {
+	robot := suite.broker.RegisterRobot(robotName, suite.globalCtx, nil)
+	suite.Require().NotNil(robot)
+	client := suite.broker.RegisterClient(clientName, suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	robotConns := make(chan RobotConnection, 1)
+	clientConns := make(chan ClientConnection, 1)
+	go func() { robotConns <- <-robot.GetConnection() }()
+	go func() { clientConns <- <-client.GetConnection() }()
+	return robotConns, clientConns
+}
+
+func (suite *BrokerSuite) TestConnectionRegistry() {
+	robotConns, _ := suite.bindPeers("robot", "client")
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	conns := suite.broker.GetConnections()
+	suite.Require().Len(conns, 1)
+	suite.Equal("client", conns[0].ClientName)
+	suite.Equal("robot", conns[0].RobotName)
+	suite.False(conns[0].IsSync)
+	suite.Require().NoError(suite.broker.DisconnectClientFromRobot("client"))
+	<-(<-robotConns).Ctx.Done()
+	time.Sleep(closeTimeout)
+	suite.Empty(suite.broker.GetConnections())
+	suite.globalCtxClose()
+}
+
+func (suite *BrokerSuite) TestConnectionRemovedOnUnregister() {
+	robotConns, _ := suite.bindPeers("robot", "client")
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	suite.Require().NoError(suite.broker.UnregisterRobot("robot"))
+	<-(<-robotConns).Ctx.Done()
+	time.Sleep(closeTimeout)
+	suite.Empty(suite.broker.GetConnections())
+	suite.globalCtxClose()
+}
+
+func (suite *BrokerSuite) TestConnectAlreadyConnected() {
+	suite.bindPeers("robot", "client")
+	suite.bindPeers("robot2", "client2")
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	suite.Error(suite.broker.ConnectClientToRobot("client", "robot2"))
+	suite.Error(suite.broker.ConnectClientToRobot("client2", "robot"))
+	suite.globalCtxClose()
+}
+
+func (suite *BrokerSuite) TestSlowBindDoesNotBlockBroker() {
+	// Neither session picks its connection up until the robot's is taken below
+	robot := suite.broker.RegisterRobot("robot", suite.globalCtx, nil)
+	suite.Require().NotNil(robot)
+	client := suite.broker.RegisterClient("client", suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	connected := make(chan error, 1)
+	go func() { connected <- suite.broker.ConnectClientToRobot("client", "robot") }()
+	time.Sleep(closeTimeout)
+
+	done := make(chan struct{})
+	go func() {
+		suite.broker.GetRobotNames()
+		suite.broker.SetSimState(pb.SimState{State: pb.SimState_START})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("Broker blocked while binding")
+	}
+
+	<-robot.GetConnection()
+	<-client.GetConnection()
+	suite.NoError(<-connected)
+	suite.Len(suite.broker.GetConnections(), 1)
+	suite.globalCtxClose()
+}
+
+func (suite *BrokerSuite) TestRobotInfo() {
+	info := &pb.RobotInfo{
+		SensorInfos: []*pb.SensorInfo{{Name: "so0", Type: pb.SensorType_DISTANCE_SENSOR}},
+		MotorInfos:  []*pb.MotorInfo{{Name: "left wheel", MaxVelocity: 6.28}},
+		LedInfos:    []*pb.LEDInfo{{Name: "led"}},
+	}
+	robot := suite.broker.RegisterRobot("robot", suite.globalCtx, info)
+	suite.Require().NotNil(robot)
+	client := suite.broker.RegisterClient("client", suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	go func() { <-robot.GetConnection() }()
+	got, err := suite.broker.GetRobotInfo("robot")
+	suite.Require().NoError(err)
+	suite.Equal(info, got)
+	_, err = suite.broker.GetRobotInfo("nonexistant")
+	suite.Error(err)
+	clientConns := make(chan ClientConnection, 1)
+	go func() { clientConns <- <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	suite.Equal(info, (<-clientConns).RobotInfo)
+	suite.globalCtxClose()
+}
+
 func TestBrokerSuite(t *testing.T) {
 	suite.Run(t, new(BrokerSuite))
 }
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"io"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/ethanwu10/erebus/broker/gen"
 )
@@ -12,15 +16,23 @@ type ClientControllerServer struct {
 	pb.UnimplementedClientControllerServer
 
 	broker *Broker
+	tokens *TokenStore
 }
 
-func NewClientControllerServer(broker *Broker) *ClientControllerServer {
-	return &ClientControllerServer{broker: broker}
+// NewClientControllerServer creates a server for client sessions. Clients must
+// present their team's token from tokens, unless tokens is nil.
+func NewClientControllerServer(broker *Broker, tokens *TokenStore) *ClientControllerServer {
+	return &ClientControllerServer{broker: broker, tokens: tokens}
 }
 
 func (s *ClientControllerServer) Session(srv pb.ClientController_SessionServer) error {
+	// Ended early if another session resumes this one
+	streamCtx, cancelStream := context.WithCancel(srv.Context())
+	defer cancelStream()
 	hasInitialized := false
 	var clientHandle *ClientHandle
+	var missedSimStates []*pb.SimState
+	resumed := false
 	var name string
 	var logger *logrus.Entry
 	for !hasInitialized {
@@ -38,56 +50,188 @@ func (s *ClientControllerServer) Session(srv pb.ClientController_SessionServer)
 			logger = log.WithFields(logrus.Fields{
 				"client": name,
 			})
-			clientHandle = s.broker.RegisterClient(name, srv.Context(), handshake.GetRequestSync())
-			if clientHandle == nil {
+			if !s.broker.beginSession() {
 				srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerHandshakeResponse{
 					ClientControllerHandshakeResponse: &pb.ClientControllerHandshakeResponse{Data: &pb.ClientControllerHandshakeResponse_Error{
-						Error: "name in use",
+						Error: "Broker shutting down",
 					}},
 				}})
-				logger.Info("Client rejected for duplicate name")
+				logger.Info("Client rejected during shutdown")
+				s.broker.metrics.rejectHandshake("client", "shutting_down")
 				return nil
 			}
+			defer s.broker.endSession()
+			err := checkPeerName(srv.Context(), name)
+			if err == nil {
+				err = s.tokens.Check(name, handshake.GetToken())
+			}
+			if err != nil {
+				srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerHandshakeResponse{
+					ClientControllerHandshakeResponse: &pb.ClientControllerHandshakeResponse{Data: &pb.ClientControllerHandshakeResponse_Error{
+						Error: err.Error(),
+					}},
+				}})
+				logger.Warnf("Client rejected: %s", err.Error())
+				s.broker.metrics.rejectHandshake("client", "unauthenticated")
+				return nil
+			}
+			if token := handshake.GetResumeToken(); token != "" {
+				clientHandle, missedSimStates, err = s.broker.ResumeClient(name, token, cancelStream)
+				if err != nil {
+					srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerHandshakeResponse{
+						ClientControllerHandshakeResponse: &pb.ClientControllerHandshakeResponse{Data: &pb.ClientControllerHandshakeResponse_Error{
+							Error: err.Error(),
+						}},
+					}})
+					logger.Infof("Client resume rejected: %s", err.Error())
+					s.broker.metrics.rejectHandshake("client", "resume")
+					return nil
+				}
+				resumed = true
+			} else {
+				clientHandle = s.broker.RegisterClient(name, s.broker.ctx, handshake.GetRequestSync())
+				if clientHandle == nil {
+					srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerHandshakeResponse{
+						ClientControllerHandshakeResponse: &pb.ClientControllerHandshakeResponse{Data: &pb.ClientControllerHandshakeResponse_Error{
+							Error: "name in use",
+						}},
+					}})
+					logger.Info("Client rejected for duplicate name")
+					s.broker.metrics.rejectHandshake("client", "name_in_use")
+					return nil
+				}
+				s.broker.attach(&clientHandle.session, cancelStream)
+			}
 			hasInitialized = true
 			if err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerHandshakeResponse{
 				ClientControllerHandshakeResponse: &pb.ClientControllerHandshakeResponse{Data: &pb.ClientControllerHandshakeResponse_Ok_{
 					Ok: &pb.ClientControllerHandshakeResponse_Ok{
-						Timestep: int32(s.broker.simInfo.timestep),
+						Timestep:    int32(s.broker.simInfo.timestep),
+						ResumeToken: clientHandle.session.resumeToken,
+						Resumed:     resumed,
 					},
 				}},
 			}}); err != nil {
 				logger.Errorf("Couldn't send handshake response: %s", err.Error())
+				// The client never learned the token for a new session, so it
+				// can't be resumed
+				if resumed {
+					s.broker.DetachClient(name, clientHandle, nil)
+				} else {
+					clientHandle.cancel()
+				}
 				return err
 			}
 			logger.Info("Client connected")
 		}
 	}
+	// A session the client closes itself, or which is evicted for missing
+	// heartbeats, ends for good; any other end leaves the client to be resumed
+	endsForGood := false
+	// Last commands forwarded to the robot, which the robot is stopped from
+	// following while the client is detached
+	var lastCommands *pb.Commands
+	defer func() {
+		if endsForGood {
+			clientHandle.cancel()
+		} else {
+			s.broker.DetachClient(name, clientHandle, lastCommands)
+		}
+	}()
 	incoming := make(chan *pb.ClientControllerMessage_ControllerMessage)
+	var recvErr error
 	go func() {
 		for {
 			msg, err := srv.Recv()
 			if err != nil {
-				// TODO: better handle incoming errors
+				recvErr = err
 				close(incoming)
 				return
 			}
 			incoming <- msg
 		}
 	}()
+	hb := newHeartbeat(s.broker.simInfo.heartbeatInterval, s.broker.simInfo.heartbeatMisses, logger)
+	hb.rttObserver = s.broker.metrics.heartbeatRTT.WithLabelValues("client")
+	defer hb.stop()
+	// heartbeatTick pings the client, and evicts it once it has missed too many
+	// heartbeats
+	heartbeatTick := func() error {
+		ping, ok := hb.tick()
+		if !ok {
+			logger.Warn("Client missed too many heartbeats, evicting")
+			endsForGood = true
+			return status.Error(codes.Unavailable, "missed too many heartbeats")
+		}
+		if err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_Ping{Ping: ping}}); err != nil {
+			logger.Errorf("Couldn't send ping: %s", err.Error())
+			return err
+		}
+		return nil
+	}
+	// hungUp handles the client closing its stream
+	hungUp := func() error {
+		logger.Info("Client disconnected")
+		endsForGood = recvErr == io.EOF
+		return nil
+	}
+	connection, isBound := ClientConnection{}, false
+	if resumed {
+		connection, isBound = s.broker.ClientConnection(clientHandle)
+	}
 	for {
-		var connection ClientConnection
-		logger.Debug("Client waiting for peer")
-		select {
-		case connection = <-clientHandle.GetConnection():
-		case <-srv.Context().Done():
-			return nil
+		if !isBound {
+			logger.Debug("Client waiting for peer")
+		LAwaitPeer:
+			for {
+				select {
+				case connection = <-clientHandle.GetConnection():
+					break LAwaitPeer
+				case controllerMsg, ok := <-incoming:
+					if !ok {
+						// Remote hung up
+						return hungUp()
+					}
+					if pong := controllerMsg.GetPong(); pong != nil {
+						hb.pong(pong)
+					} else if controllerMsg.GetSensorSamplingPeriods() != nil {
+						if err := sendSensorSamplingPeriodsResponse(srv, errors.New("Not bound to a robot")); err != nil {
+							logger.Errorf("Couldn't send sensor sampling periods response: %s", err.Error())
+							return err
+						}
+					}
+				case <-hb.C():
+					if err := heartbeatTick(); err != nil {
+						return err
+					}
+				case <-s.broker.ShuttingDown():
+					endsForGood = true
+					return s.shutDownClient(srv, false, logger)
+				case <-streamCtx.Done():
+					return streamEnded(srv)
+				}
+			}
+			lastCommands = nil
 		}
 		if err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerBound{
-			ClientControllerBound: &pb.ClientControllerBound{IsSync: connection.IsSync},
+			ClientControllerBound: &pb.ClientControllerBound{
+				IsSync:    connection.IsSync,
+				RobotInfo: connection.RobotInfo,
+			},
 		}}); err != nil {
 			logger.Errorf("Couldn't send bound message: %s", err.Error())
 			return err
 		}
+		if isBound {
+			// Catch the resumed client up on what it missed
+			for _, ssc := range missedSimStates {
+				if err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_SimStateChange{SimStateChange: ssc}}); err != nil {
+					logger.Errorf("Couldn't send sim state change message: %s", err.Error())
+					return err
+				}
+			}
+			isBound = false
+		}
 		logger.Debug("Client got peer")
 	LBoundSession:
 		for {
@@ -95,11 +239,37 @@ func (s *ClientControllerServer) Session(srv pb.ClientController_SessionServer)
 			case controllerMsg, ok := <-incoming:
 				if !ok {
 					// Remote hung up
-					logger.Info("Client disconnected")
-					return nil
+					return hungUp()
 				}
 				if cmd := controllerMsg.GetCommands(); cmd != nil {
-					connection.CmdOut <- cmd
+					connection.Metrics.CommandsReceived()
+					cmd, cmdErrs := connection.CommandValidator.Validate(cmd)
+					if len(cmdErrs) > 0 {
+						logger.WithField("count", len(cmdErrs)).Debug("Rejected invalid commands")
+						err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_CommandErrors{
+							CommandErrors: &pb.CommandErrors{Errors: cmdErrs},
+						}})
+						if err != nil {
+							logger.Errorf("Couldn't send command errors message: %s", err.Error())
+							return err
+						}
+					}
+					select {
+					case connection.CmdOut <- cmd:
+						lastCommands = cmd
+					case <-connection.Ctx.Done():
+					}
+				} else if pong := controllerMsg.GetPong(); pong != nil {
+					hb.pong(pong)
+				} else if periods := controllerMsg.GetSensorSamplingPeriods(); periods != nil {
+					reqErr := connection.SensorSampling.Request(periods.GetPeriods())
+					if reqErr != nil {
+						logger.Infof("Rejected sensor sampling periods: %s", reqErr.Error())
+					}
+					if err := sendSensorSamplingPeriodsResponse(srv, reqErr); err != nil {
+						logger.Errorf("Couldn't send sensor sampling periods response: %s", err.Error())
+						return err
+					}
 				}
 			case sd, ok := <-connection.SdIn:
 				if !ok {
@@ -110,6 +280,7 @@ func (s *ClientControllerServer) Session(srv pb.ClientController_SessionServer)
 					logger.Errorf("Couldn't send sensor data message: %s", err.Error())
 					return err
 				}
+				connection.Metrics.SensorFrameSent()
 			case ssc, ok := <-connection.SimStateChange:
 				if !ok {
 					continue
@@ -119,6 +290,10 @@ func (s *ClientControllerServer) Session(srv pb.ClientController_SessionServer)
 					logger.Errorf("Couldn't send sim state change message: %s", err.Error())
 					return err
 				}
+			case <-hb.C():
+				if err := heartbeatTick(); err != nil {
+					return err
+				}
 			case <-connection.Ctx.Done():
 				err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerUnbound{ClientControllerUnbound: &pb.ClientControllerUnbound{}}})
 				if err != nil {
@@ -126,7 +301,24 @@ func (s *ClientControllerServer) Session(srv pb.ClientController_SessionServer)
 					return err
 				}
 				break LBoundSession
+			case <-s.broker.ShuttingDown():
+				endsForGood = true
+				return s.shutDownClient(srv, true, logger)
+			case <-streamCtx.Done():
+				return streamEnded(srv)
 			}
 		}
 	}
 }
+
+// sendSensorSamplingPeriodsResponse tells the client whether its sampling
+// periods were applied, or why not if err is set
+func sendSensorSamplingPeriodsResponse(srv pb.ClientController_SessionServer, err error) error {
+	res := &pb.SensorSamplingPeriodsResponse{Data: &pb.SensorSamplingPeriodsResponse_Ok_{Ok: &pb.SensorSamplingPeriodsResponse_Ok{}}}
+	if err != nil {
+		res.Data = &pb.SensorSamplingPeriodsResponse_Error{Error: err.Error()}
+	}
+	return srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_SensorSamplingPeriodsResponse{
+		SensorSamplingPeriodsResponse: res,
+	}})
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Flags which can only be given on the command line
+var commandLineOnly = map[string]bool{
+	"config":       true,
+	"print-config": true,
+	// broker replay
+	"name":  true,
+	"arena": true,
+	"speed": true,
+	"step":  true,
+}
+
+// Options which are redacted by -print-config
+var secretOptions = map[string]bool{
+	"admin-token": true,
+}
+
+// Options which may be given as a map in the config file, whose entries are
+// joined as a list of KEY=VALUE
+var mapOptions = map[string]bool{
+	"pair": true,
+}
+
+// envName returns the environment variable which overrides an option
+func envName(option string) string {
+	return "EREBUS_" + strings.ToUpper(strings.Replace(option, "-", "_", -1))
+}
+
+// applyConfig sets the flags which weren't given on the command line from the
+// YAML config file at path, if any, and then from EREBUS_* environment
+// variables, so that the command line overrides the environment, which
+// overrides the config file.
+//
+// Config file keys are flag names. Keys of nested sections are joined to their
+// section's with "-", so "heartbeat: {interval: 5s}" sets -heartbeat-interval,
+// and lists are joined with ",". Options in mapOptions may also be maps, which
+// are joined as a list of KEY=VALUE in key order.
+func applyConfig(flags *flag.FlagSet, path string, lookupEnv func(string) (string, bool)) error {
+	onCommandLine := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		onCommandLine[f.Name] = true
+	})
+	values := make(map[string]string)
+	// Where each value came from, for errors
+	sources := make(map[string]string)
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var raw map[string]interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("%s: %s", path, err.Error())
+		}
+		if err := flattenConfig("", raw, values); err != nil {
+			return fmt.Errorf("%s: %s", path, err.Error())
+		}
+		for name := range values {
+			if flags.Lookup(name) == nil || commandLineOnly[name] {
+				return fmt.Errorf("%s: unknown option \"%s\"", path, name)
+			}
+			sources[name] = path
+		}
+	}
+	flags.VisitAll(func(f *flag.Flag) {
+		if commandLineOnly[f.Name] {
+			return
+		}
+		if value, ok := lookupEnv(envName(f.Name)); ok {
+			values[f.Name] = value
+			sources[f.Name] = "$" + envName(f.Name)
+		}
+	})
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if onCommandLine[name] {
+			continue
+		}
+		if err := flags.Set(name, values[name]); err != nil {
+			return fmt.Errorf("%s: invalid value \"%s\" for %s: %s", sources[name], values[name], name, err.Error())
+		}
+	}
+	return nil
+}
+
+// flattenConfig adds the options of a config file section to values
+func flattenConfig(prefix string, section map[string]interface{}, values map[string]string) error {
+	for key, value := range section {
+		name := key
+		if prefix != "" {
+			name = prefix + "-" + key
+		}
+		switch value := value.(type) {
+		case map[interface{}]interface{}:
+			if mapOptions[name] {
+				entries := make([]string, 0, len(value))
+				for k, v := range value {
+					entries = append(entries, fmt.Sprintf("%v=%v", k, v))
+				}
+				sort.Strings(entries)
+				values[name] = strings.Join(entries, ",")
+				continue
+			}
+			nested := make(map[string]interface{}, len(value))
+			for k, v := range value {
+				nested[fmt.Sprint(k)] = v
+			}
+			if err := flattenConfig(name, nested, values); err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, len(value))
+			for i, item := range value {
+				switch item.(type) {
+				case map[interface{}]interface{}, []interface{}:
+					return fmt.Errorf("%s: lists may only hold plain values", name)
+				}
+				items[i] = fmt.Sprint(item)
+			}
+			values[name] = strings.Join(items, ",")
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(value)
+		}
+	}
+	return nil
+}
+
+// printConfig writes the value of every configurable option as a config file
+func printConfig(w io.Writer, flags *flag.FlagSet) error {
+	values := make(map[string]interface{})
+	flags.VisitAll(func(f *flag.Flag) {
+		if commandLineOnly[f.Name] {
+			return
+		}
+		var value interface{} = f.Value.String()
+		if getter, ok := f.Value.(flag.Getter); ok {
+			// Durations are left as strings, which read better than
+			// nanoseconds
+			switch v := getter.Get().(type) {
+			case bool, int, float64:
+				value = v
+			}
+		}
+		if secretOptions[f.Name] && value != "" {
+			value = "<redacted>"
+		}
+		values[f.Name] = value
+	})
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
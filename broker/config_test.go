@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+	dir   string
+	flags *flag.FlagSet
+	env   map[string]string
+
+	timestep    *int
+	syncTimeout *time.Duration
+	listen      *string
+	adminToken  *string
+	pair        *string
+}
+
+func (suite *ConfigSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "erebus-config-test")
+	suite.Require().NoError(err)
+	suite.dir = dir
+	suite.flags = flag.NewFlagSet("broker", flag.ContinueOnError)
+	suite.flags.SetOutput(ioutil.Discard)
+	suite.flags.String("config", "", "")
+	suite.timestep = suite.flags.Int("timestep", 32, "")
+	suite.syncTimeout = suite.flags.Duration("sync-timeout", time.Second, "")
+	suite.listen = suite.flags.String("listen", "", "")
+	suite.adminToken = suite.flags.String("admin-token", "", "")
+	suite.pair = suite.flags.String("pair", "", "")
+	suite.env = make(map[string]string)
+}
+
+func (suite *ConfigSuite) TearDownTest() {
+	os.RemoveAll(suite.dir)
+}
+
+func (suite *ConfigSuite) lookupEnv(name string) (string, bool) {
+	value, ok := suite.env[name]
+	return value, ok
+}
+
+// apply parses args and applies a config file with the given contents
+func (suite *ConfigSuite) apply(config string, args ...string) error {
+	path := filepath.Join(suite.dir, "erebus.yaml")
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(config), 0644))
+	suite.Require().NoError(suite.flags.Parse(args))
+	return applyConfig(suite.flags, path, suite.lookupEnv)
+}
+
+func (suite *ConfigSuite) TestConfigFile() {
+	suite.Require().NoError(suite.apply(`
+timestep: 16
+sync:
+  timeout: 500ms
+listen: [":51512", "127.0.0.1:51600"]
+`))
+	suite.Equal(16, *suite.timestep)
+	suite.Equal(500*time.Millisecond, *suite.syncTimeout)
+	suite.Equal(":51512,127.0.0.1:51600", *suite.listen)
+}
+
+func (suite *ConfigSuite) TestMapOption() {
+	suite.Require().NoError(suite.apply(`
+pair:
+  team-b: robot1
+  team-a: robot0
+`))
+	suite.Equal("team-a=robot0,team-b=robot1", *suite.pair)
+}
+
+func (suite *ConfigSuite) TestPrecedence() {
+	suite.env["EREBUS_TIMESTEP"] = "8"
+	suite.env["EREBUS_SYNC_TIMEOUT"] = "2s"
+	suite.Require().NoError(suite.apply("timestep: 16\nsync-timeout: 3s\n", "-timestep", "64"))
+	suite.Equal(64, *suite.timestep)
+	suite.Equal(2*time.Second, *suite.syncTimeout)
+}
+
+func (suite *ConfigSuite) TestInvalid() {
+	suite.Error(suite.apply("timestp: 16\n"))
+	suite.SetupTest()
+	suite.Error(suite.apply("config: other.yaml\n"))
+	suite.SetupTest()
+	suite.Error(suite.apply("timestep: fast\n"))
+	suite.SetupTest()
+	suite.Error(suite.apply("listen: [{a: b}]\n"))
+	suite.SetupTest()
+	suite.env["EREBUS_SYNC_TIMEOUT"] = "abc"
+	suite.Error(suite.apply(""))
+}
+
+func (suite *ConfigSuite) TestCommandLineOnly() {
+	arena := suite.flags.String("arena", "", "")
+	suite.env["EREBUS_ARENA"] = "arena.yaml"
+	suite.Require().NoError(suite.apply(""))
+	suite.Equal("", *arena)
+	suite.Require().NoError(suite.apply("", "-arena", "other.yaml"))
+	suite.Equal("other.yaml", *arena)
+
+	suite.SetupTest()
+	suite.flags.String("arena", "", "")
+	suite.Error(suite.apply("arena: arena.yaml\n"))
+}
+
+func (suite *ConfigSuite) TestPrintConfig() {
+	suite.Require().NoError(suite.apply("admin-token: hunter2\n"))
+	var out bytes.Buffer
+	suite.Require().NoError(printConfig(&out, suite.flags))
+	suite.Equal(`admin-token: <redacted>
+listen: ""
+pair: ""
+sync-timeout: 1s
+timestep: 32
+`, out.String())
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
@@ -2,8 +2,9 @@ package main
 
 import (
 	"context"
-	"errors"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 
 	pb "github.com/ethanwu10/erebus/broker/gen"
@@ -14,23 +15,71 @@ var _ = logrus.New // FIXME
 type ControlServer struct {
 	pb.UnimplementedControlServer
 
-	broker *Broker
+	broker   *Broker
+	recorder *Recorder
+	replayer *Replayer
+	pairer   *Pairer
+	clock    *MatchClock
 }
 
-func NewControlServer(broker *Broker) *ControlServer {
-	return &ControlServer{broker: broker}
+func NewControlServer(broker *Broker, recorder *Recorder, replayer *Replayer, pairer *Pairer, clock *MatchClock) *ControlServer {
+	return &ControlServer{broker: broker, recorder: recorder, replayer: replayer, pairer: pairer, clock: clock}
 }
 
 func (s *ControlServer) GetRobots(context.Context, *pb.Null) (*pb.ControlMessage_GetRobotsResponse, error) {
 	return &pb.ControlMessage_GetRobotsResponse{RobotNames: s.broker.GetRobotNames()}, nil
 }
 
+func (s *ControlServer) DescribeRobot(_ context.Context, req *pb.ControlMessage_DescribeRobotRequest) (*pb.ControlMessage_DescribeRobotResponse, error) {
+	info, err := s.broker.GetRobotInfo(req.GetRobotName())
+	if err != nil {
+		return &pb.ControlMessage_DescribeRobotResponse{Data: &pb.ControlMessage_DescribeRobotResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_DescribeRobotResponse{Data: &pb.ControlMessage_DescribeRobotResponse_Ok_{Ok: &pb.ControlMessage_DescribeRobotResponse_Ok{RobotInfo: info}}}, nil
+}
+
 func (s *ControlServer) GetClientControllers(context.Context, *pb.Null) (*pb.ControlMessage_GetClientControllersResponse, error) {
 	return &pb.ControlMessage_GetClientControllersResponse{ControllerNames: s.broker.GetClientNames()}, nil
 }
 
 func (s *ControlServer) SubscribeClientControllers(_ *pb.Null, srv pb.Control_SubscribeClientControllersServer) error {
-	return errors.New("Not yet implemented")
+	ctx, cancel := s.broker.untilShutdown(srv.Context())
+	defer cancel()
+	// Clients already present are reported as having just joined
+	names, events := s.broker.GetClientListener(ctx)
+	for _, name := range names {
+		if err := srv.Send(&pb.ControlMessage_SubscribeClientControllersMessage{
+			EventType:      pb.ControlMessage_SubscribeClientControllersMessage_JOINED,
+			ControllerName: name,
+		}); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			var eventType pb.ControlMessage_SubscribeClientControllersMessage_EventType
+			switch event.Type {
+			case EventClientRegistered:
+				eventType = pb.ControlMessage_SubscribeClientControllersMessage_JOINED
+			case EventClientUnregistered:
+				eventType = pb.ControlMessage_SubscribeClientControllersMessage_PARTED
+			default:
+				continue
+			}
+			if err := srv.Send(&pb.ControlMessage_SubscribeClientControllersMessage{
+				EventType:      eventType,
+				ControllerName: event.ClientName,
+			}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
 func (s *ControlServer) GetSimulationState(context.Context, *pb.Null) (*pb.SimState, error) {
@@ -39,7 +88,8 @@ func (s *ControlServer) GetSimulationState(context.Context, *pb.Null) (*pb.SimSt
 }
 
 func (s *ControlServer) SubscribeSimulationState(_ *pb.Null, srv pb.Control_SubscribeSimulationStateServer) error {
-	ctx := srv.Context()
+	ctx, cancel := s.broker.untilShutdown(srv.Context())
+	defer cancel()
 	sscChan := s.broker.GetSimStateListener(ctx)
 	for {
 		select {
@@ -56,9 +106,20 @@ func (s *ControlServer) SetSimulationState(_ context.Context, state *pb.SimState
 	return &pb.Null{}, nil
 }
 
+func (s *ControlServer) GetMatchStatus(context.Context, *pb.Null) (*pb.ControlMessage_MatchStatus, error) {
+	status := s.clock.Status()
+	return &pb.ControlMessage_MatchStatus{
+		Duration:  status.Duration.Seconds(),
+		Elapsed:   status.Elapsed.Seconds(),
+		Remaining: status.Remaining.Seconds(),
+		Running:   status.Running,
+		Expired:   status.Expired,
+		SimTimed:  status.SimTimed,
+	}, nil
+}
+
 func (s *ControlServer) ConnectClientToRobot(_ context.Context, req *pb.ControlMessage_ConnectClientToRobotRequest) (*pb.ControlMessage_ConnectClientToRobotResponse, error) {
-	// TODO: sync behavior
-	err := s.broker.ConnectClientToRobot(req.GetClientName(), req.GetRobotName(), true)
+	err := s.broker.ConnectClientToRobot(req.GetClientName(), req.GetRobotName())
 	if err != nil {
 		return &pb.ControlMessage_ConnectClientToRobotResponse{Data: &pb.ControlMessage_ConnectClientToRobotResponse_Error{Error: err.Error()}}, nil
 	}
@@ -72,3 +133,257 @@ func (s *ControlServer) DisconnectClientFromRobot(_ context.Context, req *pb.Con
 	}
 	return &pb.ControlMessage_DisconnectClientFromRobotResponse{Data: &pb.ControlMessage_DisconnectClientFromRobotResponse_Ok_{Ok: &pb.ControlMessage_DisconnectClientFromRobotResponse_Ok{}}}, nil
 }
+
+func (s *ControlServer) GetConnections(context.Context, *pb.Null) (*pb.ControlMessage_GetConnectionsResponse, error) {
+	conns := s.broker.GetConnections()
+	res := &pb.ControlMessage_GetConnectionsResponse{
+		Connections: make([]*pb.ControlMessage_Connection, 0, len(conns)),
+	}
+	for _, conn := range conns {
+		connection, err := connectionProto(conn)
+		if err != nil {
+			return nil, err
+		}
+		res.Connections = append(res.Connections, connection)
+	}
+	return res, nil
+}
+
+func connectionProto(conn ConnectionInfo) (*pb.ControlMessage_Connection, error) {
+	boundSince, err := ptypes.TimestampProto(conn.BoundSince)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ControlMessage_Connection{
+		ClientName: conn.ClientName,
+		RobotName:  conn.RobotName,
+		IsSync:     conn.IsSync,
+		BoundSince: boundSince,
+
+		RejectedCommands: conn.RejectedCommands,
+	}, nil
+}
+
+var eventTypes = map[EventType]pb.ControlMessage_BrokerEvent_EventType{
+	EventRobotRegistered:    pb.ControlMessage_BrokerEvent_ROBOT_REGISTERED,
+	EventRobotUnregistered:  pb.ControlMessage_BrokerEvent_ROBOT_UNREGISTERED,
+	EventClientRegistered:   pb.ControlMessage_BrokerEvent_CLIENT_REGISTERED,
+	EventClientUnregistered: pb.ControlMessage_BrokerEvent_CLIENT_UNREGISTERED,
+	EventConnectionBound:    pb.ControlMessage_BrokerEvent_CONNECTION_BOUND,
+	EventConnectionUnbound:  pb.ControlMessage_BrokerEvent_CONNECTION_UNBOUND,
+	EventSimStateChanged:    pb.ControlMessage_BrokerEvent_SIM_STATE_CHANGED,
+	EventShuttingDown:       pb.ControlMessage_BrokerEvent_SHUTTING_DOWN,
+}
+
+func (s *ControlServer) SubscribeEvents(_ *pb.Null, srv pb.Control_SubscribeEventsServer) error {
+	ctx, cancel := s.broker.untilShutdown(srv.Context())
+	defer cancel()
+	events := s.broker.GetEventListener(ctx)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			eventTime, err := ptypes.TimestampProto(event.Time)
+			if err != nil {
+				return err
+			}
+			if err := srv.Send(&pb.ControlMessage_BrokerEvent{
+				EventType:  eventTypes[event.Type],
+				Time:       eventTime,
+				RobotName:  event.RobotName,
+				ClientName: event.ClientName,
+				SimState:   event.SimState,
+			}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *ControlServer) StartRecording(_ context.Context, req *pb.ControlMessage_StartRecordingRequest) (*pb.ControlMessage_StartRecordingResponse, error) {
+	err := s.recorder.Start(req.GetDirectory())
+	if err != nil {
+		return &pb.ControlMessage_StartRecordingResponse{Data: &pb.ControlMessage_StartRecordingResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_StartRecordingResponse{Data: &pb.ControlMessage_StartRecordingResponse_Ok_{Ok: &pb.ControlMessage_StartRecordingResponse_Ok{}}}, nil
+}
+
+func (s *ControlServer) StopRecording(context.Context, *pb.Null) (*pb.ControlMessage_StopRecordingResponse, error) {
+	err := s.recorder.Stop()
+	if err != nil {
+		return &pb.ControlMessage_StopRecordingResponse{Data: &pb.ControlMessage_StopRecordingResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_StopRecordingResponse{Data: &pb.ControlMessage_StopRecordingResponse_Ok_{Ok: &pb.ControlMessage_StopRecordingResponse_Ok{}}}, nil
+}
+
+func (s *ControlServer) StartReplay(_ context.Context, req *pb.ControlMessage_StartReplayRequest) (*pb.ControlMessage_StartReplayResponse, error) {
+	name, err := s.replayer.Start(req.GetRobotName(), req.GetRecordingPath(), ReplayOptions{
+		Speed:    req.GetSpeed(),
+		StepMode: req.GetStepMode(),
+	})
+	if err != nil {
+		return &pb.ControlMessage_StartReplayResponse{Data: &pb.ControlMessage_StartReplayResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_StartReplayResponse{Data: &pb.ControlMessage_StartReplayResponse_Ok_{Ok: &pb.ControlMessage_StartReplayResponse_Ok{RobotName: name}}}, nil
+}
+
+func (s *ControlServer) StepReplay(_ context.Context, req *pb.ControlMessage_StepReplayRequest) (*pb.ControlMessage_StepReplayResponse, error) {
+	err := s.replayer.Step(req.GetRobotName(), int(req.GetFrames()))
+	if err != nil {
+		return &pb.ControlMessage_StepReplayResponse{Data: &pb.ControlMessage_StepReplayResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_StepReplayResponse{Data: &pb.ControlMessage_StepReplayResponse_Ok_{Ok: &pb.ControlMessage_StepReplayResponse_Ok{}}}, nil
+}
+
+func (s *ControlServer) StopReplay(_ context.Context, req *pb.ControlMessage_StopReplayRequest) (*pb.ControlMessage_StopReplayResponse, error) {
+	err := s.replayer.Stop(req.GetRobotName())
+	if err != nil {
+		return &pb.ControlMessage_StopReplayResponse{Data: &pb.ControlMessage_StopReplayResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_StopReplayResponse{Data: &pb.ControlMessage_StopReplayResponse_Ok_{Ok: &pb.ControlMessage_StopReplayResponse_Ok{}}}, nil
+}
+
+var commandDiffKinds = map[CommandDiffKind]pb.ControlMessage_CommandDiff_Kind{
+	CommandMissing:  pb.ControlMessage_CommandDiff_MISSING,
+	CommandExtra:    pb.ControlMessage_CommandDiff_EXTRA,
+	CommandVelocity: pb.ControlMessage_CommandDiff_VELOCITY,
+	CommandLED:      pb.ControlMessage_CommandDiff_LED,
+	CommandType:     pb.ControlMessage_CommandDiff_TYPE,
+}
+
+func (s *ControlServer) Regress(ctx context.Context, req *pb.ControlMessage_RegressRequest) (*pb.ControlMessage_RegressResponse, error) {
+	seconds := func(secs float64) time.Duration { return time.Duration(secs * float64(time.Second)) }
+	report, err := s.replayer.Regress(ctx, req.GetRecordingPath(), req.GetClientName(), RegressionOptions{
+		RobotName:         req.GetRobotName(),
+		ClientTimeout:     seconds(req.GetClientTimeout()),
+		VelocityTolerance: req.GetVelocityTolerance(),
+		LatencyTolerance:  seconds(req.GetLatencyTolerance()),
+	})
+	if err != nil {
+		return &pb.ControlMessage_RegressResponse{Data: &pb.ControlMessage_RegressResponse_Error{Error: err.Error()}}, nil
+	}
+	ok := &pb.ControlMessage_RegressResponse_Ok{
+		RobotName:           report.RobotName,
+		Frames:              int32(report.Frames),
+		MaxVelocityDelta:    report.MaxVelocityDelta,
+		MeanExpectedLatency: report.MeanExpectedLatency.Seconds(),
+		MeanActualLatency:   report.MeanActualLatency.Seconds(),
+		MaxActualLatency:    report.MaxActualLatency.Seconds(),
+	}
+	for _, failure := range report.Failures {
+		frame := &pb.ControlMessage_RegressionFrame{
+			Index:           int32(failure.Index),
+			Timestamp:       failure.Timestamp,
+			MissedReply:     failure.MissedReply,
+			ExpectedLatency: failure.ExpectedLatency.Seconds(),
+			ActualLatency:   failure.ActualLatency.Seconds(),
+			LatencyExceeded: failure.LatencyExceeded,
+		}
+		for _, diff := range failure.Commands {
+			frame.CommandDiffs = append(frame.CommandDiffs, &pb.ControlMessage_CommandDiff{
+				Kind:          commandDiffKinds[diff.Kind],
+				Device:        diff.Device,
+				Expected:      diff.Expected,
+				Actual:        diff.Actual,
+				VelocityDelta: diff.VelocityDelta,
+			})
+		}
+		ok.Failures = append(ok.Failures, frame)
+	}
+	return &pb.ControlMessage_RegressResponse{Data: &pb.ControlMessage_RegressResponse_Ok_{Ok: ok}}, nil
+}
+
+func (s *ControlServer) GetPairingRules(context.Context, *pb.Null) (*pb.ControlMessage_GetPairingRulesResponse, error) {
+	statuses := s.pairer.Rules()
+	res := &pb.ControlMessage_GetPairingRulesResponse{
+		Rules: make([]*pb.ControlMessage_PairingRuleStatus, 0, len(statuses)),
+	}
+	for _, status := range statuses {
+		rule := &pb.ControlMessage_PairingRuleStatus{
+			Rule:           status.Rule,
+			WaitingClients: status.WaitingClients,
+		}
+		for _, conn := range status.Pairs {
+			connection, err := connectionProto(conn)
+			if err != nil {
+				return nil, err
+			}
+			rule.Pairs = append(rule.Pairs, connection)
+		}
+		res.Rules = append(res.Rules, rule)
+	}
+	return res, nil
+}
+
+func (s *ControlServer) AddPairingRule(_ context.Context, req *pb.ControlMessage_AddPairingRuleRequest) (*pb.ControlMessage_AddPairingRuleResponse, error) {
+	rule, err := ParsePairingRule(req.GetRule())
+	if err == nil {
+		err = s.pairer.Add(rule)
+	}
+	if err != nil {
+		return &pb.ControlMessage_AddPairingRuleResponse{Data: &pb.ControlMessage_AddPairingRuleResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_AddPairingRuleResponse{Data: &pb.ControlMessage_AddPairingRuleResponse_Ok_{Ok: &pb.ControlMessage_AddPairingRuleResponse_Ok{}}}, nil
+}
+
+func (s *ControlServer) RemovePairingRule(_ context.Context, req *pb.ControlMessage_RemovePairingRuleRequest) (*pb.ControlMessage_RemovePairingRuleResponse, error) {
+	if err := s.pairer.Remove(req.GetRule()); err != nil {
+		return &pb.ControlMessage_RemovePairingRuleResponse{Data: &pb.ControlMessage_RemovePairingRuleResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.ControlMessage_RemovePairingRuleResponse{Data: &pb.ControlMessage_RemovePairingRuleResponse_Ok_{Ok: &pb.ControlMessage_RemovePairingRuleResponse_Ok{}}}, nil
+}
+
+func (s *ControlServer) WatchRobot(req *pb.ControlMessage_WatchRobotRequest, srv pb.Control_WatchRobotServer) error {
+	ctx, cancel := s.broker.untilShutdown(srv.Context())
+	defer cancel()
+	traffic, err := s.broker.WatchRobot(ctx, req.GetRobotName())
+	if err != nil {
+		return srv.Send(&pb.ControlMessage_RobotTraffic{Data: &pb.ControlMessage_RobotTraffic_Error{Error: err.Error()}})
+	}
+	for t := range traffic {
+		trafficTime, err := ptypes.TimestampProto(t.Time)
+		if err != nil {
+			return err
+		}
+		msg := &pb.ControlMessage_RobotTraffic{
+			Time:       trafficTime,
+			ClientName: t.ClientName,
+			Dropped:    t.Dropped,
+		}
+		switch t.Type {
+		case TrafficBound:
+			msg.Data = &pb.ControlMessage_RobotTraffic_Bound_{Bound: &pb.ControlMessage_RobotTraffic_Bound{IsSync: t.IsSync}}
+		case TrafficUnbound:
+			msg.Data = &pb.ControlMessage_RobotTraffic_Unbound_{Unbound: &pb.ControlMessage_RobotTraffic_Unbound{}}
+		case TrafficSensorsData:
+			msg.Data = &pb.ControlMessage_RobotTraffic_SensorsData{SensorsData: t.SensorsData}
+		case TrafficCommands:
+			msg.Data = &pb.ControlMessage_RobotTraffic_Commands{Commands: t.Commands}
+			msg.FromPolicy = t.FromPolicy
+		}
+		if err := srv.Send(msg); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *ControlServer) Shutdown(_ context.Context, req *pb.ControlMessage_ShutdownRequest) (*pb.ControlMessage_ShutdownResponse, error) {
+	return shutdownResponse(req, s.broker.Shutdown), nil
+}
+
+// shutdownResponse answers a shutdown request by calling shutdown
+func shutdownResponse(req *pb.ControlMessage_ShutdownRequest, shutdown func(reason string) error) *pb.ControlMessage_ShutdownResponse {
+	reason := req.GetReason()
+	if reason == "" {
+		reason = "Shut down by an operator"
+	}
+	if err := shutdown(reason); err != nil {
+		return &pb.ControlMessage_ShutdownResponse{Data: &pb.ControlMessage_ShutdownResponse_Error{Error: err.Error()}}
+	}
+	return &pb.ControlMessage_ShutdownResponse{Data: &pb.ControlMessage_ShutdownResponse_Ok_{Ok: &pb.ControlMessage_ShutdownResponse_Ok{}}}
+}
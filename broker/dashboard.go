@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Dashboard serves a web page for operating matches from a browser, along
+// with the gateway to the Control service it uses, so the page can do nothing
+// the CLI can't
+type Dashboard struct {
+	gateway *Gateway
+}
+
+// NewDashboard creates a dashboard operating the broker through gateway
+func NewDashboard(gateway *Gateway) *Dashboard {
+	return &Dashboard{gateway: gateway}
+}
+
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, gatewayPrefix+"/") {
+		d.gateway.ServeHTTP(w, r)
+		return
+	}
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, dashboardPage)
+}
@@ -0,0 +1,337 @@
+package main
+
+// dashboardPage is the dashboard's web page. It is self-contained so that it
+// works at events without internet access.
+const dashboardPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Erebus</title>
+<style>
+  body { font-family: sans-serif; margin: 0; background: #f4f4f4; color: #222; }
+  header { display: flex; align-items: center; gap: 1em; padding: 0.8em 1.2em; background: #222; color: #fff; }
+  header h1 { font-size: 1.3em; margin: 0; flex: 1; }
+  #state { font-weight: bold; padding: 0.3em 0.8em; border-radius: 4px; background: #666; }
+  #state.START { background: #2a8a2a; }
+  #state.STOP { background: #b07a10; }
+  #state.RESET { background: #555; }
+  #live { font-size: 0.9em; }
+  main { display: grid; grid-template-columns: repeat(auto-fit, minmax(18em, 1fr)); gap: 1em; padding: 1em; }
+  section { background: #fff; border-radius: 6px; padding: 0.8em 1em; box-shadow: 0 1px 3px rgba(0, 0, 0, 0.15); }
+  section.wide { grid-column: 1 / -1; }
+  h2 { font-size: 1.1em; margin: 0 0 0.6em; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { text-align: left; padding: 0.3em 0.4em; border-bottom: 1px solid #ddd; }
+  ul { list-style: none; padding: 0; margin: 0; }
+  li { padding: 0.2em 0; }
+  .bound { color: #888; }
+  .empty { color: #888; font-style: italic; }
+  button { font-size: 1em; padding: 0.4em 1em; border: 0; border-radius: 4px; cursor: pointer; background: #ddd; }
+  button.start { background: #2a8a2a; color: #fff; }
+  button.stop { background: #b07a10; color: #fff; }
+  button.reset { background: #a33; color: #fff; }
+  select { font-size: 1em; padding: 0.3em; }
+  form { display: flex; flex-wrap: wrap; gap: 0.5em; align-items: center; margin-top: 0.8em; }
+  #error { display: none; margin: 1em 1em 0; padding: 0.6em 1em; background: #fdd; border-radius: 4px; }
+  #log { font-family: monospace; font-size: 0.85em; max-height: 14em; overflow-y: auto; }
+</style>
+</head>
+<body>
+<header>
+  <h1>Erebus</h1>
+  <select id="arena" hidden></select>
+  <span id="live">Connecting&hellip;</span>
+  <span id="state">UNKNOWN</span>
+  <button class="start" data-state="START">Start</button>
+  <button class="stop" data-state="STOP">Pause</button>
+  <button class="reset" data-state="RESET">Reset</button>
+</header>
+<div id="error"></div>
+<main>
+  <section>
+    <h2>Robots</h2>
+    <ul id="robots"></ul>
+  </section>
+  <section>
+    <h2>Clients</h2>
+    <ul id="clients"></ul>
+  </section>
+  <section class="wide">
+    <h2>Connections</h2>
+    <table>
+      <thead><tr><th>Client</th><th>Robot</th><th>Mode</th><th>Bound since</th><th></th></tr></thead>
+      <tbody id="connections"></tbody>
+    </table>
+    <form id="connect">
+      <select id="connect-client"></select>
+      <span>&rarr;</span>
+      <select id="connect-robot"></select>
+      <button type="submit">Connect</button>
+    </form>
+  </section>
+  <section class="wide">
+    <h2>Events</h2>
+    <div id="log"></div>
+  </section>
+</main>
+<script>
+"use strict";
+
+var tokenKey = "erebus-admin-token";
+
+// Arena the dashboard operates, chosen with ?arena= if the broker runs several
+var arena = new URLSearchParams(window.location.search).get("arena") || "";
+
+// apiPath names the dashboard's arena in a call to the broker
+function apiPath(path) {
+  return arena ? path + "?arena=" + encodeURIComponent(arena) : path;
+}
+
+function showError(message) {
+  var el = document.getElementById("error");
+  el.textContent = message;
+  el.style.display = message ? "block" : "none";
+}
+
+function authHeaders() {
+  var headers = {};
+  var token = sessionStorage.getItem(tokenKey);
+  if (token) {
+    headers["Authorization"] = "Bearer " + token;
+  }
+  return headers;
+}
+
+// askToken asks for the admin token, returning whether one was given
+function askToken() {
+  var token = window.prompt("Admin token");
+  if (token === null) {
+    return false;
+  }
+  sessionStorage.setItem(tokenKey, token);
+  return true;
+}
+
+// api calls the broker, resolving to the response message. RPC errors are
+// shown and rejected.
+function api(method, path, body) {
+  var init = { method: method, headers: authHeaders() };
+  if (body !== undefined) {
+    init.body = JSON.stringify(body);
+    init.headers["Content-Type"] = "application/json";
+  }
+  return fetch(apiPath(path), init).then(function (res) {
+    if (res.status === 401 && askToken()) {
+      return api(method, path, body);
+    }
+    return res.json().then(function (msg) {
+      if (msg && msg.error) {
+        showError(msg.error);
+        throw new Error(msg.error);
+      }
+      showError("");
+      return msg;
+    });
+  });
+}
+
+function clear(el) {
+  while (el.firstChild) {
+    el.removeChild(el.firstChild);
+  }
+}
+
+function listItems(el, names, boundNames) {
+  clear(el);
+  if (names.length === 0) {
+    var empty = document.createElement("li");
+    empty.className = "empty";
+    empty.textContent = "None";
+    el.appendChild(empty);
+  }
+  names.forEach(function (name) {
+    var item = document.createElement("li");
+    item.textContent = name;
+    if (boundNames[name]) {
+      item.className = "bound";
+      item.textContent += " (bound)";
+    }
+    el.appendChild(item);
+  });
+}
+
+function options(el, names) {
+  var selected = el.value;
+  clear(el);
+  names.forEach(function (name) {
+    var option = document.createElement("option");
+    option.value = option.textContent = name;
+    el.appendChild(option);
+  });
+  if (names.indexOf(selected) >= 0) {
+    el.value = selected;
+  }
+}
+
+function refresh() {
+  return Promise.all([
+    api("GET", "api/robots"),
+    api("GET", "api/clients"),
+    api("GET", "api/connections"),
+    api("GET", "api/simulation-state")
+  ]).then(function (results) {
+    var robots = results[0].robotNames.slice().sort();
+    var clients = results[1].controllerNames.slice().sort();
+    var connections = results[2].connections;
+    var state = results[3].state;
+
+    var stateEl = document.getElementById("state");
+    stateEl.textContent = state;
+    stateEl.className = state;
+
+    var boundRobots = {};
+    var boundClients = {};
+    var rows = document.getElementById("connections");
+    clear(rows);
+    connections.forEach(function (conn) {
+      boundRobots[conn.robotName] = true;
+      boundClients[conn.clientName] = true;
+      var row = document.createElement("tr");
+      var since = conn.boundSince ? new Date(conn.boundSince).toLocaleTimeString() : "";
+      [conn.clientName, conn.robotName, conn.isSync ? "sync" : "async", since].forEach(function (text) {
+        var cell = document.createElement("td");
+        cell.textContent = text;
+        row.appendChild(cell);
+      });
+      var cell = document.createElement("td");
+      var button = document.createElement("button");
+      button.textContent = "Disconnect";
+      button.onclick = function () {
+        api("DELETE", "api/connections/" + encodeURIComponent(conn.clientName)).then(refresh, function () {});
+      };
+      cell.appendChild(button);
+      row.appendChild(cell);
+      rows.appendChild(row);
+    });
+
+    listItems(document.getElementById("robots"), robots, boundRobots);
+    listItems(document.getElementById("clients"), clients, boundClients);
+    options(document.getElementById("connect-client"), clients.filter(function (name) { return !boundClients[name]; }));
+    options(document.getElementById("connect-robot"), robots.filter(function (name) { return !boundRobots[name]; }));
+  }, function () {});
+}
+
+function logEvent(event) {
+  var log = document.getElementById("log");
+  var line = document.createElement("div");
+  var fields = [new Date(event.time).toLocaleTimeString(), event.eventType.toLowerCase().replace(/_/g, "-")];
+  if (event.robotName) {
+    fields.push("robot=" + event.robotName);
+  }
+  if (event.clientName) {
+    fields.push("client=" + event.clientName);
+  }
+  if (event.simState) {
+    fields.push("state=" + event.simState.state.toLowerCase());
+  }
+  line.textContent = fields.join(" ");
+  log.insertBefore(line, log.firstChild);
+  while (log.childNodes.length > 200) {
+    log.removeChild(log.lastChild);
+  }
+}
+
+// subscribe follows the broker's events, refreshing on each one. The stream
+// is read with fetch rather than EventSource, which can't send the admin
+// token.
+function subscribe() {
+  var live = document.getElementById("live");
+  function retry() {
+    live.textContent = "Disconnected, retrying...";
+    setTimeout(subscribe, 2000);
+  }
+  fetch(apiPath("api/events"), { headers: authHeaders() }).then(function (res) {
+    if (res.status === 401) {
+      if (askToken()) {
+        subscribe();
+      } else {
+        live.textContent = "Not authorized";
+      }
+      return;
+    }
+    if (!res.ok || !res.body) {
+      retry();
+      return;
+    }
+    live.textContent = "Live";
+    refresh();
+    var reader = res.body.getReader();
+    var decoder = new TextDecoder();
+    var buffer = "";
+    function read() {
+      return reader.read().then(function (chunk) {
+        if (chunk.done) {
+          retry();
+          return;
+        }
+        buffer += decoder.decode(chunk.value, { stream: true });
+        var end;
+        while ((end = buffer.indexOf("\n\n")) >= 0) {
+          var message = buffer.slice(0, end);
+          buffer = buffer.slice(end + 2);
+          if (message.indexOf("data: ") === 0) {
+            logEvent(JSON.parse(message.slice(6)));
+          }
+        }
+        refresh();
+        return read();
+      });
+    }
+    return read();
+  }).catch(retry);
+}
+
+// chooseArena offers the broker's arenas to choose from if it runs several,
+// operating the first one until another is chosen
+function chooseArena() {
+  return api("GET", "api/arenas").then(function (res) {
+    var names = res.arenas.map(function (a) { return a.name; });
+    if (names.length < 2) {
+      return;
+    }
+    if (!arena) {
+      arena = names[0];
+    }
+    var select = document.getElementById("arena");
+    options(select, names);
+    select.value = arena;
+    select.hidden = false;
+    select.onchange = function () {
+      window.location.search = "?arena=" + encodeURIComponent(select.value);
+    };
+  }, function () {});
+}
+
+Array.prototype.forEach.call(document.querySelectorAll("header button"), function (button) {
+  button.onclick = function () {
+    api("PUT", "api/simulation-state", { state: button.dataset.state }).then(refresh, function () {});
+  };
+});
+
+document.getElementById("connect").onsubmit = function (e) {
+  e.preventDefault();
+  var clientName = document.getElementById("connect-client").value;
+  var robotName = document.getElementById("connect-robot").value;
+  if (!clientName || !robotName) {
+    showError("Choose a client and a robot to connect");
+    return;
+  }
+  api("POST", "api/connections", { clientName: clientName, robotName: robotName }).then(refresh, function () {});
+};
+
+chooseArena().then(subscribe);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DashboardSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	server         *httptest.Server
+}
+
+func (suite *DashboardSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	broker := NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+	gateway, err := NewGateway(NewControlServer(broker, nil, nil, nil, nil), "")
+	suite.Require().NoError(err)
+	suite.server = httptest.NewServer(NewDashboard(gateway))
+}
+
+func (suite *DashboardSuite) TearDownTest() {
+	suite.server.Close()
+	suite.globalCtxClose()
+}
+
+func (suite *DashboardSuite) get(path string) *http.Response {
+	res, err := http.Get(suite.server.URL + path)
+	suite.Require().NoError(err)
+	res.Body.Close()
+	return res
+}
+
+func (suite *DashboardSuite) TestPage() {
+	res := suite.get("/")
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Contains(res.Header.Get("Content-Type"), "text/html")
+	suite.Equal(http.StatusNotFound, suite.get("/nothing").StatusCode)
+}
+
+func (suite *DashboardSuite) TestGateway() {
+	res := suite.get("/api/robots")
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Equal("application/json", res.Header.Get("Content-Type"))
+}
+
+func TestDashboardSuite(t *testing.T) {
+	suite.Run(t, new(DashboardSuite))
+}
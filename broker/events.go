@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// EventType is the kind of change described by an Event
+type EventType int
+
+const (
+	EventRobotRegistered EventType = iota + 1
+	EventRobotUnregistered
+	EventClientRegistered
+	EventClientUnregistered
+	EventConnectionBound
+	EventConnectionUnbound
+	EventSimStateChanged
+	EventShuttingDown
+)
+
+// Event describes a change in the state of the broker
+type Event struct {
+	Type       EventType
+	Time       time.Time
+	RobotName  string
+	ClientName string
+	SimState   *pb.SimState
+}
+
+// Number of events buffered for each listener before further events are
+// dropped
+const eventListenerBuffer = 256
+
+// GetEventListener returns a channel which receives every event published by
+// the broker until ctx is done. Events are dropped if the listener falls too
+// far behind, so it suits streams to outside subscribers.
+func (b *Broker) GetEventListener(ctx context.Context) <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.addEventListener(ctx)
+}
+
+// GetClientListener returns the names of the registered clients along with an
+// event listener which receives every event published after them, so that
+// each client is either named or has its registration received, but not both
+func (b *Broker) GetClientListener(ctx context.Context) ([]string, <-chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := make([]string, 0, len(b.clients))
+	for name := range b.clients {
+		names = append(names, name)
+	}
+	return names, b.addEventListener(ctx)
+}
+
+// addEventListener adds an event listener until ctx is done. b.mu must be
+// held.
+func (b *Broker) addEventListener(ctx context.Context) <-chan Event {
+	ch := make(chan Event, eventListenerBuffer)
+	b.eventListeners[ch] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.eventListeners, ch)
+		close(ch)
+	}()
+	return ch
+}
+
+// eventQueue holds the events published for a listener which mustn't miss
+// any, until they are received
+type eventQueue struct {
+	mu     sync.Mutex
+	events []Event
+	// Receives a value when events are queued
+	queued chan struct{}
+}
+
+func (q *eventQueue) push(event Event) {
+	q.mu.Lock()
+	q.events = append(q.events, event)
+	q.mu.Unlock()
+	select {
+	case q.queued <- struct{}{}:
+	default:
+	}
+}
+
+// take removes and returns every queued event
+func (q *eventQueue) take() []Event {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	events := q.events
+	q.events = nil
+	return events
+}
+
+// GetEventQueue returns a channel which receives every event published by the
+// broker until ctx is done, as GetEventListener does, except that no event is
+// ever dropped: events are queued for as long as the listener takes to receive
+// them. It is meant for the parts of the broker whose state is built from
+// events.
+func (b *Broker) GetEventQueue(ctx context.Context) <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q := &eventQueue{queued: make(chan struct{}, 1)}
+	b.eventQueues[q] = struct{}{}
+	ch := make(chan Event)
+	go func() {
+		defer func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.eventQueues, q)
+			close(ch)
+		}()
+		for {
+			select {
+			case <-q.queued:
+			case <-ctx.Done():
+				return
+			}
+			for _, event := range q.take() {
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+// publishEvent sends an event to all event listeners and queues, and updates
+// the broker's metrics. b.mu must be held.
+func (b *Broker) publishEvent(event Event) {
+	event.Time = time.Now()
+	b.metrics.observeEvent(event)
+	for q := range b.eventQueues {
+		q.push(event)
+	}
+	for listener := range b.eventListeners {
+		select {
+		case listener <- event:
+		default:
+			log.WithField("event", event.Type).Warn("Event listener is full, dropping event")
+		}
+	}
+}
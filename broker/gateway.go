@@ -0,0 +1,523 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// Path the gateway's routes are served under
+const gatewayPrefix = "/api"
+
+// Gateway maps every RPC of the Control service to a REST endpoint taking and
+// returning JSON, answering each call with the broker's Control server.
+// Streaming RPCs are served as server-sent events, each carrying a message as
+// JSON. Requests and responses are the RPCs' messages in their JSON encoding,
+// with fields named in lowerCamelCase; fields set by the path needn't be in the
+// body. The routes are described by an OpenAPI document served at
+// /api/openapi.json.
+//
+// If the broker has an admin token, every call must send it as
+// "Authorization: Bearer <token>". Calls name their arena with an "arena"
+// query parameter, passed on as the RPC's arena metadata.
+type Gateway struct {
+	adminToken string
+	routes     []gatewayRoute
+	openAPI    []byte
+}
+
+// gatewayRoute maps a REST endpoint to an RPC
+type gatewayRoute struct {
+	method string
+	// Path under gatewayPrefix; segments of the form {field} match a single
+	// path segment and set that field of the request
+	path    string
+	rpc     string
+	summary string
+	// Prototypes of the RPC's request and response
+	request  proto.Message
+	response proto.Message
+	// Set for unary RPCs
+	call func(ctx context.Context, req proto.Message) (proto.Message, error)
+	// Set for streaming RPCs
+	stream func(req proto.Message, stream *sseStream) error
+}
+
+var (
+	jsonMarshaler   = &jsonpb.Marshaler{EmitDefaults: true}
+	jsonUnmarshaler = &jsonpb.Unmarshaler{}
+)
+
+// NewGateway creates a gateway to the Control service served by control
+func NewGateway(control pb.ControlServer, adminToken string) (*Gateway, error) {
+	g := &Gateway{
+		adminToken: adminToken,
+		routes:     controlRoutes(control),
+	}
+	doc, err := g.buildOpenAPI()
+	if err != nil {
+		return nil, err
+	}
+	if g.openAPI, err = json.MarshalIndent(doc, "", "  "); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func controlRoutes(s pb.ControlServer) []gatewayRoute {
+	return []gatewayRoute{
+		{
+			method: http.MethodGet, path: "/arenas", rpc: "GetArenas",
+			summary: "List the arenas run by the broker",
+			request: &pb.Null{}, response: &pb.ControlMessage_GetArenasResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetArenas(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/robots", rpc: "GetRobots",
+			summary: "List the registered robots",
+			request: &pb.Null{}, response: &pb.ControlMessage_GetRobotsResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetRobots(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/robots/{robotName}", rpc: "DescribeRobot",
+			summary: "Describe the devices of a robot",
+			request: &pb.ControlMessage_DescribeRobotRequest{}, response: &pb.ControlMessage_DescribeRobotResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.DescribeRobot(ctx, req.(*pb.ControlMessage_DescribeRobotRequest))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/robots/{robotName}/traffic", rpc: "WatchRobot",
+			summary: "Follow the sensor data and commands of a robot",
+			request: &pb.ControlMessage_WatchRobotRequest{}, response: &pb.ControlMessage_RobotTraffic{},
+			stream: func(req proto.Message, stream *sseStream) error {
+				return s.WatchRobot(req.(*pb.ControlMessage_WatchRobotRequest), watchRobotSSEStream{stream})
+			},
+		},
+		{
+			method: http.MethodGet, path: "/clients", rpc: "GetClientControllers",
+			summary: "List the registered clients",
+			request: &pb.Null{}, response: &pb.ControlMessage_GetClientControllersResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetClientControllers(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/clients/events", rpc: "SubscribeClientControllers",
+			summary: "Follow clients joining and leaving",
+			request: &pb.Null{}, response: &pb.ControlMessage_SubscribeClientControllersMessage{},
+			stream: func(req proto.Message, stream *sseStream) error {
+				return s.SubscribeClientControllers(req.(*pb.Null), clientControllersSSEStream{stream})
+			},
+		},
+		{
+			method: http.MethodGet, path: "/simulation-state", rpc: "GetSimulationState",
+			summary: "Get the simulation state",
+			request: &pb.Null{}, response: &pb.SimState{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetSimulationState(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/simulation-state", rpc: "SetSimulationState",
+			summary: "Start, stop or reset the simulation",
+			request: &pb.SimState{}, response: &pb.Null{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.SetSimulationState(ctx, req.(*pb.SimState))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/simulation-state/events", rpc: "SubscribeSimulationState",
+			summary: "Follow changes to the simulation state",
+			request: &pb.Null{}, response: &pb.SimState{},
+			stream: func(req proto.Message, stream *sseStream) error {
+				return s.SubscribeSimulationState(req.(*pb.Null), simStateSSEStream{stream})
+			},
+		},
+		{
+			method: http.MethodGet, path: "/match", rpc: "GetMatchStatus",
+			summary: "Get the state of the match clock",
+			request: &pb.Null{}, response: &pb.ControlMessage_MatchStatus{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetMatchStatus(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/connections", rpc: "GetConnections",
+			summary: "List the bound connections",
+			request: &pb.Null{}, response: &pb.ControlMessage_GetConnectionsResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetConnections(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/connections", rpc: "ConnectClientToRobot",
+			summary: "Bind a client to a robot",
+			request: &pb.ControlMessage_ConnectClientToRobotRequest{}, response: &pb.ControlMessage_ConnectClientToRobotResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.ConnectClientToRobot(ctx, req.(*pb.ControlMessage_ConnectClientToRobotRequest))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/connections/{clientName}", rpc: "DisconnectClientFromRobot",
+			summary: "Unbind a client from its robot",
+			request: &pb.ControlMessage_DisconnectClientFromRobotRequest{}, response: &pb.ControlMessage_DisconnectClientFromRobotResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.DisconnectClientFromRobot(ctx, req.(*pb.ControlMessage_DisconnectClientFromRobotRequest))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/events", rpc: "SubscribeEvents",
+			summary: "Follow every change to the broker's robots, clients, connections and simulation state",
+			request: &pb.Null{}, response: &pb.ControlMessage_BrokerEvent{},
+			stream: func(req proto.Message, stream *sseStream) error {
+				return s.SubscribeEvents(req.(*pb.Null), eventsSSEStream{stream})
+			},
+		},
+		{
+			method: http.MethodPost, path: "/recording", rpc: "StartRecording",
+			summary: "Start recording every bound connection",
+			request: &pb.ControlMessage_StartRecordingRequest{}, response: &pb.ControlMessage_StartRecordingResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.StartRecording(ctx, req.(*pb.ControlMessage_StartRecordingRequest))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/recording", rpc: "StopRecording",
+			summary: "Stop recording",
+			request: &pb.Null{}, response: &pb.ControlMessage_StopRecordingResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.StopRecording(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/replays", rpc: "StartReplay",
+			summary: "Start a replay robot playing back a recording",
+			request: &pb.ControlMessage_StartReplayRequest{}, response: &pb.ControlMessage_StartReplayResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.StartReplay(ctx, req.(*pb.ControlMessage_StartReplayRequest))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/replays/{robotName}/step", rpc: "StepReplay",
+			summary: "Release frames of a replay in step mode",
+			request: &pb.ControlMessage_StepReplayRequest{}, response: &pb.ControlMessage_StepReplayResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.StepReplay(ctx, req.(*pb.ControlMessage_StepReplayRequest))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/replays/{robotName}", rpc: "StopReplay",
+			summary: "Stop a replay",
+			request: &pb.ControlMessage_StopReplayRequest{}, response: &pb.ControlMessage_StopReplayResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.StopReplay(ctx, req.(*pb.ControlMessage_StopReplayRequest))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/regressions", rpc: "Regress",
+			summary: "Replay a recording to a client and compare its commands with the recorded ones",
+			request: &pb.ControlMessage_RegressRequest{}, response: &pb.ControlMessage_RegressResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.Regress(ctx, req.(*pb.ControlMessage_RegressRequest))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/pairing-rules", rpc: "GetPairingRules",
+			summary: "List the pairing rules",
+			request: &pb.Null{}, response: &pb.ControlMessage_GetPairingRulesResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetPairingRules(ctx, req.(*pb.Null))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/pairing-rules", rpc: "AddPairingRule",
+			summary: "Add a pairing rule",
+			request: &pb.ControlMessage_AddPairingRuleRequest{}, response: &pb.ControlMessage_AddPairingRuleResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.AddPairingRule(ctx, req.(*pb.ControlMessage_AddPairingRuleRequest))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/pairing-rules/{rule}", rpc: "RemovePairingRule",
+			summary: "Remove a pairing rule, given URL-encoded",
+			request: &pb.ControlMessage_RemovePairingRuleRequest{}, response: &pb.ControlMessage_RemovePairingRuleResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.RemovePairingRule(ctx, req.(*pb.ControlMessage_RemovePairingRuleRequest))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/shutdown", rpc: "Shutdown",
+			summary: "Shut the broker down, stopping every robot and notifying every peer in every arena",
+			request: &pb.ControlMessage_ShutdownRequest{}, response: &pb.ControlMessage_ShutdownResponse{},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.Shutdown(ctx, req.(*pb.ControlMessage_ShutdownRequest))
+			},
+		},
+	}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.EscapedPath(), gatewayPrefix)
+	if path == r.URL.EscapedPath() {
+		http.NotFound(w, r)
+		return
+	}
+	if path == "/openapi.json" {
+		if !allowMethods(w, r, http.MethodGet) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(g.openAPI)
+		return
+	}
+	if !g.authorize(r) {
+		writeJSONError(w, http.StatusUnauthorized, "admin token required")
+		return
+	}
+	var methods []string
+	for _, route := range g.routes {
+		params, ok := route.match(path)
+		if !ok {
+			continue
+		}
+		if r.Method != route.method {
+			methods = append(methods, route.method)
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"rpc":    route.rpc,
+		}).Debug("Gateway call")
+		if arena := r.URL.Query().Get("arena"); arena != "" {
+			r = r.WithContext(metadata.NewIncomingContext(r.Context(), metadata.Pairs(arenaMetadataKey, arena)))
+		}
+		g.serveRoute(w, r, route, params)
+		return
+	}
+	if len(methods) > 0 {
+		allowMethods(w, r, methods...)
+		return
+	}
+	writeJSONError(w, http.StatusNotFound, "Not found")
+}
+
+// authorize checks the request's admin token, if the broker has one
+func (g *Gateway) authorize(r *http.Request) bool {
+	if g.adminToken == "" {
+		return true
+	}
+	value := r.Header.Get("Authorization")
+	token := strings.TrimPrefix(value, "Bearer ")
+	return token != value && tokensEqual(g.adminToken, token)
+}
+
+// match matches an escaped path against the route's path, returning the
+// unescaped path parameters
+func (route gatewayRoute) match(path string) (map[string]string, bool) {
+	patternSegments := strings.Split(route.path, "/")
+	pathSegments := strings.Split(path, "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, pattern := range patternSegments {
+		if strings.HasPrefix(pattern, "{") && strings.HasSuffix(pattern, "}") {
+			value, err := url.PathUnescape(pathSegments[i])
+			if err != nil || value == "" {
+				return nil, false
+			}
+			params[pattern[1:len(pattern)-1]] = value
+		} else if pattern != pathSegments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (g *Gateway) serveRoute(w http.ResponseWriter, r *http.Request, route gatewayRoute, params map[string]string) {
+	req, err := route.readRequest(r, params)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+	if route.stream != nil {
+		stream, ok := newSSEStream(w, r)
+		if !ok {
+			return
+		}
+		stream.end(route.stream(req, stream))
+		return
+	}
+	res, err := route.call(r.Context(), req)
+	if err != nil {
+		st := status.Convert(err)
+		writeJSONError(w, httpStatusCodes[st.Code()], st.Message())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := jsonMarshaler.Marshal(w, res); err != nil {
+		log.WithError(err).Warn("Failed to write gateway response")
+	}
+}
+
+// readRequest decodes the route's request from the request body, if any, and
+// the path parameters
+func (route gatewayRoute) readRequest(r *http.Request, params map[string]string) (proto.Message, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, err
+		}
+	}
+	for name, value := range params {
+		encoded, _ := json.Marshal(value)
+		fields[name] = encoded
+	}
+	merged, _ := json.Marshal(fields)
+	req := proto.Clone(route.request)
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(merged), req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// HTTP status codes of the errors RPCs fail with
+var httpStatusCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// allowMethods writes a 405 response and returns false unless the request
+// uses one of methods
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	return false
+}
+
+// writeJSONError writes an error in the same shape as the error of an RPC
+// response
+func writeJSONError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// sseStream adapts an HTTP response to the server side of a streaming RPC,
+// sending each message as a server-sent event. The Control service only uses
+// the stream's Context and Send, so the rest of grpc.ServerStream is left
+// unimplemented.
+type sseStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newSSEStream(w http.ResponseWriter, r *http.Request) (*sseStream, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "Streaming unsupported")
+		return nil, false
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &sseStream{ctx: r.Context(), w: w, flusher: flusher}, true
+}
+
+func (s *sseStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *sseStream) send(msg proto.Message) error {
+	data, err := jsonMarshaler.MarshalToString(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// end reports the error the stream ended with, unless the request was
+// cancelled
+func (s *sseStream) end(err error) {
+	if err == nil || s.ctx.Err() != nil {
+		return
+	}
+	fmt.Fprintf(s.w, "event: error\ndata: %s\n\n", strings.ReplaceAll(status.Convert(err).Message(), "\n", " "))
+	s.flusher.Flush()
+}
+
+type eventsSSEStream struct{ *sseStream }
+
+func (s eventsSSEStream) Send(event *pb.ControlMessage_BrokerEvent) error {
+	return s.send(event)
+}
+
+type clientControllersSSEStream struct{ *sseStream }
+
+func (s clientControllersSSEStream) Send(msg *pb.ControlMessage_SubscribeClientControllersMessage) error {
+	return s.send(msg)
+}
+
+type simStateSSEStream struct{ *sseStream }
+
+func (s simStateSSEStream) Send(state *pb.SimState) error {
+	return s.send(state)
+}
+
+type watchRobotSSEStream struct{ *sseStream }
+
+func (s watchRobotSSEStream) Send(traffic *pb.ControlMessage_RobotTraffic) error {
+	return s.send(traffic)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+const gatewayToken = "secret"
+
+type GatewaySuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	server         *httptest.Server
+}
+
+func (suite *GatewaySuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+	gateway, err := NewGateway(NewControlServer(suite.broker, nil, nil, nil, nil), gatewayToken)
+	suite.Require().NoError(err)
+	suite.server = httptest.NewServer(gateway)
+}
+
+func (suite *GatewaySuite) TearDownTest() {
+	suite.server.Close()
+	suite.globalCtxClose()
+}
+
+// request makes an API request with the admin token, returning the response
+// body
+func (suite *GatewaySuite) request(method, path, body string, expectedStatus int) string {
+	req, err := http.NewRequest(method, suite.server.URL+path, strings.NewReader(body))
+	suite.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+gatewayToken)
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+	suite.Equal(expectedStatus, res.StatusCode)
+	var out strings.Builder
+	_, err = bufio.NewReader(res.Body).WriteTo(&out)
+	suite.Require().NoError(err)
+	return out.String()
+}
+
+func (suite *GatewaySuite) TestOpenAPI() {
+	// Served without the admin token
+	res, err := http.Get(suite.server.URL + "/api/openapi.json")
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+	suite.Equal(http.StatusOK, res.StatusCode)
+	var doc struct {
+		Paths      map[string]map[string]struct{ OperationID string }
+		Components struct{ Schemas map[string]interface{} }
+	}
+	suite.Require().NoError(json.NewDecoder(res.Body).Decode(&doc))
+	operations := make(map[string]bool)
+	for _, path := range doc.Paths {
+		for _, op := range path {
+			operations[op.OperationID] = true
+		}
+	}
+	server := grpc.NewServer()
+	pb.RegisterControlServer(server, &ControlServer{})
+	for _, method := range server.GetServiceInfo()["erebus.Control"].Methods {
+		suite.True(operations[method.Name], "No route for %s", method.Name)
+	}
+	suite.Contains(doc.Components.Schemas, "erebus.ControlMessage.Connection")
+	suite.Contains(doc.Components.Schemas, "erebus.SimState.State")
+}
+
+func (suite *GatewaySuite) TestAuth() {
+	res, err := http.Get(suite.server.URL + "/api/robots")
+	suite.Require().NoError(err)
+	res.Body.Close()
+	suite.Equal(http.StatusUnauthorized, res.StatusCode)
+	req, _ := http.NewRequest(http.MethodGet, suite.server.URL+"/api/robots", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	res, err = http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	res.Body.Close()
+	suite.Equal(http.StatusUnauthorized, res.StatusCode)
+}
+
+func (suite *GatewaySuite) TestConnections() {
+	robot := suite.broker.RegisterRobot("robot", suite.globalCtx, nil)
+	suite.Require().NotNil(robot)
+	client := suite.broker.RegisterClient("client", suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	go func() { <-robot.GetConnection() }()
+	go func() { <-client.GetConnection() }()
+
+	suite.JSONEq(`{"robotNames": ["robot"]}`, suite.request(http.MethodGet, "/api/robots", "", http.StatusOK))
+	suite.JSONEq(`{"controllerNames": ["client"]}`, suite.request(http.MethodGet, "/api/clients", "", http.StatusOK))
+	suite.JSONEq(`{"ok": {}}`, suite.request(http.MethodPost, "/api/connections", `{"clientName": "client", "robotName": "robot"}`, http.StatusOK))
+	suite.Len(suite.broker.GetConnections(), 1)
+	suite.Contains(suite.request(http.MethodGet, "/api/connections", "", http.StatusOK), `"robotName":"robot"`)
+	suite.Contains(suite.request(http.MethodGet, "/api/robots/robot", "", http.StatusOK), `"robotInfo"`)
+	suite.JSONEq(`{"error": "Client already connected"}`, suite.request(http.MethodPost, "/api/connections", `{"clientName": "client", "robotName": "robot"}`, http.StatusOK))
+	suite.JSONEq(`{"ok": {}}`, suite.request(http.MethodDelete, "/api/connections/client", "", http.StatusOK))
+	suite.Empty(suite.broker.GetConnections())
+
+	suite.request(http.MethodPost, "/api/connections", `{"client": "client"}`, http.StatusBadRequest)
+	suite.request(http.MethodPut, "/api/robots", "", http.StatusMethodNotAllowed)
+	suite.request(http.MethodGet, "/api/nothing", "", http.StatusNotFound)
+}
+
+func (suite *GatewaySuite) TestPathParameters() {
+	pairer := NewPairer(suite.broker, nil)
+	gateway, err := NewGateway(NewControlServer(suite.broker, nil, nil, pairer, nil), gatewayToken)
+	suite.Require().NoError(err)
+	suite.server.Close()
+	suite.server = httptest.NewServer(gateway)
+
+	suite.JSONEq(`{"ok": {}}`, suite.request(http.MethodPost, "/api/pairing-rules", `{"rule": "/team-(.*)/=robot-$1"}`, http.StatusOK))
+	suite.JSONEq(`{"ok": {}}`, suite.request(http.MethodDelete, "/api/pairing-rules/"+url.PathEscape("/team-(.*)/=robot-$1"), "", http.StatusOK))
+	suite.Empty(pairer.Rules())
+}
+
+func (suite *GatewaySuite) TestSimulationState() {
+	suite.JSONEq(`{"state": "RESET"}`, suite.request(http.MethodGet, "/api/simulation-state", "", http.StatusOK))
+	suite.request(http.MethodPut, "/api/simulation-state", `{"state": "START"}`, http.StatusOK)
+	suite.Equal(pb.SimState_START, suite.broker.GetSimState().State)
+}
+
+func (suite *GatewaySuite) TestArena() {
+	other := NewBroker(suite.globalCtx, SimInfo{timestep: 16})
+	arenas, err := NewArenas(
+		NewArena("field1", suite.broker, nil, nil, nil, nil, nil, nil),
+		NewArena("field2", other, nil, nil, nil, nil, nil, nil),
+	)
+	suite.Require().NoError(err)
+	gateway, err := NewGateway(NewArenaControlServer(arenas), gatewayToken)
+	suite.Require().NoError(err)
+	suite.server.Close()
+	suite.server = httptest.NewServer(gateway)
+
+	suite.request(http.MethodPut, "/api/simulation-state?arena=field2", `{"state": "START"}`, http.StatusOK)
+	suite.Equal(pb.SimState_START, other.GetSimState().State)
+	suite.Equal(pb.SimState_RESET, suite.broker.GetSimState().State)
+	suite.Contains(suite.request(http.MethodGet, "/api/arenas", "", http.StatusOK), `"name":"field2"`)
+	suite.request(http.MethodGet, "/api/robots", "", http.StatusBadRequest)
+	suite.request(http.MethodGet, "/api/robots?arena=field3", "", http.StatusBadRequest)
+}
+
+func (suite *GatewaySuite) TestEvents() {
+	req, err := http.NewRequest(http.MethodGet, suite.server.URL+"/api/events", nil)
+	suite.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+gatewayToken)
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+	suite.Equal("text/event-stream", res.Header.Get("Content-Type"))
+	// The response starts before the subscription does
+	time.Sleep(closeTimeout)
+
+	suite.Require().NotNil(suite.broker.RegisterRobot("robot", suite.globalCtx, nil))
+	line, err := bufio.NewReader(res.Body).ReadString('\n')
+	suite.Require().NoError(err)
+	suite.True(strings.HasPrefix(line, "data: "))
+	suite.Contains(line, `"eventType":"ROBOT_REGISTERED"`)
+	suite.Contains(line, `"robotName":"robot"`)
+}
+
+func TestGatewaySuite(t *testing.T) {
+	suite.Run(t, new(GatewaySuite))
+}
@@ -24,9 +24,50 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type CommandError_Reason int32
+
+const (
+	CommandError_UNKNOWN               CommandError_Reason = 0
+	CommandError_UNKNOWN_DEVICE        CommandError_Reason = 1
+	CommandError_WRONG_DEVICE_TYPE     CommandError_Reason = 2
+	CommandError_VELOCITY_OUT_OF_RANGE CommandError_Reason = 3
+	CommandError_EMPTY_COMMAND         CommandError_Reason = 4
+)
+
+var CommandError_Reason_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "UNKNOWN_DEVICE",
+	2: "WRONG_DEVICE_TYPE",
+	3: "VELOCITY_OUT_OF_RANGE",
+	4: "EMPTY_COMMAND",
+}
+
+var CommandError_Reason_value = map[string]int32{
+	"UNKNOWN":               0,
+	"UNKNOWN_DEVICE":        1,
+	"WRONG_DEVICE_TYPE":     2,
+	"VELOCITY_OUT_OF_RANGE": 3,
+	"EMPTY_COMMAND":         4,
+}
+
+func (x CommandError_Reason) String() string {
+	return proto.EnumName(CommandError_Reason_name, int32(x))
+}
+
+func (CommandError_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c26ef4dddf97e5c1, []int{4, 0}
+}
+
 type ClientControllerHandshake struct {
-	ClientName           string   `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
-	RequestSync          bool     `protobuf:"varint,2,opt,name=request_sync,json=requestSync,proto3" json:"request_sync,omitempty"`
+	ClientName  string `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	RequestSync bool   `protobuf:"varint,2,opt,name=request_sync,json=requestSync,proto3" json:"request_sync,omitempty"`
+	Token       string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// Token from a previous session's handshake response, to resume that
+	// session (and the connection it was bound in) after a disconnect
+	ResumeToken string `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// Arena the client plays in; may be left empty if the broker runs a single
+	// arena
+	Arena                string   `protobuf:"bytes,5,opt,name=arena,proto3" json:"arena,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -71,6 +112,27 @@ func (m *ClientControllerHandshake) GetRequestSync() bool {
 	return false
 }
 
+func (m *ClientControllerHandshake) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ClientControllerHandshake) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *ClientControllerHandshake) GetArena() string {
+	if m != nil {
+		return m.Arena
+	}
+	return ""
+}
+
 type ClientControllerHandshakeResponse struct {
 	// Types that are valid to be assigned to Data:
 	//	*ClientControllerHandshakeResponse_Error
@@ -152,7 +214,11 @@ func (*ClientControllerHandshakeResponse) XXX_OneofWrappers() []interface{} {
 }
 
 type ClientControllerHandshakeResponse_Ok struct {
-	Timestep             int32    `protobuf:"varint,1,opt,name=timestep,proto3" json:"timestep,omitempty"`
+	Timestep int32 `protobuf:"varint,1,opt,name=timestep,proto3" json:"timestep,omitempty"`
+	// Token to resume this session with if it disconnects, or empty if
+	// the broker doesn't allow resuming sessions
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Resumed              bool     `protobuf:"varint,3,opt,name=resumed,proto3" json:"resumed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -190,6 +256,20 @@ func (m *ClientControllerHandshakeResponse_Ok) GetTimestep() int32 {
 	return 0
 }
 
+func (m *ClientControllerHandshakeResponse_Ok) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *ClientControllerHandshakeResponse_Ok) GetResumed() bool {
+	if m != nil {
+		return m.Resumed
+	}
+	return false
+}
+
 type ClientControllerBound struct {
 	IsSync               bool       `protobuf:"varint,1,opt,name=is_sync,json=isSync,proto3" json:"is_sync,omitempty"`
 	RobotInfo            *RobotInfo `protobuf:"bytes,2,opt,name=robot_info,json=robotInfo,proto3" json:"robot_info,omitempty"`
@@ -268,6 +348,214 @@ func (m *ClientControllerUnbound) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ClientControllerUnbound proto.InternalMessageInfo
 
+// A command which was not forwarded to the robot
+type CommandError struct {
+	Device               string              `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Reason               CommandError_Reason `protobuf:"varint,2,opt,name=reason,proto3,enum=erebus.CommandError_Reason" json:"reason,omitempty"`
+	Message              string              `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *CommandError) Reset()         { *m = CommandError{} }
+func (m *CommandError) String() string { return proto.CompactTextString(m) }
+func (*CommandError) ProtoMessage()    {}
+func (*CommandError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c26ef4dddf97e5c1, []int{4}
+}
+
+func (m *CommandError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandError.Unmarshal(m, b)
+}
+func (m *CommandError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandError.Marshal(b, m, deterministic)
+}
+func (m *CommandError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandError.Merge(m, src)
+}
+func (m *CommandError) XXX_Size() int {
+	return xxx_messageInfo_CommandError.Size(m)
+}
+func (m *CommandError) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandError proto.InternalMessageInfo
+
+func (m *CommandError) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *CommandError) GetReason() CommandError_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return CommandError_UNKNOWN
+}
+
+func (m *CommandError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Sent in place of silently dropping invalid commands; the valid commands of
+// the same message are still forwarded
+type CommandErrors struct {
+	Errors               []*CommandError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CommandErrors) Reset()         { *m = CommandErrors{} }
+func (m *CommandErrors) String() string { return proto.CompactTextString(m) }
+func (*CommandErrors) ProtoMessage()    {}
+func (*CommandErrors) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c26ef4dddf97e5c1, []int{5}
+}
+
+func (m *CommandErrors) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandErrors.Unmarshal(m, b)
+}
+func (m *CommandErrors) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandErrors.Marshal(b, m, deterministic)
+}
+func (m *CommandErrors) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandErrors.Merge(m, src)
+}
+func (m *CommandErrors) XXX_Size() int {
+	return xxx_messageInfo_CommandErrors.Size(m)
+}
+func (m *CommandErrors) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandErrors.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandErrors proto.InternalMessageInfo
+
+func (m *CommandErrors) GetErrors() []*CommandError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type SensorSamplingPeriodsResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*SensorSamplingPeriodsResponse_Error
+	//	*SensorSamplingPeriodsResponse_Ok_
+	Data                 isSensorSamplingPeriodsResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *SensorSamplingPeriodsResponse) Reset()         { *m = SensorSamplingPeriodsResponse{} }
+func (m *SensorSamplingPeriodsResponse) String() string { return proto.CompactTextString(m) }
+func (*SensorSamplingPeriodsResponse) ProtoMessage()    {}
+func (*SensorSamplingPeriodsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c26ef4dddf97e5c1, []int{6}
+}
+
+func (m *SensorSamplingPeriodsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensorSamplingPeriodsResponse.Unmarshal(m, b)
+}
+func (m *SensorSamplingPeriodsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensorSamplingPeriodsResponse.Marshal(b, m, deterministic)
+}
+func (m *SensorSamplingPeriodsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensorSamplingPeriodsResponse.Merge(m, src)
+}
+func (m *SensorSamplingPeriodsResponse) XXX_Size() int {
+	return xxx_messageInfo_SensorSamplingPeriodsResponse.Size(m)
+}
+func (m *SensorSamplingPeriodsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensorSamplingPeriodsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensorSamplingPeriodsResponse proto.InternalMessageInfo
+
+type isSensorSamplingPeriodsResponse_Data interface {
+	isSensorSamplingPeriodsResponse_Data()
+}
+
+type SensorSamplingPeriodsResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type SensorSamplingPeriodsResponse_Ok_ struct {
+	Ok *SensorSamplingPeriodsResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*SensorSamplingPeriodsResponse_Error) isSensorSamplingPeriodsResponse_Data() {}
+
+func (*SensorSamplingPeriodsResponse_Ok_) isSensorSamplingPeriodsResponse_Data() {}
+
+func (m *SensorSamplingPeriodsResponse) GetData() isSensorSamplingPeriodsResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SensorSamplingPeriodsResponse) GetError() string {
+	if x, ok := m.GetData().(*SensorSamplingPeriodsResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *SensorSamplingPeriodsResponse) GetOk() *SensorSamplingPeriodsResponse_Ok {
+	if x, ok := m.GetData().(*SensorSamplingPeriodsResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SensorSamplingPeriodsResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SensorSamplingPeriodsResponse_Error)(nil),
+		(*SensorSamplingPeriodsResponse_Ok_)(nil),
+	}
+}
+
+type SensorSamplingPeriodsResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SensorSamplingPeriodsResponse_Ok) Reset()         { *m = SensorSamplingPeriodsResponse_Ok{} }
+func (m *SensorSamplingPeriodsResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*SensorSamplingPeriodsResponse_Ok) ProtoMessage()    {}
+func (*SensorSamplingPeriodsResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c26ef4dddf97e5c1, []int{6, 0}
+}
+
+func (m *SensorSamplingPeriodsResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensorSamplingPeriodsResponse_Ok.Unmarshal(m, b)
+}
+func (m *SensorSamplingPeriodsResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensorSamplingPeriodsResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *SensorSamplingPeriodsResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensorSamplingPeriodsResponse_Ok.Merge(m, src)
+}
+func (m *SensorSamplingPeriodsResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_SensorSamplingPeriodsResponse_Ok.Size(m)
+}
+func (m *SensorSamplingPeriodsResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensorSamplingPeriodsResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensorSamplingPeriodsResponse_Ok proto.InternalMessageInfo
+
 type ClientControllerMessage struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -278,7 +566,7 @@ func (m *ClientControllerMessage) Reset()         { *m = ClientControllerMessage
 func (m *ClientControllerMessage) String() string { return proto.CompactTextString(m) }
 func (*ClientControllerMessage) ProtoMessage()    {}
 func (*ClientControllerMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c26ef4dddf97e5c1, []int{4}
+	return fileDescriptor_c26ef4dddf97e5c1, []int{7}
 }
 
 func (m *ClientControllerMessage) XXX_Unmarshal(b []byte) error {
@@ -304,6 +592,7 @@ type ClientControllerMessage_ControllerMessage struct {
 	//	*ClientControllerMessage_ControllerMessage_ClientControllerHandshake
 	//	*ClientControllerMessage_ControllerMessage_Pong
 	//	*ClientControllerMessage_ControllerMessage_Commands
+	//	*ClientControllerMessage_ControllerMessage_SensorSamplingPeriods
 	Message              isClientControllerMessage_ControllerMessage_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                                            `json:"-"`
 	XXX_unrecognized     []byte                                              `json:"-"`
@@ -313,10 +602,12 @@ type ClientControllerMessage_ControllerMessage struct {
 func (m *ClientControllerMessage_ControllerMessage) Reset() {
 	*m = ClientControllerMessage_ControllerMessage{}
 }
-func (m *ClientControllerMessage_ControllerMessage) String() string { return proto.CompactTextString(m) }
-func (*ClientControllerMessage_ControllerMessage) ProtoMessage()    {}
+func (m *ClientControllerMessage_ControllerMessage) String() string {
+	return proto.CompactTextString(m)
+}
+func (*ClientControllerMessage_ControllerMessage) ProtoMessage() {}
 func (*ClientControllerMessage_ControllerMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c26ef4dddf97e5c1, []int{4, 0}
+	return fileDescriptor_c26ef4dddf97e5c1, []int{7, 0}
 }
 
 func (m *ClientControllerMessage_ControllerMessage) XXX_Unmarshal(b []byte) error {
@@ -353,6 +644,10 @@ type ClientControllerMessage_ControllerMessage_Commands struct {
 	Commands *Commands `protobuf:"bytes,3,opt,name=commands,proto3,oneof"`
 }
 
+type ClientControllerMessage_ControllerMessage_SensorSamplingPeriods struct {
+	SensorSamplingPeriods *SensorSamplingPeriods `protobuf:"bytes,4,opt,name=sensor_sampling_periods,json=sensorSamplingPeriods,proto3,oneof"`
+}
+
 func (*ClientControllerMessage_ControllerMessage_ClientControllerHandshake) isClientControllerMessage_ControllerMessage_Message() {
 }
 
@@ -362,6 +657,9 @@ func (*ClientControllerMessage_ControllerMessage_Pong) isClientControllerMessage
 func (*ClientControllerMessage_ControllerMessage_Commands) isClientControllerMessage_ControllerMessage_Message() {
 }
 
+func (*ClientControllerMessage_ControllerMessage_SensorSamplingPeriods) isClientControllerMessage_ControllerMessage_Message() {
+}
+
 func (m *ClientControllerMessage_ControllerMessage) GetMessage() isClientControllerMessage_ControllerMessage_Message {
 	if m != nil {
 		return m.Message
@@ -390,12 +688,20 @@ func (m *ClientControllerMessage_ControllerMessage) GetCommands() *Commands {
 	return nil
 }
 
+func (m *ClientControllerMessage_ControllerMessage) GetSensorSamplingPeriods() *SensorSamplingPeriods {
+	if x, ok := m.GetMessage().(*ClientControllerMessage_ControllerMessage_SensorSamplingPeriods); ok {
+		return x.SensorSamplingPeriods
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ClientControllerMessage_ControllerMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*ClientControllerMessage_ControllerMessage_ClientControllerHandshake)(nil),
 		(*ClientControllerMessage_ControllerMessage_Pong)(nil),
 		(*ClientControllerMessage_ControllerMessage_Commands)(nil),
+		(*ClientControllerMessage_ControllerMessage_SensorSamplingPeriods)(nil),
 	}
 }
 
@@ -407,6 +713,9 @@ type ClientControllerMessage_ServerMessage struct {
 	//	*ClientControllerMessage_ServerMessage_SensorData
 	//	*ClientControllerMessage_ServerMessage_ClientControllerBound
 	//	*ClientControllerMessage_ServerMessage_ClientControllerUnbound
+	//	*ClientControllerMessage_ServerMessage_SensorSamplingPeriodsResponse
+	//	*ClientControllerMessage_ServerMessage_CommandErrors
+	//	*ClientControllerMessage_ServerMessage_ServerShuttingDown
 	Message              isClientControllerMessage_ServerMessage_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
 	XXX_unrecognized     []byte                                          `json:"-"`
@@ -417,7 +726,7 @@ func (m *ClientControllerMessage_ServerMessage) Reset()         { *m = ClientCon
 func (m *ClientControllerMessage_ServerMessage) String() string { return proto.CompactTextString(m) }
 func (*ClientControllerMessage_ServerMessage) ProtoMessage()    {}
 func (*ClientControllerMessage_ServerMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c26ef4dddf97e5c1, []int{4, 1}
+	return fileDescriptor_c26ef4dddf97e5c1, []int{7, 1}
 }
 
 func (m *ClientControllerMessage_ServerMessage) XXX_Unmarshal(b []byte) error {
@@ -466,10 +775,23 @@ type ClientControllerMessage_ServerMessage_ClientControllerUnbound struct {
 	ClientControllerUnbound *ClientControllerUnbound `protobuf:"bytes,6,opt,name=client_controller_unbound,json=clientControllerUnbound,proto3,oneof"`
 }
 
+type ClientControllerMessage_ServerMessage_SensorSamplingPeriodsResponse struct {
+	SensorSamplingPeriodsResponse *SensorSamplingPeriodsResponse `protobuf:"bytes,7,opt,name=sensor_sampling_periods_response,json=sensorSamplingPeriodsResponse,proto3,oneof"`
+}
+
+type ClientControllerMessage_ServerMessage_CommandErrors struct {
+	CommandErrors *CommandErrors `protobuf:"bytes,8,opt,name=command_errors,json=commandErrors,proto3,oneof"`
+}
+
+type ClientControllerMessage_ServerMessage_ServerShuttingDown struct {
+	ServerShuttingDown *ServerShuttingDown `protobuf:"bytes,9,opt,name=server_shutting_down,json=serverShuttingDown,proto3,oneof"`
+}
+
 func (*ClientControllerMessage_ServerMessage_ClientControllerHandshakeResponse) isClientControllerMessage_ServerMessage_Message() {
 }
 
-func (*ClientControllerMessage_ServerMessage_Ping) isClientControllerMessage_ServerMessage_Message() {}
+func (*ClientControllerMessage_ServerMessage_Ping) isClientControllerMessage_ServerMessage_Message() {
+}
 
 func (*ClientControllerMessage_ServerMessage_SimStateChange) isClientControllerMessage_ServerMessage_Message() {
 }
@@ -483,6 +805,15 @@ func (*ClientControllerMessage_ServerMessage_ClientControllerBound) isClientCont
 func (*ClientControllerMessage_ServerMessage_ClientControllerUnbound) isClientControllerMessage_ServerMessage_Message() {
 }
 
+func (*ClientControllerMessage_ServerMessage_SensorSamplingPeriodsResponse) isClientControllerMessage_ServerMessage_Message() {
+}
+
+func (*ClientControllerMessage_ServerMessage_CommandErrors) isClientControllerMessage_ServerMessage_Message() {
+}
+
+func (*ClientControllerMessage_ServerMessage_ServerShuttingDown) isClientControllerMessage_ServerMessage_Message() {
+}
+
 func (m *ClientControllerMessage_ServerMessage) GetMessage() isClientControllerMessage_ServerMessage_Message {
 	if m != nil {
 		return m.Message
@@ -532,6 +863,27 @@ func (m *ClientControllerMessage_ServerMessage) GetClientControllerUnbound() *Cl
 	return nil
 }
 
+func (m *ClientControllerMessage_ServerMessage) GetSensorSamplingPeriodsResponse() *SensorSamplingPeriodsResponse {
+	if x, ok := m.GetMessage().(*ClientControllerMessage_ServerMessage_SensorSamplingPeriodsResponse); ok {
+		return x.SensorSamplingPeriodsResponse
+	}
+	return nil
+}
+
+func (m *ClientControllerMessage_ServerMessage) GetCommandErrors() *CommandErrors {
+	if x, ok := m.GetMessage().(*ClientControllerMessage_ServerMessage_CommandErrors); ok {
+		return x.CommandErrors
+	}
+	return nil
+}
+
+func (m *ClientControllerMessage_ServerMessage) GetServerShuttingDown() *ServerShuttingDown {
+	if x, ok := m.GetMessage().(*ClientControllerMessage_ServerMessage_ServerShuttingDown); ok {
+		return x.ServerShuttingDown
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ClientControllerMessage_ServerMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -541,15 +893,23 @@ func (*ClientControllerMessage_ServerMessage) XXX_OneofWrappers() []interface{}
 		(*ClientControllerMessage_ServerMessage_SensorData)(nil),
 		(*ClientControllerMessage_ServerMessage_ClientControllerBound)(nil),
 		(*ClientControllerMessage_ServerMessage_ClientControllerUnbound)(nil),
+		(*ClientControllerMessage_ServerMessage_SensorSamplingPeriodsResponse)(nil),
+		(*ClientControllerMessage_ServerMessage_CommandErrors)(nil),
+		(*ClientControllerMessage_ServerMessage_ServerShuttingDown)(nil),
 	}
 }
 
 func init() {
+	proto.RegisterEnum("erebus.CommandError_Reason", CommandError_Reason_name, CommandError_Reason_value)
 	proto.RegisterType((*ClientControllerHandshake)(nil), "erebus.ClientControllerHandshake")
 	proto.RegisterType((*ClientControllerHandshakeResponse)(nil), "erebus.ClientControllerHandshakeResponse")
 	proto.RegisterType((*ClientControllerHandshakeResponse_Ok)(nil), "erebus.ClientControllerHandshakeResponse.Ok")
 	proto.RegisterType((*ClientControllerBound)(nil), "erebus.ClientControllerBound")
 	proto.RegisterType((*ClientControllerUnbound)(nil), "erebus.ClientControllerUnbound")
+	proto.RegisterType((*CommandError)(nil), "erebus.CommandError")
+	proto.RegisterType((*CommandErrors)(nil), "erebus.CommandErrors")
+	proto.RegisterType((*SensorSamplingPeriodsResponse)(nil), "erebus.SensorSamplingPeriodsResponse")
+	proto.RegisterType((*SensorSamplingPeriodsResponse_Ok)(nil), "erebus.SensorSamplingPeriodsResponse.Ok")
 	proto.RegisterType((*ClientControllerMessage)(nil), "erebus.ClientControllerMessage")
 	proto.RegisterType((*ClientControllerMessage_ControllerMessage)(nil), "erebus.ClientControllerMessage.ControllerMessage")
 	proto.RegisterType((*ClientControllerMessage_ServerMessage)(nil), "erebus.ClientControllerMessage.ServerMessage")
@@ -558,43 +918,65 @@ func init() {
 func init() { proto.RegisterFile("client_controller.proto", fileDescriptor_c26ef4dddf97e5c1) }
 
 var fileDescriptor_c26ef4dddf97e5c1 = []byte{
-	// 566 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x8f, 0x12, 0x31,
-	0x18, 0x9e, 0x61, 0xf9, 0x7c, 0xd9, 0x35, 0x6c, 0xcd, 0xca, 0x80, 0x31, 0x0b, 0x13, 0x0f, 0x98,
-	0xe8, 0x64, 0xc5, 0xc4, 0x93, 0xf1, 0x00, 0x1e, 0xc6, 0x83, 0xae, 0x29, 0x31, 0x9e, 0xcc, 0xa4,
-	0x94, 0x2e, 0x34, 0x30, 0x2d, 0xb6, 0xc5, 0x64, 0x13, 0x6f, 0xfe, 0x16, 0x7f, 0x93, 0x57, 0xcf,
-	0xfe, 0x0a, 0x33, 0x9d, 0x82, 0xca, 0x87, 0x78, 0xeb, 0xfb, 0xf5, 0x3c, 0x7d, 0xda, 0xa7, 0x85,
-	0x26, 0x5d, 0x70, 0x26, 0x4c, 0x42, 0xa5, 0x30, 0x4a, 0x2e, 0x16, 0x4c, 0x45, 0x4b, 0x25, 0x8d,
-	0x44, 0x65, 0xa6, 0xd8, 0x78, 0xa5, 0xdb, 0x35, 0xcd, 0xd3, 0x3c, 0xd5, 0x3e, 0xd3, 0x4c, 0x6b,
-	0x2e, 0x45, 0x1e, 0x86, 0x09, 0xb4, 0x86, 0x76, 0x78, 0xb8, 0x99, 0x8d, 0x89, 0x98, 0xe8, 0x19,
-	0x99, 0x33, 0x74, 0x09, 0x75, 0x87, 0x2c, 0x48, 0xca, 0x02, 0xbf, 0xe3, 0xf7, 0x6a, 0x18, 0xf2,
-	0xd4, 0x5b, 0x92, 0x32, 0xd4, 0x85, 0x53, 0xc5, 0x3e, 0xad, 0x98, 0x36, 0x89, 0xbe, 0x15, 0x34,
-	0x28, 0x74, 0xfc, 0x5e, 0x15, 0xd7, 0x5d, 0x6e, 0x74, 0x2b, 0x68, 0xf8, 0xcd, 0x87, 0xee, 0x41,
-	0x06, 0xcc, 0xf4, 0x52, 0x0a, 0xcd, 0xd0, 0x3d, 0x28, 0x31, 0xa5, 0xa4, 0xca, 0x39, 0x62, 0x0f,
-	0xe7, 0x21, 0x7a, 0x09, 0x05, 0x39, 0xb7, 0xb0, 0xf5, 0xfe, 0xe3, 0x28, 0x57, 0x13, 0x1d, 0x85,
-	0x8b, 0xae, 0xe7, 0xb1, 0x87, 0x0b, 0x72, 0xde, 0xee, 0x40, 0xe1, 0x7a, 0x8e, 0xda, 0x50, 0x35,
-	0x3c, 0x65, 0xda, 0xb0, 0xa5, 0x25, 0x28, 0xe1, 0x4d, 0x3c, 0x28, 0x43, 0x71, 0x42, 0x0c, 0x09,
-	0xc7, 0x70, 0xb1, 0x8d, 0x3b, 0x90, 0x2b, 0x31, 0x41, 0x4d, 0xa8, 0x70, 0x9d, 0xcb, 0xf3, 0xad,
-	0xbc, 0x32, 0xd7, 0x99, 0x32, 0x74, 0x05, 0xa0, 0xe4, 0x58, 0x9a, 0x84, 0x8b, 0x1b, 0xe9, 0xf6,
-	0x78, 0xbe, 0xde, 0x23, 0xce, 0x2a, 0xaf, 0xc5, 0x8d, 0xc4, 0x35, 0xb5, 0x5e, 0x86, 0x2d, 0x68,
-	0x6e, 0x73, 0xbc, 0x17, 0xe3, 0x8c, 0x25, 0xfc, 0x51, 0xda, 0xad, 0xbd, 0x61, 0x5a, 0x93, 0x29,
-	0x6b, 0x7f, 0xf7, 0xe1, 0x7c, 0x27, 0x8b, 0x28, 0xdc, 0xdf, 0xb9, 0xf6, 0x64, 0xb6, 0x3e, 0x0a,
-	0xbb, 0xd7, 0x7a, 0xbf, 0x7b, 0xf4, 0xcc, 0x62, 0x0f, 0xb7, 0xe8, 0x41, 0x07, 0x84, 0x50, 0x5c,
-	0x4a, 0x31, 0x75, 0xea, 0x4e, 0xd7, 0x68, 0xef, 0xa4, 0x98, 0xc6, 0x1e, 0xb6, 0x35, 0x14, 0x41,
-	0x95, 0xca, 0x34, 0xcd, 0x66, 0x82, 0x13, 0xdb, 0xd7, 0xd8, 0xb0, 0xba, 0x7c, 0xec, 0xe1, 0x4d,
-	0xcf, 0xa0, 0x06, 0x95, 0xd4, 0x29, 0xfb, 0x79, 0x02, 0x67, 0x23, 0xa6, 0x3e, 0xff, 0x56, 0xf5,
-	0x05, 0x1e, 0xfe, 0x43, 0x55, 0xa2, 0xdc, 0x0d, 0x3b, 0x79, 0x8f, 0xfe, 0xdb, 0x12, 0xb1, 0x87,
-	0xbb, 0xf4, 0xa8, 0x0d, 0x33, 0xb9, 0x7c, 0x8f, 0x5c, 0xee, 0xe4, 0x72, 0x31, 0x45, 0x2f, 0xa0,
-	0xa1, 0x79, 0x9a, 0x68, 0x43, 0x0c, 0x4b, 0xe8, 0x8c, 0x88, 0x29, 0xdb, 0x96, 0x3d, 0xe2, 0xe9,
-	0x28, 0x2b, 0xc7, 0x1e, 0xbe, 0xa3, 0xdd, 0x7a, 0x68, 0x3b, 0xd1, 0x73, 0xa8, 0x6b, 0x26, 0xb4,
-	0x54, 0x49, 0xe6, 0xba, 0xa0, 0x68, 0x07, 0xef, 0x6e, 0x06, 0x6d, 0x49, 0xbf, 0x22, 0x86, 0xc4,
-	0x1e, 0x86, 0xbc, 0x33, 0x8b, 0xd0, 0x87, 0x3d, 0x8f, 0x3c, 0xb1, 0xd6, 0x09, 0x4a, 0x16, 0xe3,
-	0xc1, 0xa1, 0xa3, 0xb0, 0x2e, 0x8e, 0x3d, 0x7c, 0x41, 0xf7, 0xda, 0xfb, 0x23, 0xb4, 0x76, 0x81,
-	0x57, 0xb9, 0x2b, 0x83, 0xb2, 0x85, 0xbe, 0x3c, 0x04, 0xed, 0xcc, 0x1b, 0x7b, 0xb8, 0x49, 0xf7,
-	0x97, 0xfe, 0xb8, 0xec, 0xfe, 0x57, 0x1f, 0x1a, 0xdb, 0x08, 0x48, 0x42, 0x65, 0x94, 0x7f, 0x48,
-	0xe8, 0xe9, 0x21, 0x1a, 0xe7, 0x8d, 0x68, 0xf7, 0x65, 0x3c, 0x39, 0x36, 0xf2, 0x97, 0xb9, 0x7a,
-	0xfe, 0x95, 0x3f, 0x2e, 0xdb, 0x7f, 0xef, 0xd9, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x62, 0x3e,
-	0xee, 0xfb, 0x34, 0x05, 0x00, 0x00,
+	// 925 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdd, 0x6e, 0x1a, 0x47,
+	0x14, 0xde, 0xc5, 0xe6, 0xef, 0xac, 0xb1, 0xf0, 0xd4, 0x84, 0x85, 0xc8, 0x8a, 0xbd, 0x6a, 0x25,
+	0x2a, 0xa5, 0x28, 0x25, 0x52, 0x2f, 0xaa, 0x36, 0x52, 0x8c, 0x69, 0xd6, 0x6a, 0x0d, 0xd6, 0x80,
+	0x63, 0xf9, 0x22, 0x1a, 0x2d, 0xcb, 0x04, 0xaf, 0xf0, 0xce, 0xd0, 0x9d, 0x25, 0x51, 0xa4, 0xde,
+	0x54, 0x7d, 0x9f, 0xaa, 0xf7, 0x7d, 0x9b, 0x4a, 0xbd, 0xeb, 0x43, 0x54, 0x3b, 0x33, 0x60, 0xcc,
+	0x82, 0xc9, 0xdd, 0x9e, 0xdf, 0x39, 0xe7, 0x9b, 0x6f, 0x3e, 0x2d, 0x54, 0xfd, 0xbb, 0x80, 0xb2,
+	0x98, 0xf8, 0x9c, 0xc5, 0x11, 0xbf, 0xbb, 0xa3, 0x51, 0x73, 0x1a, 0xf1, 0x98, 0xa3, 0x1c, 0x8d,
+	0xe8, 0x70, 0x26, 0xea, 0x45, 0x11, 0x84, 0xca, 0x55, 0x2f, 0x09, 0x2a, 0x44, 0xc0, 0x99, 0x32,
+	0x9d, 0x3f, 0x4d, 0xa8, 0xb5, 0x65, 0x75, 0x7b, 0x51, 0xec, 0x7a, 0x6c, 0x24, 0x6e, 0xbd, 0x09,
+	0x45, 0xcf, 0xc0, 0xd2, 0xad, 0x99, 0x17, 0x52, 0xdb, 0x3c, 0x36, 0x1b, 0x45, 0x0c, 0xca, 0xd5,
+	0xf5, 0x42, 0x8a, 0x4e, 0x60, 0x2f, 0xa2, 0xbf, 0xce, 0xa8, 0x88, 0x89, 0xf8, 0xc4, 0x7c, 0x3b,
+	0x73, 0x6c, 0x36, 0x0a, 0xd8, 0xd2, 0xbe, 0xfe, 0x27, 0xe6, 0xa3, 0x43, 0xc8, 0xc6, 0x7c, 0x42,
+	0x99, 0xbd, 0x23, 0xab, 0x95, 0xa1, 0x0a, 0xc5, 0x2c, 0xa4, 0x44, 0x05, 0x77, 0x65, 0xd0, 0x52,
+	0xbe, 0x81, 0x4c, 0x39, 0x84, 0xac, 0x17, 0x51, 0xe6, 0xd9, 0x59, 0x55, 0x28, 0x0d, 0xe7, 0x1f,
+	0x13, 0x4e, 0x36, 0x0e, 0x8c, 0xa9, 0x98, 0x72, 0x26, 0x28, 0x7a, 0x02, 0x59, 0x1a, 0x45, 0x3c,
+	0x52, 0x23, 0xbb, 0x06, 0x56, 0x26, 0x7a, 0x05, 0x19, 0x3e, 0x91, 0x53, 0x5a, 0xad, 0xe7, 0x4d,
+	0x85, 0x4e, 0x73, 0x6b, 0xbb, 0x66, 0x6f, 0xe2, 0x1a, 0x38, 0xc3, 0x27, 0xf5, 0x77, 0x90, 0xe9,
+	0x4d, 0x50, 0x1d, 0x0a, 0x71, 0x10, 0x52, 0x11, 0xd3, 0xa9, 0x3c, 0x20, 0x8b, 0x17, 0x76, 0x6a,
+	0xb1, 0x4c, 0x7a, 0x31, 0x1b, 0xf2, 0xca, 0x1c, 0x49, 0x4c, 0x0a, 0x78, 0x6e, 0x9e, 0xe6, 0x60,
+	0x77, 0xe4, 0xc5, 0x9e, 0x33, 0x84, 0xca, 0xea, 0x50, 0xa7, 0x7c, 0xc6, 0x46, 0xa8, 0x0a, 0xf9,
+	0x40, 0x28, 0xa8, 0x4d, 0x59, 0x9a, 0x0b, 0x84, 0x44, 0xf9, 0x05, 0x40, 0xc4, 0x87, 0x3c, 0x26,
+	0x01, 0x7b, 0xcf, 0xf5, 0x82, 0x07, 0xf3, 0x05, 0x71, 0x12, 0x39, 0x67, 0xef, 0x39, 0x2e, 0x46,
+	0xf3, 0x4f, 0xa7, 0x06, 0xd5, 0xd5, 0x33, 0xae, 0xd8, 0x30, 0x39, 0xc5, 0xf9, 0xd7, 0x84, 0xbd,
+	0x36, 0x0f, 0x43, 0x8f, 0x8d, 0x3a, 0x12, 0xb6, 0x27, 0x90, 0x1b, 0xd1, 0x0f, 0x81, 0x3f, 0xa7,
+	0x80, 0xb6, 0xd0, 0x4b, 0xc8, 0x45, 0xd4, 0x13, 0x5c, 0xad, 0xb9, 0xdf, 0x7a, 0xba, 0x80, 0x74,
+	0xa9, 0xba, 0x89, 0x65, 0x0a, 0xd6, 0xa9, 0xc9, 0xfa, 0x21, 0x15, 0xc2, 0x1b, 0x53, 0x4d, 0x89,
+	0xb9, 0xe9, 0x30, 0xc8, 0xa9, 0x5c, 0x64, 0x41, 0xfe, 0xaa, 0xfb, 0x73, 0xb7, 0x77, 0xdd, 0x2d,
+	0x1b, 0x08, 0xc1, 0xbe, 0x36, 0xc8, 0x59, 0xe7, 0xed, 0x79, 0xbb, 0x53, 0x36, 0x51, 0x05, 0x0e,
+	0xae, 0x71, 0xaf, 0xfb, 0x46, 0x7b, 0xc8, 0xe0, 0xe6, 0xb2, 0x53, 0xce, 0xa0, 0x1a, 0x54, 0xde,
+	0x76, 0x7e, 0xe9, 0xb5, 0xcf, 0x07, 0x37, 0xa4, 0x77, 0x35, 0x20, 0xbd, 0x9f, 0x08, 0x7e, 0xdd,
+	0x7d, 0xd3, 0x29, 0xef, 0xa0, 0x03, 0x28, 0x75, 0x2e, 0x2e, 0x07, 0x37, 0xa4, 0xdd, 0xbb, 0xb8,
+	0x78, 0xdd, 0x3d, 0x2b, 0xef, 0x3a, 0x3f, 0x42, 0x69, 0x79, 0x50, 0x81, 0x9e, 0x43, 0x4e, 0xf2,
+	0x44, 0xd8, 0xe6, 0xf1, 0x4e, 0xc3, 0x6a, 0x1d, 0xae, 0xdb, 0x07, 0xeb, 0x1c, 0xe7, 0x77, 0x13,
+	0x8e, 0xfa, 0x94, 0x09, 0x1e, 0xf5, 0xbd, 0x70, 0x7a, 0x17, 0xb0, 0xf1, 0x25, 0x8d, 0x02, 0x3e,
+	0x12, 0x5b, 0x69, 0xf8, 0xfd, 0x12, 0x0d, 0x1b, 0xf3, 0x33, 0x1e, 0x6d, 0x75, 0x4f, 0xc1, 0xdd,
+	0x84, 0x82, 0x0b, 0xa6, 0xfc, 0x5d, 0x48, 0x5f, 0xe3, 0x85, 0x82, 0xb3, 0xfe, 0x57, 0x06, 0x0e,
+	0x52, 0x5e, 0xe4, 0xc3, 0xd3, 0x94, 0x5c, 0x90, 0xdb, 0x39, 0xe5, 0xe5, 0xa4, 0x56, 0xeb, 0x64,
+	0xeb, 0xdb, 0x70, 0x0d, 0x5c, 0xf3, 0x37, 0x05, 0x91, 0x03, 0xbb, 0x53, 0xce, 0xc6, 0x7a, 0xc5,
+	0xbd, 0x79, 0xb7, 0x4b, 0xce, 0xc6, 0xae, 0x81, 0x65, 0x0c, 0x35, 0xa1, 0xe0, 0x2b, 0x58, 0x85,
+	0x24, 0x82, 0xd5, 0x2a, 0xaf, 0xc0, 0x2d, 0x5c, 0x03, 0x2f, 0x72, 0xd0, 0x35, 0x54, 0x85, 0x84,
+	0x88, 0x08, 0x8d, 0x11, 0x99, 0x2a, 0x90, 0xa4, 0x7a, 0x58, 0xad, 0xa3, 0x47, 0x91, 0x74, 0x0d,
+	0x5c, 0x11, 0xeb, 0x02, 0xa7, 0xc5, 0x05, 0x21, 0xeb, 0xff, 0x65, 0xa1, 0xd4, 0xa7, 0xd1, 0x87,
+	0x7b, 0xb8, 0x7e, 0x83, 0x2f, 0x1f, 0x81, 0x8b, 0x44, 0xfa, 0x7e, 0x34, 0x6e, 0x5f, 0x7f, 0xb6,
+	0xa6, 0xb8, 0x06, 0x3e, 0xf1, 0xb7, 0xea, 0x58, 0x82, 0x63, 0xb0, 0x06, 0xc7, 0x40, 0xe3, 0x18,
+	0xb0, 0x31, 0xfa, 0x01, 0xca, 0x22, 0x08, 0x89, 0x88, 0xbd, 0x98, 0x12, 0xff, 0xd6, 0x63, 0x63,
+	0xba, 0x8a, 0x67, 0x3f, 0x08, 0xfb, 0x49, 0xd8, 0x35, 0xf0, 0xbe, 0xd0, 0xdf, 0x6d, 0x99, 0x89,
+	0xbe, 0x03, 0x4b, 0xa3, 0x9a, 0xf0, 0x49, 0x23, 0xf9, 0xc5, 0x43, 0x24, 0xc5, 0x99, 0x17, 0x7b,
+	0xae, 0x81, 0x41, 0x65, 0x26, 0x56, 0x72, 0x1b, 0x69, 0x5c, 0xa4, 0x7c, 0xd8, 0xd9, 0x87, 0xb7,
+	0xb1, 0x56, 0xc9, 0x92, 0xdb, 0xf0, 0xd7, 0x05, 0xd0, 0x3b, 0xa8, 0xa5, 0x1b, 0xcf, 0x94, 0x32,
+	0xd9, 0x39, 0xd9, 0xfa, 0xd9, 0xa6, 0xd6, 0x5a, 0xc0, 0x5c, 0x03, 0x57, 0xfd, 0xf5, 0x21, 0x34,
+	0x85, 0xe3, 0x0d, 0x2c, 0xba, 0xbf, 0xcb, 0xbc, 0x3c, 0xe5, 0xab, 0xcf, 0x7a, 0x98, 0xae, 0x81,
+	0x8f, 0xc4, 0xa3, 0x22, 0xf0, 0x0a, 0xf6, 0x35, 0x87, 0x89, 0x16, 0x97, 0x82, 0xec, 0x5f, 0x59,
+	0x27, 0x2e, 0x09, 0x4d, 0x4b, 0xfe, 0xb2, 0x03, 0x75, 0xe1, 0x50, 0x48, 0x4a, 0x12, 0x71, 0x3b,
+	0x8b, 0xe3, 0x64, 0xe2, 0x11, 0xff, 0xc8, 0xec, 0xa2, 0xec, 0x52, 0xbf, 0x9f, 0x32, 0xc9, 0xe9,
+	0xeb, 0x94, 0x33, 0xfe, 0x91, 0xb9, 0x06, 0x46, 0x22, 0xe5, 0x5d, 0xa2, 0x7b, 0xeb, 0x0f, 0x13,
+	0xca, 0xab, 0x18, 0x22, 0x0e, 0xf9, 0xbe, 0xfa, 0x47, 0x40, 0xdf, 0x6e, 0x02, 0x5a, 0xbf, 0x8e,
+	0x66, 0x5a, 0x74, 0xbe, 0xd9, 0x56, 0xf2, 0xe0, 0x79, 0x35, 0xcc, 0x17, 0xe6, 0x30, 0x27, 0x7f,
+	0x45, 0x5e, 0xfe, 0x3f, 0x00, 0x88, 0xe5, 0x83, 0xc1, 0xc7, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: recording.proto
+
+package erebus
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// A session recording is a sequence of entries, each prefixed with its length
+// in bytes encoded as a varint
+type RecordingEntry struct {
+	Time    *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	SimTime float64              `protobuf:"fixed64,2,opt,name=sim_time,json=simTime,proto3" json:"sim_time,omitempty"`
+	// Types that are valid to be assigned to Entry:
+	//	*RecordingEntry_Bound_
+	//	*RecordingEntry_Unbound_
+	//	*RecordingEntry_SensorData
+	//	*RecordingEntry_Commands
+	//	*RecordingEntry_SimStateChange
+	//	*RecordingEntry_PolicyCommands
+	Entry                isRecordingEntry_Entry `protobuf_oneof:"entry"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *RecordingEntry) Reset()         { *m = RecordingEntry{} }
+func (m *RecordingEntry) String() string { return proto.CompactTextString(m) }
+func (*RecordingEntry) ProtoMessage()    {}
+func (*RecordingEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_63603908395817d1, []int{0}
+}
+
+func (m *RecordingEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordingEntry.Unmarshal(m, b)
+}
+func (m *RecordingEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordingEntry.Marshal(b, m, deterministic)
+}
+func (m *RecordingEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordingEntry.Merge(m, src)
+}
+func (m *RecordingEntry) XXX_Size() int {
+	return xxx_messageInfo_RecordingEntry.Size(m)
+}
+func (m *RecordingEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordingEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordingEntry proto.InternalMessageInfo
+
+func (m *RecordingEntry) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *RecordingEntry) GetSimTime() float64 {
+	if m != nil {
+		return m.SimTime
+	}
+	return 0
+}
+
+type isRecordingEntry_Entry interface {
+	isRecordingEntry_Entry()
+}
+
+type RecordingEntry_Bound_ struct {
+	Bound *RecordingEntry_Bound `protobuf:"bytes,3,opt,name=bound,proto3,oneof"`
+}
+
+type RecordingEntry_Unbound_ struct {
+	Unbound *RecordingEntry_Unbound `protobuf:"bytes,4,opt,name=unbound,proto3,oneof"`
+}
+
+type RecordingEntry_SensorData struct {
+	SensorData *SensorsData `protobuf:"bytes,5,opt,name=sensor_data,json=sensorData,proto3,oneof"`
+}
+
+type RecordingEntry_Commands struct {
+	Commands *Commands `protobuf:"bytes,6,opt,name=commands,proto3,oneof"`
+}
+
+type RecordingEntry_SimStateChange struct {
+	SimStateChange *SimState `protobuf:"bytes,7,opt,name=sim_state_change,json=simStateChange,proto3,oneof"`
+}
+
+type RecordingEntry_PolicyCommands struct {
+	PolicyCommands *Commands `protobuf:"bytes,8,opt,name=policy_commands,json=policyCommands,proto3,oneof"`
+}
+
+func (*RecordingEntry_Bound_) isRecordingEntry_Entry() {}
+
+func (*RecordingEntry_Unbound_) isRecordingEntry_Entry() {}
+
+func (*RecordingEntry_SensorData) isRecordingEntry_Entry() {}
+
+func (*RecordingEntry_Commands) isRecordingEntry_Entry() {}
+
+func (*RecordingEntry_SimStateChange) isRecordingEntry_Entry() {}
+
+func (*RecordingEntry_PolicyCommands) isRecordingEntry_Entry() {}
+
+func (m *RecordingEntry) GetEntry() isRecordingEntry_Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *RecordingEntry) GetBound() *RecordingEntry_Bound {
+	if x, ok := m.GetEntry().(*RecordingEntry_Bound_); ok {
+		return x.Bound
+	}
+	return nil
+}
+
+func (m *RecordingEntry) GetUnbound() *RecordingEntry_Unbound {
+	if x, ok := m.GetEntry().(*RecordingEntry_Unbound_); ok {
+		return x.Unbound
+	}
+	return nil
+}
+
+func (m *RecordingEntry) GetSensorData() *SensorsData {
+	if x, ok := m.GetEntry().(*RecordingEntry_SensorData); ok {
+		return x.SensorData
+	}
+	return nil
+}
+
+func (m *RecordingEntry) GetCommands() *Commands {
+	if x, ok := m.GetEntry().(*RecordingEntry_Commands); ok {
+		return x.Commands
+	}
+	return nil
+}
+
+func (m *RecordingEntry) GetSimStateChange() *SimState {
+	if x, ok := m.GetEntry().(*RecordingEntry_SimStateChange); ok {
+		return x.SimStateChange
+	}
+	return nil
+}
+
+func (m *RecordingEntry) GetPolicyCommands() *Commands {
+	if x, ok := m.GetEntry().(*RecordingEntry_PolicyCommands); ok {
+		return x.PolicyCommands
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RecordingEntry) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RecordingEntry_Bound_)(nil),
+		(*RecordingEntry_Unbound_)(nil),
+		(*RecordingEntry_SensorData)(nil),
+		(*RecordingEntry_Commands)(nil),
+		(*RecordingEntry_SimStateChange)(nil),
+		(*RecordingEntry_PolicyCommands)(nil),
+	}
+}
+
+type RecordingEntry_Bound struct {
+	RobotName            string     `protobuf:"bytes,1,opt,name=robot_name,json=robotName,proto3" json:"robot_name,omitempty"`
+	ClientName           string     `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	IsSync               bool       `protobuf:"varint,3,opt,name=is_sync,json=isSync,proto3" json:"is_sync,omitempty"`
+	RobotInfo            *RobotInfo `protobuf:"bytes,4,opt,name=robot_info,json=robotInfo,proto3" json:"robot_info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RecordingEntry_Bound) Reset()         { *m = RecordingEntry_Bound{} }
+func (m *RecordingEntry_Bound) String() string { return proto.CompactTextString(m) }
+func (*RecordingEntry_Bound) ProtoMessage()    {}
+func (*RecordingEntry_Bound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_63603908395817d1, []int{0, 0}
+}
+
+func (m *RecordingEntry_Bound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordingEntry_Bound.Unmarshal(m, b)
+}
+func (m *RecordingEntry_Bound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordingEntry_Bound.Marshal(b, m, deterministic)
+}
+func (m *RecordingEntry_Bound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordingEntry_Bound.Merge(m, src)
+}
+func (m *RecordingEntry_Bound) XXX_Size() int {
+	return xxx_messageInfo_RecordingEntry_Bound.Size(m)
+}
+func (m *RecordingEntry_Bound) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordingEntry_Bound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordingEntry_Bound proto.InternalMessageInfo
+
+func (m *RecordingEntry_Bound) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+func (m *RecordingEntry_Bound) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+func (m *RecordingEntry_Bound) GetIsSync() bool {
+	if m != nil {
+		return m.IsSync
+	}
+	return false
+}
+
+func (m *RecordingEntry_Bound) GetRobotInfo() *RobotInfo {
+	if m != nil {
+		return m.RobotInfo
+	}
+	return nil
+}
+
+type RecordingEntry_Unbound struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecordingEntry_Unbound) Reset()         { *m = RecordingEntry_Unbound{} }
+func (m *RecordingEntry_Unbound) String() string { return proto.CompactTextString(m) }
+func (*RecordingEntry_Unbound) ProtoMessage()    {}
+func (*RecordingEntry_Unbound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_63603908395817d1, []int{0, 1}
+}
+
+func (m *RecordingEntry_Unbound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordingEntry_Unbound.Unmarshal(m, b)
+}
+func (m *RecordingEntry_Unbound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordingEntry_Unbound.Marshal(b, m, deterministic)
+}
+func (m *RecordingEntry_Unbound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordingEntry_Unbound.Merge(m, src)
+}
+func (m *RecordingEntry_Unbound) XXX_Size() int {
+	return xxx_messageInfo_RecordingEntry_Unbound.Size(m)
+}
+func (m *RecordingEntry_Unbound) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordingEntry_Unbound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordingEntry_Unbound proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*RecordingEntry)(nil), "erebus.RecordingEntry")
+	proto.RegisterType((*RecordingEntry_Bound)(nil), "erebus.RecordingEntry.Bound")
+	proto.RegisterType((*RecordingEntry_Unbound)(nil), "erebus.RecordingEntry.Unbound")
+}
+
+func init() { proto.RegisterFile("recording.proto", fileDescriptor_63603908395817d1) }
+
+var fileDescriptor_63603908395817d1 = []byte{
+	// 393 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xbf, 0x8e, 0xd4, 0x30,
+	0x10, 0x87, 0x93, 0x63, 0xf3, 0x6f, 0x56, 0xda, 0x3b, 0x4c, 0x41, 0x88, 0x80, 0x3b, 0x51, 0x5d,
+	0xe5, 0x43, 0x80, 0x28, 0x80, 0xea, 0x0e, 0xa4, 0xd0, 0x50, 0x38, 0x50, 0x47, 0x4e, 0xe2, 0x0d,
+	0x96, 0xd6, 0xf6, 0x2a, 0x76, 0x8a, 0xbc, 0x06, 0x6f, 0xc7, 0xdb, 0x9c, 0x6c, 0xc7, 0x2b, 0x5d,
+	0xb1, 0xdd, 0xec, 0xcc, 0xf7, 0xcd, 0x6c, 0xf2, 0x0b, 0x5c, 0x4e, 0xac, 0x57, 0xd3, 0xc0, 0xe5,
+	0x88, 0x8f, 0x93, 0x32, 0x0a, 0xa5, 0x6c, 0x62, 0xdd, 0xac, 0xab, 0xeb, 0x51, 0xa9, 0xf1, 0xc0,
+	0xee, 0x5c, 0xb7, 0x9b, 0xf7, 0x77, 0x86, 0x0b, 0xa6, 0x0d, 0x15, 0x47, 0x0f, 0x56, 0x85, 0xe6,
+	0xc2, 0x97, 0xef, 0xfe, 0x6f, 0x60, 0x47, 0xc2, 0x9e, 0x1f, 0xd2, 0x4c, 0x0b, 0xc2, 0xb0, 0xb1,
+	0x42, 0x19, 0xdf, 0xc4, 0xb7, 0xdb, 0x0f, 0x15, 0xf6, 0xdb, 0x70, 0xd8, 0x86, 0x7f, 0x87, 0x6d,
+	0xc4, 0x71, 0xe8, 0x15, 0xe4, 0x9a, 0x8b, 0xd6, 0x39, 0x17, 0x37, 0xf1, 0x6d, 0x4c, 0x32, 0xcd,
+	0x85, 0xa5, 0xd0, 0x27, 0x48, 0x3a, 0x35, 0xcb, 0xa1, 0x7c, 0xe6, 0x76, 0xbd, 0xc6, 0xfe, 0x1f,
+	0xe2, 0xa7, 0x17, 0xf1, 0xbd, 0x65, 0xea, 0x88, 0x78, 0x18, 0x7d, 0x81, 0x6c, 0x96, 0xde, 0xdb,
+	0x38, 0xef, 0xed, 0x19, 0xef, 0x8f, 0xa7, 0xea, 0x88, 0x04, 0x01, 0x7d, 0x86, 0xad, 0x66, 0x52,
+	0xab, 0xa9, 0x1d, 0xa8, 0xa1, 0x65, 0xe2, 0xfc, 0x17, 0xc1, 0x6f, 0xdc, 0x48, 0x7f, 0xa7, 0x86,
+	0xd6, 0x11, 0x01, 0x4f, 0xda, 0x5f, 0x08, 0x43, 0xde, 0x2b, 0x21, 0xa8, 0x1c, 0x74, 0x99, 0x3a,
+	0xe9, 0x2a, 0x48, 0x0f, 0x6b, 0xbf, 0x8e, 0xc8, 0x89, 0x41, 0xdf, 0xe0, 0xca, 0x3e, 0xb4, 0x36,
+	0xd4, 0xb0, 0xb6, 0xff, 0x4b, 0xe5, 0xc8, 0xca, 0xec, 0xa9, 0xd7, 0x70, 0xd1, 0xd8, 0x71, 0x1d,
+	0x91, 0x9d, 0x5e, 0xeb, 0x07, 0x47, 0xa2, 0xaf, 0x70, 0x79, 0x54, 0x07, 0xde, 0x2f, 0xed, 0xe9,
+	0x68, 0x7e, 0xf6, 0xe8, 0xce, 0xa3, 0xa1, 0x53, 0xfd, 0x8b, 0x21, 0x71, 0x6f, 0x0c, 0xbd, 0x01,
+	0x98, 0x54, 0xa7, 0x4c, 0x2b, 0xe9, 0x9a, 0x57, 0x41, 0x0a, 0xd7, 0xf9, 0x45, 0x05, 0x43, 0xd7,
+	0xb0, 0xed, 0x0f, 0x9c, 0xc9, 0x75, 0x7e, 0xe1, 0xe6, 0xe0, 0x5b, 0x0e, 0x78, 0x09, 0x19, 0xd7,
+	0xad, 0x5e, 0x64, 0xef, 0x02, 0xca, 0x49, 0xca, 0x75, 0xb3, 0xc8, 0x1e, 0xbd, 0x0f, 0x8b, 0xb9,
+	0xdc, 0xab, 0x35, 0x84, 0xe7, 0xa7, 0x10, 0xec, 0xe4, 0xa7, 0xdc, 0xab, 0xf5, 0x96, 0x2d, 0xab,
+	0x02, 0xb2, 0x35, 0x8d, 0xfb, 0x0c, 0x12, 0x66, 0xe3, 0xe9, 0x52, 0xf7, 0xc9, 0x7c, 0x7c, 0x1c,
+	0x00, 0xc6, 0xd1, 0x24, 0x92, 0xa9, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,671 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: scoreboard.proto
+
+package erebus
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GameEvent_Type int32
+
+const (
+	GameEvent_UNKNOWN          GameEvent_Type = 0
+	GameEvent_VICTIM_PICKED_UP GameEvent_Type = 1
+	GameEvent_VICTIM_DELIVERED GameEvent_Type = 2
+	GameEvent_BASE_ENTERED     GameEvent_Type = 3
+	GameEvent_BASE_EXITED      GameEvent_Type = 4
+	GameEvent_PENALTY          GameEvent_Type = 5
+)
+
+var GameEvent_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "VICTIM_PICKED_UP",
+	2: "VICTIM_DELIVERED",
+	3: "BASE_ENTERED",
+	4: "BASE_EXITED",
+	5: "PENALTY",
+}
+
+var GameEvent_Type_value = map[string]int32{
+	"UNKNOWN":          0,
+	"VICTIM_PICKED_UP": 1,
+	"VICTIM_DELIVERED": 2,
+	"BASE_ENTERED":     3,
+	"BASE_EXITED":      4,
+	"PENALTY":          5,
+}
+
+func (x GameEvent_Type) String() string {
+	return proto.EnumName(GameEvent_Type_name, int32(x))
+}
+
+func (GameEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ff350828fb116070, []int{0, 0}
+}
+
+type GameEvent struct {
+	Type                 GameEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=erebus.GameEvent_Type" json:"type,omitempty"`
+	RobotName            string         `protobuf:"bytes,2,opt,name=robot_name,json=robotName,proto3" json:"robot_name,omitempty"`
+	Points               int32          `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`
+	Description          string         `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Timestamp            float64        `protobuf:"fixed64,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GameEvent) Reset()         { *m = GameEvent{} }
+func (m *GameEvent) String() string { return proto.CompactTextString(m) }
+func (*GameEvent) ProtoMessage()    {}
+func (*GameEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff350828fb116070, []int{0}
+}
+
+func (m *GameEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GameEvent.Unmarshal(m, b)
+}
+func (m *GameEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GameEvent.Marshal(b, m, deterministic)
+}
+func (m *GameEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GameEvent.Merge(m, src)
+}
+func (m *GameEvent) XXX_Size() int {
+	return xxx_messageInfo_GameEvent.Size(m)
+}
+func (m *GameEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_GameEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GameEvent proto.InternalMessageInfo
+
+func (m *GameEvent) GetType() GameEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return GameEvent_UNKNOWN
+}
+
+func (m *GameEvent) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+func (m *GameEvent) GetPoints() int32 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+func (m *GameEvent) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *GameEvent) GetTimestamp() float64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type PostGameEventResponse struct {
+	// Types that are valid to be assigned to Data:
+	//	*PostGameEventResponse_Error
+	//	*PostGameEventResponse_Ok_
+	Data                 isPostGameEventResponse_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *PostGameEventResponse) Reset()         { *m = PostGameEventResponse{} }
+func (m *PostGameEventResponse) String() string { return proto.CompactTextString(m) }
+func (*PostGameEventResponse) ProtoMessage()    {}
+func (*PostGameEventResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff350828fb116070, []int{1}
+}
+
+func (m *PostGameEventResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PostGameEventResponse.Unmarshal(m, b)
+}
+func (m *PostGameEventResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PostGameEventResponse.Marshal(b, m, deterministic)
+}
+func (m *PostGameEventResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostGameEventResponse.Merge(m, src)
+}
+func (m *PostGameEventResponse) XXX_Size() int {
+	return xxx_messageInfo_PostGameEventResponse.Size(m)
+}
+func (m *PostGameEventResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostGameEventResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostGameEventResponse proto.InternalMessageInfo
+
+type isPostGameEventResponse_Data interface {
+	isPostGameEventResponse_Data()
+}
+
+type PostGameEventResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type PostGameEventResponse_Ok_ struct {
+	Ok *PostGameEventResponse_Ok `protobuf:"bytes,2,opt,name=ok,proto3,oneof"`
+}
+
+func (*PostGameEventResponse_Error) isPostGameEventResponse_Data() {}
+
+func (*PostGameEventResponse_Ok_) isPostGameEventResponse_Data() {}
+
+func (m *PostGameEventResponse) GetData() isPostGameEventResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *PostGameEventResponse) GetError() string {
+	if x, ok := m.GetData().(*PostGameEventResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *PostGameEventResponse) GetOk() *PostGameEventResponse_Ok {
+	if x, ok := m.GetData().(*PostGameEventResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PostGameEventResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PostGameEventResponse_Error)(nil),
+		(*PostGameEventResponse_Ok_)(nil),
+	}
+}
+
+type PostGameEventResponse_Ok struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PostGameEventResponse_Ok) Reset()         { *m = PostGameEventResponse_Ok{} }
+func (m *PostGameEventResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*PostGameEventResponse_Ok) ProtoMessage()    {}
+func (*PostGameEventResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff350828fb116070, []int{1, 0}
+}
+
+func (m *PostGameEventResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PostGameEventResponse_Ok.Unmarshal(m, b)
+}
+func (m *PostGameEventResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PostGameEventResponse_Ok.Marshal(b, m, deterministic)
+}
+func (m *PostGameEventResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostGameEventResponse_Ok.Merge(m, src)
+}
+func (m *PostGameEventResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_PostGameEventResponse_Ok.Size(m)
+}
+func (m *PostGameEventResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostGameEventResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostGameEventResponse_Ok proto.InternalMessageInfo
+
+type Scores struct {
+	Robots               []*Scores_RobotScore `protobuf:"bytes,1,rep,name=robots,proto3" json:"robots,omitempty"`
+	Teams                []*Scores_TeamScore  `protobuf:"bytes,2,rep,name=teams,proto3" json:"teams,omitempty"`
+	LastEvent            *GameEvent           `protobuf:"bytes,3,opt,name=last_event,json=lastEvent,proto3" json:"last_event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Scores) Reset()         { *m = Scores{} }
+func (m *Scores) String() string { return proto.CompactTextString(m) }
+func (*Scores) ProtoMessage()    {}
+func (*Scores) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff350828fb116070, []int{2}
+}
+
+func (m *Scores) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Scores.Unmarshal(m, b)
+}
+func (m *Scores) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Scores.Marshal(b, m, deterministic)
+}
+func (m *Scores) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Scores.Merge(m, src)
+}
+func (m *Scores) XXX_Size() int {
+	return xxx_messageInfo_Scores.Size(m)
+}
+func (m *Scores) XXX_DiscardUnknown() {
+	xxx_messageInfo_Scores.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Scores proto.InternalMessageInfo
+
+func (m *Scores) GetRobots() []*Scores_RobotScore {
+	if m != nil {
+		return m.Robots
+	}
+	return nil
+}
+
+func (m *Scores) GetTeams() []*Scores_TeamScore {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+func (m *Scores) GetLastEvent() *GameEvent {
+	if m != nil {
+		return m.LastEvent
+	}
+	return nil
+}
+
+type Scores_RobotScore struct {
+	RobotName            string   `protobuf:"bytes,1,opt,name=robot_name,json=robotName,proto3" json:"robot_name,omitempty"`
+	TeamName             string   `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Score                int32    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	VictimsPickedUp      int32    `protobuf:"varint,4,opt,name=victims_picked_up,json=victimsPickedUp,proto3" json:"victims_picked_up,omitempty"`
+	VictimsDelivered     int32    `protobuf:"varint,5,opt,name=victims_delivered,json=victimsDelivered,proto3" json:"victims_delivered,omitempty"`
+	Penalties            int32    `protobuf:"varint,6,opt,name=penalties,proto3" json:"penalties,omitempty"`
+	InBase               bool     `protobuf:"varint,7,opt,name=in_base,json=inBase,proto3" json:"in_base,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Scores_RobotScore) Reset()         { *m = Scores_RobotScore{} }
+func (m *Scores_RobotScore) String() string { return proto.CompactTextString(m) }
+func (*Scores_RobotScore) ProtoMessage()    {}
+func (*Scores_RobotScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff350828fb116070, []int{2, 0}
+}
+
+func (m *Scores_RobotScore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Scores_RobotScore.Unmarshal(m, b)
+}
+func (m *Scores_RobotScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Scores_RobotScore.Marshal(b, m, deterministic)
+}
+func (m *Scores_RobotScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Scores_RobotScore.Merge(m, src)
+}
+func (m *Scores_RobotScore) XXX_Size() int {
+	return xxx_messageInfo_Scores_RobotScore.Size(m)
+}
+func (m *Scores_RobotScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_Scores_RobotScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Scores_RobotScore proto.InternalMessageInfo
+
+func (m *Scores_RobotScore) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+func (m *Scores_RobotScore) GetTeamName() string {
+	if m != nil {
+		return m.TeamName
+	}
+	return ""
+}
+
+func (m *Scores_RobotScore) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Scores_RobotScore) GetVictimsPickedUp() int32 {
+	if m != nil {
+		return m.VictimsPickedUp
+	}
+	return 0
+}
+
+func (m *Scores_RobotScore) GetVictimsDelivered() int32 {
+	if m != nil {
+		return m.VictimsDelivered
+	}
+	return 0
+}
+
+func (m *Scores_RobotScore) GetPenalties() int32 {
+	if m != nil {
+		return m.Penalties
+	}
+	return 0
+}
+
+func (m *Scores_RobotScore) GetInBase() bool {
+	if m != nil {
+		return m.InBase
+	}
+	return false
+}
+
+type Scores_TeamScore struct {
+	TeamName             string   `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Score                int32    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Scores_TeamScore) Reset()         { *m = Scores_TeamScore{} }
+func (m *Scores_TeamScore) String() string { return proto.CompactTextString(m) }
+func (*Scores_TeamScore) ProtoMessage()    {}
+func (*Scores_TeamScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff350828fb116070, []int{2, 1}
+}
+
+func (m *Scores_TeamScore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Scores_TeamScore.Unmarshal(m, b)
+}
+func (m *Scores_TeamScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Scores_TeamScore.Marshal(b, m, deterministic)
+}
+func (m *Scores_TeamScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Scores_TeamScore.Merge(m, src)
+}
+func (m *Scores_TeamScore) XXX_Size() int {
+	return xxx_messageInfo_Scores_TeamScore.Size(m)
+}
+func (m *Scores_TeamScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_Scores_TeamScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Scores_TeamScore proto.InternalMessageInfo
+
+func (m *Scores_TeamScore) GetTeamName() string {
+	if m != nil {
+		return m.TeamName
+	}
+	return ""
+}
+
+func (m *Scores_TeamScore) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("erebus.GameEvent_Type", GameEvent_Type_name, GameEvent_Type_value)
+	proto.RegisterType((*GameEvent)(nil), "erebus.GameEvent")
+	proto.RegisterType((*PostGameEventResponse)(nil), "erebus.PostGameEventResponse")
+	proto.RegisterType((*PostGameEventResponse_Ok)(nil), "erebus.PostGameEventResponse.Ok")
+	proto.RegisterType((*Scores)(nil), "erebus.Scores")
+	proto.RegisterType((*Scores_RobotScore)(nil), "erebus.Scores.RobotScore")
+	proto.RegisterType((*Scores_TeamScore)(nil), "erebus.Scores.TeamScore")
+}
+
+func init() { proto.RegisterFile("scoreboard.proto", fileDescriptor_ff350828fb116070) }
+
+var fileDescriptor_ff350828fb116070 = []byte{
+	// 595 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0xed, 0x3a, 0xb1, 0x5b, 0x8f, 0x7b, 0x71, 0x97, 0x52, 0x4c, 0xa0, 0x92, 0x95, 0xa7, 0xa8,
+	0x08, 0xab, 0x04, 0xf1, 0x8a, 0xd4, 0x36, 0x56, 0x1b, 0xb5, 0xb8, 0xd1, 0x26, 0x2d, 0xf0, 0x64,
+	0xd9, 0xc9, 0x3c, 0x58, 0x89, 0xbd, 0xc6, 0xbb, 0xa9, 0xd4, 0xaf, 0xe1, 0x2b, 0xf8, 0x0d, 0x3e,
+	0x85, 0x6f, 0x40, 0x5e, 0xe7, 0xd6, 0x14, 0x10, 0x6f, 0x9e, 0x33, 0x67, 0x66, 0x77, 0xce, 0x9c,
+	0x35, 0xd8, 0x62, 0xc8, 0x0b, 0x8c, 0x79, 0x54, 0x8c, 0xbc, 0xbc, 0xe0, 0x92, 0x53, 0x03, 0x0b,
+	0x8c, 0xa7, 0xa2, 0x61, 0xc9, 0x87, 0x1c, 0x45, 0x05, 0x36, 0xbf, 0x6b, 0x60, 0x5e, 0x44, 0x29,
+	0xfa, 0xf7, 0x98, 0x49, 0x7a, 0x0c, 0xf5, 0x32, 0xe9, 0x10, 0x97, 0xb4, 0x76, 0xdb, 0x87, 0x5e,
+	0x55, 0xe1, 0x2d, 0x08, 0xde, 0xe0, 0x21, 0x47, 0xa6, 0x38, 0xf4, 0x08, 0xa0, 0xe0, 0x31, 0x97,
+	0x61, 0x16, 0xa5, 0xe8, 0x68, 0x2e, 0x69, 0x99, 0xcc, 0x54, 0x48, 0x10, 0xa5, 0x48, 0x0f, 0xc1,
+	0xc8, 0x79, 0x92, 0x49, 0xe1, 0xd4, 0x5c, 0xd2, 0xd2, 0xd9, 0x2c, 0xa2, 0x2e, 0x58, 0x23, 0x14,
+	0xc3, 0x22, 0xc9, 0x65, 0xc2, 0x33, 0xa7, 0xae, 0xea, 0x56, 0x21, 0xfa, 0x1a, 0x4c, 0x99, 0xa4,
+	0x28, 0x64, 0x94, 0xe6, 0x8e, 0xee, 0x92, 0x16, 0x61, 0x4b, 0xa0, 0xc9, 0xa1, 0x5e, 0x5e, 0x82,
+	0x5a, 0xb0, 0x79, 0x1b, 0x5c, 0x05, 0x37, 0x9f, 0x03, 0x7b, 0x83, 0x1e, 0x80, 0x7d, 0xd7, 0x3d,
+	0x1f, 0x74, 0x3f, 0x85, 0xbd, 0xee, 0xf9, 0x95, 0xdf, 0x09, 0x6f, 0x7b, 0x36, 0x59, 0x41, 0x3b,
+	0xfe, 0x75, 0xf7, 0xce, 0x67, 0x7e, 0xc7, 0xd6, 0xa8, 0x0d, 0xdb, 0x67, 0xa7, 0x7d, 0x3f, 0xf4,
+	0x83, 0x81, 0x42, 0x6a, 0x74, 0x0f, 0xac, 0x0a, 0xf9, 0xd2, 0x1d, 0xf8, 0x1d, 0xbb, 0x5e, 0xf6,
+	0xee, 0xf9, 0xc1, 0xe9, 0xf5, 0xe0, 0xab, 0xad, 0x37, 0xbf, 0xc1, 0xf3, 0x1e, 0x17, 0x72, 0xa1,
+	0x01, 0x43, 0x91, 0xf3, 0x4c, 0x94, 0x13, 0xea, 0x58, 0x14, 0xbc, 0x50, 0x6a, 0x99, 0x97, 0x1b,
+	0xac, 0x0a, 0x69, 0x1b, 0x34, 0x3e, 0x56, 0x82, 0x58, 0x6d, 0x77, 0x2e, 0xe1, 0x1f, 0x5b, 0x78,
+	0x37, 0xe3, 0xcb, 0x0d, 0xa6, 0xf1, 0x71, 0xa3, 0x0e, 0xda, 0xcd, 0xf8, 0xcc, 0x80, 0xfa, 0x28,
+	0x92, 0x51, 0xf3, 0x67, 0x0d, 0x8c, 0x7e, 0xb9, 0x3e, 0x41, 0xdf, 0x81, 0xa1, 0x34, 0x15, 0x0e,
+	0x71, 0x6b, 0x2d, 0xab, 0xfd, 0x72, 0xde, 0xb0, 0xca, 0x7b, 0xac, 0x4c, 0xaa, 0x6f, 0x36, 0x23,
+	0x52, 0x0f, 0x74, 0x89, 0x51, 0x2a, 0x1c, 0x4d, 0x55, 0x38, 0x6b, 0x15, 0x03, 0x8c, 0xd2, 0xaa,
+	0xa0, 0xa2, 0xd1, 0x13, 0x80, 0x49, 0x24, 0x64, 0x88, 0xe5, 0xd5, 0xd4, 0xb6, 0xac, 0xf6, 0xfe,
+	0x93, 0xd5, 0x33, 0xb3, 0x24, 0xa9, 0xcf, 0xc6, 0x2f, 0x02, 0xb0, 0x3c, 0x78, 0xcd, 0x09, 0x64,
+	0xdd, 0x09, 0xaf, 0xc0, 0x2c, 0x0f, 0x5a, 0xf5, 0xc9, 0x56, 0x09, 0xa8, 0xe4, 0x01, 0xe8, 0xca,
+	0xa8, 0x33, 0x97, 0x54, 0x01, 0x3d, 0x86, 0xfd, 0xfb, 0x64, 0x28, 0x93, 0x54, 0x84, 0x79, 0x32,
+	0x1c, 0xe3, 0x28, 0x9c, 0xe6, 0xca, 0x2a, 0x3a, 0xdb, 0x9b, 0x25, 0x7a, 0x0a, 0xbf, 0xcd, 0xe9,
+	0x9b, 0x25, 0x77, 0x84, 0x93, 0xe4, 0x1e, 0x0b, 0x1c, 0x29, 0xdb, 0xe8, 0xcc, 0x9e, 0x25, 0x3a,
+	0x73, 0xbc, 0xf4, 0x56, 0x8e, 0x59, 0x34, 0x91, 0x09, 0x0a, 0xc7, 0x50, 0xa4, 0x25, 0x40, 0x5f,
+	0xc0, 0x66, 0x92, 0x85, 0x71, 0x24, 0xd0, 0xd9, 0x74, 0x49, 0x6b, 0x8b, 0x19, 0x49, 0x76, 0x16,
+	0x09, 0x6c, 0x7c, 0x04, 0x73, 0x21, 0xdb, 0xe3, 0x79, 0xc8, 0xdf, 0xe6, 0xd1, 0x56, 0xe6, 0x69,
+	0xff, 0x20, 0x00, 0xfd, 0xc5, 0x7b, 0xa4, 0xa7, 0xb0, 0xf3, 0xc8, 0x0f, 0xf4, 0xa9, 0xdc, 0x8d,
+	0xa3, 0x7f, 0x3a, 0x87, 0xbe, 0x85, 0x9d, 0x0b, 0x94, 0x2b, 0x3d, 0xb7, 0xe7, 0xfc, 0x60, 0x3a,
+	0x99, 0x34, 0x76, 0x1f, 0x2f, 0x9d, 0x7e, 0x80, 0x67, 0xfd, 0x69, 0x5c, 0xbe, 0xb1, 0x18, 0xff,
+	0xb7, 0xe8, 0x84, 0xc4, 0x86, 0xfa, 0x49, 0xbc, 0xff, 0x3d, 0x00, 0x3c, 0x22, 0x88, 0xd4, 0x4d,
+	0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ScoreboardClient is the client API for Scoreboard service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ScoreboardClient interface {
+	PostGameEvent(ctx context.Context, in *GameEvent, opts ...grpc.CallOption) (*PostGameEventResponse, error)
+	GetScoreboard(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Scores, error)
+	// Sends the scores straight away and after every change; a subscriber
+	// which falls behind only gets the latest scores
+	SubscribeScoreboard(ctx context.Context, in *Null, opts ...grpc.CallOption) (Scoreboard_SubscribeScoreboardClient, error)
+}
+
+type scoreboardClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoreboardClient(cc grpc.ClientConnInterface) ScoreboardClient {
+	return &scoreboardClient{cc}
+}
+
+func (c *scoreboardClient) PostGameEvent(ctx context.Context, in *GameEvent, opts ...grpc.CallOption) (*PostGameEventResponse, error) {
+	out := new(PostGameEventResponse)
+	err := c.cc.Invoke(ctx, "/erebus.Scoreboard/PostGameEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardClient) GetScoreboard(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Scores, error) {
+	out := new(Scores)
+	err := c.cc.Invoke(ctx, "/erebus.Scoreboard/GetScoreboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardClient) SubscribeScoreboard(ctx context.Context, in *Null, opts ...grpc.CallOption) (Scoreboard_SubscribeScoreboardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Scoreboard_serviceDesc.Streams[0], "/erebus.Scoreboard/SubscribeScoreboard", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &scoreboardSubscribeScoreboardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Scoreboard_SubscribeScoreboardClient interface {
+	Recv() (*Scores, error)
+	grpc.ClientStream
+}
+
+type scoreboardSubscribeScoreboardClient struct {
+	grpc.ClientStream
+}
+
+func (x *scoreboardSubscribeScoreboardClient) Recv() (*Scores, error) {
+	m := new(Scores)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ScoreboardServer is the server API for Scoreboard service.
+type ScoreboardServer interface {
+	PostGameEvent(context.Context, *GameEvent) (*PostGameEventResponse, error)
+	GetScoreboard(context.Context, *Null) (*Scores, error)
+	// Sends the scores straight away and after every change; a subscriber
+	// which falls behind only gets the latest scores
+	SubscribeScoreboard(*Null, Scoreboard_SubscribeScoreboardServer) error
+}
+
+// UnimplementedScoreboardServer can be embedded to have forward compatible implementations.
+type UnimplementedScoreboardServer struct {
+}
+
+func (*UnimplementedScoreboardServer) PostGameEvent(ctx context.Context, req *GameEvent) (*PostGameEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostGameEvent not implemented")
+}
+func (*UnimplementedScoreboardServer) GetScoreboard(ctx context.Context, req *Null) (*Scores, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScoreboard not implemented")
+}
+func (*UnimplementedScoreboardServer) SubscribeScoreboard(req *Null, srv Scoreboard_SubscribeScoreboardServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeScoreboard not implemented")
+}
+
+func RegisterScoreboardServer(s *grpc.Server, srv ScoreboardServer) {
+	s.RegisterService(&_Scoreboard_serviceDesc, srv)
+}
+
+func _Scoreboard_PostGameEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServer).PostGameEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Scoreboard/PostGameEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServer).PostGameEvent(ctx, req.(*GameEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scoreboard_GetScoreboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServer).GetScoreboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/erebus.Scoreboard/GetScoreboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServer).GetScoreboard(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scoreboard_SubscribeScoreboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Null)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScoreboardServer).SubscribeScoreboard(m, &scoreboardSubscribeScoreboardServer{stream})
+}
+
+type Scoreboard_SubscribeScoreboardServer interface {
+	Send(*Scores) error
+	grpc.ServerStream
+}
+
+type scoreboardSubscribeScoreboardServer struct {
+	grpc.ServerStream
+}
+
+func (x *scoreboardSubscribeScoreboardServer) Send(m *Scores) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Scoreboard_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "erebus.Scoreboard",
+	HandlerType: (*ScoreboardServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PostGameEvent",
+			Handler:    _Scoreboard_PostGameEvent_Handler,
+		},
+		{
+			MethodName: "GetScoreboard",
+			Handler:    _Scoreboard_GetScoreboard_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeScoreboard",
+			Handler:       _Scoreboard_SubscribeScoreboard_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "scoreboard.proto",
+}
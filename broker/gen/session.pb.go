@@ -98,19 +98,62 @@ func (m *Pong) GetNonce() int32 {
 	return 0
 }
 
+// Sent to every peer just before the broker shuts down and ends its session
+type ServerShuttingDown struct {
+	Reason               string   `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServerShuttingDown) Reset()         { *m = ServerShuttingDown{} }
+func (m *ServerShuttingDown) String() string { return proto.CompactTextString(m) }
+func (*ServerShuttingDown) ProtoMessage()    {}
+func (*ServerShuttingDown) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3a6be1b361fa6f14, []int{2}
+}
+
+func (m *ServerShuttingDown) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerShuttingDown.Unmarshal(m, b)
+}
+func (m *ServerShuttingDown) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerShuttingDown.Marshal(b, m, deterministic)
+}
+func (m *ServerShuttingDown) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerShuttingDown.Merge(m, src)
+}
+func (m *ServerShuttingDown) XXX_Size() int {
+	return xxx_messageInfo_ServerShuttingDown.Size(m)
+}
+func (m *ServerShuttingDown) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerShuttingDown.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerShuttingDown proto.InternalMessageInfo
+
+func (m *ServerShuttingDown) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Ping)(nil), "erebus.Ping")
 	proto.RegisterType((*Pong)(nil), "erebus.Pong")
+	proto.RegisterType((*ServerShuttingDown)(nil), "erebus.ServerShuttingDown")
 }
 
 func init() { proto.RegisterFile("session.proto", fileDescriptor_3a6be1b361fa6f14) }
 
 var fileDescriptor_3a6be1b361fa6f14 = []byte{
-	// 83 bytes of a gzipped FileDescriptorProto
+	// 117 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2d, 0x4e, 0x2d, 0x2e,
 	0xce, 0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4b, 0x2d, 0x4a, 0x4d, 0x2a,
 	0x2d, 0x56, 0x92, 0xe1, 0x62, 0x09, 0xc8, 0xcc, 0x4b, 0x17, 0x12, 0xe1, 0x62, 0xcd, 0xcb, 0xcf,
-	0x4b, 0x4e, 0x95, 0x60, 0x54, 0x60, 0xd4, 0x60, 0x0d, 0x82, 0x70, 0xc0, 0xb2, 0xf9, 0xb8, 0x64,
-	0x93, 0xd8, 0xc0, 0x46, 0x19, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x98, 0x3a, 0x36, 0x5b,
-	0x00, 0x00, 0x00,
+	0x4b, 0x4e, 0x95, 0x60, 0x54, 0x60, 0xd4, 0x60, 0x0d, 0x82, 0x70, 0xc0, 0xb2, 0xf9, 0x38, 0x65,
+	0x75, 0xb8, 0x84, 0x82, 0x53, 0x8b, 0xca, 0x52, 0x8b, 0x82, 0x33, 0x4a, 0x4b, 0x4a, 0x32, 0xf3,
+	0xd2, 0x5d, 0xf2, 0xcb, 0xf3, 0x84, 0xc4, 0xb8, 0xd8, 0x8a, 0x52, 0x13, 0x8b, 0xf3, 0xf3, 0xc0,
+	0x8a, 0x39, 0x83, 0xa0, 0xbc, 0x24, 0x36, 0xb0, 0xc5, 0xc6, 0x80, 0x01, 0x00, 0xe5, 0x79, 0x07,
+	0x0f, 0x89, 0x00, 0x00, 0x00,
 }
@@ -85,7 +85,7 @@ func (x SimState_State) String() string {
 }
 
 func (SimState_State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{8, 0}
+	return fileDescriptor_469efc5e4ad605ad, []int{11, 0}
 }
 
 type SensorType struct {
@@ -536,6 +536,45 @@ func (m *SensorSamplingPeriod) GetSamplingPeriod() int32 {
 	return 0
 }
 
+type SensorSamplingPeriods struct {
+	Periods              []*SensorSamplingPeriod `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *SensorSamplingPeriods) Reset()         { *m = SensorSamplingPeriods{} }
+func (m *SensorSamplingPeriods) String() string { return proto.CompactTextString(m) }
+func (*SensorSamplingPeriods) ProtoMessage()    {}
+func (*SensorSamplingPeriods) Descriptor() ([]byte, []int) {
+	return fileDescriptor_469efc5e4ad605ad, []int{3}
+}
+
+func (m *SensorSamplingPeriods) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensorSamplingPeriods.Unmarshal(m, b)
+}
+func (m *SensorSamplingPeriods) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensorSamplingPeriods.Marshal(b, m, deterministic)
+}
+func (m *SensorSamplingPeriods) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensorSamplingPeriods.Merge(m, src)
+}
+func (m *SensorSamplingPeriods) XXX_Size() int {
+	return xxx_messageInfo_SensorSamplingPeriods.Size(m)
+}
+func (m *SensorSamplingPeriods) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensorSamplingPeriods.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensorSamplingPeriods proto.InternalMessageInfo
+
+func (m *SensorSamplingPeriods) GetPeriods() []*SensorSamplingPeriod {
+	if m != nil {
+		return m.Periods
+	}
+	return nil
+}
+
 type SensorInfo struct {
 	Name                 string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Type                 SensorType_SensorType `protobuf:"varint,2,opt,name=type,proto3,enum=erebus.SensorType_SensorType" json:"type,omitempty"`
@@ -548,7 +587,7 @@ func (m *SensorInfo) Reset()         { *m = SensorInfo{} }
 func (m *SensorInfo) String() string { return proto.CompactTextString(m) }
 func (*SensorInfo) ProtoMessage()    {}
 func (*SensorInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{3}
+	return fileDescriptor_469efc5e4ad605ad, []int{4}
 }
 
 func (m *SensorInfo) XXX_Unmarshal(b []byte) error {
@@ -595,7 +634,7 @@ func (m *SensorsData) Reset()         { *m = SensorsData{} }
 func (m *SensorsData) String() string { return proto.CompactTextString(m) }
 func (*SensorsData) ProtoMessage()    {}
 func (*SensorsData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{4}
+	return fileDescriptor_469efc5e4ad605ad, []int{5}
 }
 
 func (m *SensorsData) XXX_Unmarshal(b []byte) error {
@@ -645,7 +684,7 @@ func (m *Command) Reset()         { *m = Command{} }
 func (m *Command) String() string { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()    {}
 func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{5}
+	return fileDescriptor_469efc5e4ad605ad, []int{6}
 }
 
 func (m *Command) XXX_Unmarshal(b []byte) error {
@@ -730,7 +769,7 @@ func (m *Command_MotorCommand) Reset()         { *m = Command_MotorCommand{} }
 func (m *Command_MotorCommand) String() string { return proto.CompactTextString(m) }
 func (*Command_MotorCommand) ProtoMessage()    {}
 func (*Command_MotorCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{5, 0}
+	return fileDescriptor_469efc5e4ad605ad, []int{6, 0}
 }
 
 func (m *Command_MotorCommand) XXX_Unmarshal(b []byte) error {
@@ -770,7 +809,7 @@ func (m *Command_LEDCommand) Reset()         { *m = Command_LEDCommand{} }
 func (m *Command_LEDCommand) String() string { return proto.CompactTextString(m) }
 func (*Command_LEDCommand) ProtoMessage()    {}
 func (*Command_LEDCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{5, 1}
+	return fileDescriptor_469efc5e4ad605ad, []int{6, 1}
 }
 
 func (m *Command_LEDCommand) XXX_Unmarshal(b []byte) error {
@@ -809,7 +848,7 @@ func (m *Commands) Reset()         { *m = Commands{} }
 func (m *Commands) String() string { return proto.CompactTextString(m) }
 func (*Commands) ProtoMessage()    {}
 func (*Commands) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{6}
+	return fileDescriptor_469efc5e4ad605ad, []int{7}
 }
 
 func (m *Commands) XXX_Unmarshal(b []byte) error {
@@ -837,8 +876,98 @@ func (m *Commands) GetCommands() []*Command {
 	return nil
 }
 
+// WeBots Motor node
+type MotorInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxVelocity          float64  `protobuf:"fixed64,2,opt,name=max_velocity,json=maxVelocity,proto3" json:"max_velocity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MotorInfo) Reset()         { *m = MotorInfo{} }
+func (m *MotorInfo) String() string { return proto.CompactTextString(m) }
+func (*MotorInfo) ProtoMessage()    {}
+func (*MotorInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_469efc5e4ad605ad, []int{8}
+}
+
+func (m *MotorInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MotorInfo.Unmarshal(m, b)
+}
+func (m *MotorInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MotorInfo.Marshal(b, m, deterministic)
+}
+func (m *MotorInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MotorInfo.Merge(m, src)
+}
+func (m *MotorInfo) XXX_Size() int {
+	return xxx_messageInfo_MotorInfo.Size(m)
+}
+func (m *MotorInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_MotorInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MotorInfo proto.InternalMessageInfo
+
+func (m *MotorInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MotorInfo) GetMaxVelocity() float64 {
+	if m != nil {
+		return m.MaxVelocity
+	}
+	return 0
+}
+
+// WeBots LED node
+type LEDInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LEDInfo) Reset()         { *m = LEDInfo{} }
+func (m *LEDInfo) String() string { return proto.CompactTextString(m) }
+func (*LEDInfo) ProtoMessage()    {}
+func (*LEDInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_469efc5e4ad605ad, []int{9}
+}
+
+func (m *LEDInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LEDInfo.Unmarshal(m, b)
+}
+func (m *LEDInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LEDInfo.Marshal(b, m, deterministic)
+}
+func (m *LEDInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LEDInfo.Merge(m, src)
+}
+func (m *LEDInfo) XXX_Size() int {
+	return xxx_messageInfo_LEDInfo.Size(m)
+}
+func (m *LEDInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_LEDInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LEDInfo proto.InternalMessageInfo
+
+func (m *LEDInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type RobotInfo struct {
 	SensorInfos          []*SensorInfo `protobuf:"bytes,1,rep,name=sensor_infos,json=sensorInfos,proto3" json:"sensor_infos,omitempty"`
+	MotorInfos           []*MotorInfo  `protobuf:"bytes,2,rep,name=motor_infos,json=motorInfos,proto3" json:"motor_infos,omitempty"`
+	LedInfos             []*LEDInfo    `protobuf:"bytes,3,rep,name=led_infos,json=ledInfos,proto3" json:"led_infos,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -848,7 +977,7 @@ func (m *RobotInfo) Reset()         { *m = RobotInfo{} }
 func (m *RobotInfo) String() string { return proto.CompactTextString(m) }
 func (*RobotInfo) ProtoMessage()    {}
 func (*RobotInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{7}
+	return fileDescriptor_469efc5e4ad605ad, []int{10}
 }
 
 func (m *RobotInfo) XXX_Unmarshal(b []byte) error {
@@ -876,6 +1005,20 @@ func (m *RobotInfo) GetSensorInfos() []*SensorInfo {
 	return nil
 }
 
+func (m *RobotInfo) GetMotorInfos() []*MotorInfo {
+	if m != nil {
+		return m.MotorInfos
+	}
+	return nil
+}
+
+func (m *RobotInfo) GetLedInfos() []*LEDInfo {
+	if m != nil {
+		return m.LedInfos
+	}
+	return nil
+}
+
 type SimState struct {
 	State                SimState_State `protobuf:"varint,1,opt,name=state,proto3,enum=erebus.SimState_State" json:"state,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -887,7 +1030,7 @@ func (m *SimState) Reset()         { *m = SimState{} }
 func (m *SimState) String() string { return proto.CompactTextString(m) }
 func (*SimState) ProtoMessage()    {}
 func (*SimState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_469efc5e4ad605ad, []int{8}
+	return fileDescriptor_469efc5e4ad605ad, []int{11}
 }
 
 func (m *SimState) XXX_Unmarshal(b []byte) error {
@@ -926,12 +1069,15 @@ func init() {
 	proto.RegisterType((*SensorData_CameraRecognitionData)(nil), "erebus.SensorData.CameraRecognitionData")
 	proto.RegisterType((*SensorData_CameraRecognitionData_WbCameraRecognitionObject)(nil), "erebus.SensorData.CameraRecognitionData.WbCameraRecognitionObject")
 	proto.RegisterType((*SensorSamplingPeriod)(nil), "erebus.SensorSamplingPeriod")
+	proto.RegisterType((*SensorSamplingPeriods)(nil), "erebus.SensorSamplingPeriods")
 	proto.RegisterType((*SensorInfo)(nil), "erebus.SensorInfo")
 	proto.RegisterType((*SensorsData)(nil), "erebus.SensorsData")
 	proto.RegisterType((*Command)(nil), "erebus.Command")
 	proto.RegisterType((*Command_MotorCommand)(nil), "erebus.Command.MotorCommand")
 	proto.RegisterType((*Command_LEDCommand)(nil), "erebus.Command.LEDCommand")
 	proto.RegisterType((*Commands)(nil), "erebus.Commands")
+	proto.RegisterType((*MotorInfo)(nil), "erebus.MotorInfo")
+	proto.RegisterType((*LEDInfo)(nil), "erebus.LEDInfo")
 	proto.RegisterType((*RobotInfo)(nil), "erebus.RobotInfo")
 	proto.RegisterType((*SimState)(nil), "erebus.SimState")
 }
@@ -939,56 +1085,62 @@ func init() {
 func init() { proto.RegisterFile("sim.proto", fileDescriptor_469efc5e4ad605ad) }
 
 var fileDescriptor_469efc5e4ad605ad = []byte{
-	// 812 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0xe7, 0x67, 0x93, 0x9c, 0xec, 0x4f, 0x3a, 0x6c, 0xcb, 0xd6, 0x6a, 0xa5, 0x95, 0x25,
-	0x20, 0x2a, 0x28, 0x12, 0x29, 0x88, 0x2b, 0x90, 0xb2, 0x59, 0x43, 0x2d, 0x5a, 0x3b, 0x1a, 0x1b,
-	0x55, 0x48, 0x48, 0xd1, 0xc4, 0x9e, 0x2e, 0x83, 0x6c, 0x8f, 0xe5, 0x99, 0x16, 0x2d, 0x0f, 0xc0,
-	0x05, 0x12, 0x0f, 0xc0, 0xf3, 0x70, 0xc9, 0x2b, 0x71, 0x81, 0x66, 0x3c, 0x4e, 0xd6, 0xeb, 0xac,
-	0xe0, 0x82, 0x9b, 0xe8, 0x9c, 0xcf, 0xdf, 0xf9, 0xe6, 0xfc, 0xcd, 0x04, 0x46, 0x82, 0x65, 0xb3,
-	0xa2, 0xe4, 0x92, 0xa3, 0x43, 0x5a, 0xd2, 0xcd, 0x5b, 0x61, 0x8f, 0xe5, 0x4d, 0x41, 0x45, 0x05,
-	0x3a, 0x7f, 0x58, 0x00, 0x21, 0xcd, 0x05, 0x2f, 0xa3, 0x9b, 0x82, 0x3a, 0xbf, 0x35, 0x5c, 0x34,
-	0x86, 0xc1, 0x77, 0xfe, 0xb7, 0x7e, 0xf0, 0xda, 0x9f, 0x1c, 0xa0, 0xf7, 0xe0, 0xf4, 0xca, 0x0b,
-	0xa3, 0x85, 0xbf, 0x74, 0xd7, 0xa1, 0xeb, 0x87, 0x01, 0x9e, 0x58, 0x0a, 0x5c, 0x05, 0xa1, 0x17,
-	0x79, 0x81, 0x5f, 0x83, 0x1d, 0x05, 0x7a, 0xbe, 0x8b, 0x23, 0x6f, 0xf1, 0xb2, 0x06, 0xbb, 0xe8,
-	0x01, 0x1c, 0x2f, 0x17, 0xaf, 0x5c, 0xbc, 0xa8, 0xa1, 0x1e, 0x7a, 0x0a, 0x8f, 0x0d, 0x84, 0xdd,
-	0x65, 0xf0, 0x8d, 0xdf, 0x90, 0xe9, 0x3b, 0xbf, 0x0f, 0xea, 0x64, 0xae, 0x88, 0x24, 0x08, 0x41,
-	0x2f, 0x27, 0x19, 0x3d, 0xb7, 0x2e, 0xac, 0xe9, 0x08, 0x6b, 0x1b, 0x7d, 0x0f, 0x67, 0x09, 0x13,
-	0x92, 0xe4, 0x31, 0x5d, 0x0b, 0x4d, 0x5d, 0x27, 0x44, 0x92, 0xf3, 0xce, 0x85, 0x35, 0x1d, 0xcf,
-	0x3f, 0x98, 0x55, 0x25, 0xcf, 0x76, 0x2a, 0xb3, 0x2b, 0x43, 0xdf, 0x41, 0x2f, 0x0e, 0x30, 0x4a,
-	0x5a, 0xa8, 0x92, 0x2e, 0xb8, 0x60, 0x92, 0xf1, 0xbc, 0x21, 0xdd, 0xbd, 0x57, 0x7a, 0x65, 0xe8,
-	0x4d, 0xe9, 0xa2, 0x85, 0x2a, 0x69, 0x96, 0xd3, 0x52, 0x32, 0x92, 0x36, 0xa4, 0x7b, 0xf7, 0x4a,
-	0x7b, 0x86, 0xde, 0x94, 0x66, 0x2d, 0x14, 0x6d, 0xe0, 0xfd, 0x98, 0x64, 0xb4, 0x24, 0xeb, 0x92,
-	0xc6, 0xfc, 0x3a, 0xaf, 0xf2, 0xd7, 0xea, 0x7d, 0xad, 0x3e, 0xdd, 0xa3, 0xbe, 0xd4, 0x11, 0x78,
-	0x17, 0x60, 0x0e, 0x78, 0x18, 0xef, 0xfb, 0x60, 0x3f, 0x03, 0xd4, 0xee, 0x22, 0x3a, 0x83, 0xfe,
-	0x3b, 0x92, 0xbe, 0xad, 0xe6, 0x63, 0xe1, 0xca, 0x51, 0xdc, 0x76, 0x5b, 0xee, 0xe1, 0xae, 0x00,
-	0xb5, 0xeb, 0x54, 0x63, 0x2f, 0x79, 0x9a, 0x1a, 0xaa, 0xb6, 0x55, 0x7c, 0xc1, 0x64, 0xfc, 0xa3,
-	0x9e, 0xb3, 0x85, 0x2b, 0x07, 0x4d, 0xa0, 0x7b, 0x43, 0x7e, 0xd6, 0x03, 0xb2, 0xb0, 0x32, 0xed,
-	0x3f, 0x3b, 0xf0, 0x70, 0x6f, 0x71, 0xe8, 0x07, 0x18, 0xf0, 0xcd, 0x4f, 0x34, 0x96, 0xe2, 0xdc,
-	0xba, 0xe8, 0x4e, 0xc7, 0xf3, 0xcb, 0xff, 0xda, 0x97, 0xd9, 0xeb, 0x4d, 0x0b, 0x0f, 0xb4, 0x14,
-	0xae, 0x25, 0xed, 0xbf, 0x2c, 0x78, 0x7c, 0x2f, 0x0d, 0x9d, 0x40, 0x87, 0x25, 0xba, 0x9e, 0x3e,
-	0xee, 0xb0, 0x04, 0x7d, 0x0d, 0x0f, 0xb6, 0x9b, 0xc6, 0xf3, 0x35, 0xcb, 0xc8, 0x35, 0x35, 0x1b,
-	0x6c, 0xd7, 0x59, 0x2d, 0x49, 0x29, 0xa9, 0x60, 0x24, 0xf7, 0x72, 0xf9, 0x7c, 0xbe, 0x22, 0xac,
-	0xc4, 0xa7, 0x75, 0x50, 0x90, 0x7b, 0x2a, 0x04, 0x7d, 0x05, 0xc7, 0x82, 0xfd, 0x42, 0x77, 0x1a,
-	0xdd, 0x7f, 0xd5, 0x18, 0xab, 0x80, 0x3a, 0xfe, 0x11, 0x1c, 0xc6, 0x3c, 0xe5, 0xa5, 0x38, 0xef,
-	0x5d, 0x74, 0xa7, 0x16, 0x36, 0xde, 0xe5, 0x21, 0xf4, 0xd4, 0x02, 0x39, 0xbf, 0x5a, 0x70, 0x56,
-	0x75, 0x27, 0x24, 0x59, 0x91, 0xb2, 0xfc, 0x7a, 0x45, 0x4b, 0xc6, 0x93, 0xbd, 0x37, 0xf3, 0x53,
-	0xe8, 0xa9, 0x77, 0x46, 0xd7, 0x71, 0x32, 0x7f, 0xda, 0xec, 0xae, 0x7a, 0x5c, 0x6e, 0x99, 0x58,
-	0x53, 0xd1, 0x47, 0x70, 0x2a, 0x8c, 0xf0, 0xba, 0xd0, 0xca, 0xba, 0x82, 0x3e, 0x3e, 0x11, 0x8d,
-	0xf3, 0x9c, 0xb0, 0x7e, 0x17, 0xbc, 0xfc, 0x0d, 0xff, 0x9f, 0x4e, 0x77, 0x42, 0x18, 0x57, 0x98,
-	0xd0, 0x0b, 0xf2, 0x61, 0x55, 0xb4, 0xd9, 0x0e, 0xd4, 0xde, 0x0e, 0xac, 0xbf, 0xa3, 0x27, 0x30,
-	0x92, 0x2c, 0xa3, 0x42, 0x92, 0xac, 0x30, 0xeb, 0xb8, 0x03, 0x9c, 0xbf, 0x2d, 0x18, 0x2c, 0x79,
-	0x96, 0x91, 0x7c, 0x7f, 0x97, 0xbe, 0x84, 0x71, 0x4a, 0x93, 0x75, 0x5c, 0x51, 0x5a, 0x43, 0xaf,
-	0xe0, 0xd9, 0x4b, 0xf7, 0xca, 0x98, 0x2f, 0x0e, 0x30, 0xa4, 0x34, 0xa9, 0x25, 0x97, 0x70, 0x9c,
-	0x71, 0xc9, 0xcb, 0xad, 0x40, 0x35, 0xf1, 0x27, 0x77, 0x05, 0x5e, 0x29, 0xd2, 0x4e, 0xe2, 0x28,
-	0xbb, 0xe5, 0xdb, 0xcf, 0xe0, 0xe8, 0xf6, 0x77, 0x64, 0xc3, 0xf0, 0x1d, 0x4d, 0x79, 0xcc, 0xe4,
-	0x8d, 0xb9, 0x74, 0x5b, 0xdf, 0x76, 0x00, 0x76, 0xc9, 0xa8, 0x6b, 0x28, 0x24, 0x91, 0xd4, 0xec,
-	0x72, 0xe5, 0x5c, 0x8e, 0x60, 0x60, 0xd2, 0x71, 0xbe, 0x80, 0xa1, 0xe1, 0x0a, 0xf4, 0x31, 0x0c,
-	0x0d, 0x5c, 0x5f, 0xb9, 0xd3, 0x3b, 0x69, 0xe2, 0x2d, 0xc1, 0xb9, 0x84, 0x11, 0xe6, 0x1b, 0x2e,
-	0xf5, 0x80, 0x3f, 0x87, 0x23, 0xf3, 0x4a, 0xb2, 0xfc, 0x0d, 0x17, 0xfb, 0x47, 0xa2, 0x98, 0x78,
-	0x2c, 0xb6, 0xb6, 0x70, 0x72, 0x18, 0x86, 0x2c, 0x0b, 0x55, 0x4e, 0xe8, 0x93, 0xdb, 0x99, 0x9e,
-	0xcc, 0x1f, 0x6d, 0x63, 0x0d, 0x61, 0xa6, 0x7f, 0x4d, 0x05, 0xce, 0x67, 0xd0, 0xaf, 0xc2, 0x1a,
-	0xff, 0x7f, 0x23, 0xe8, 0x87, 0xd1, 0x02, 0x47, 0x13, 0x0b, 0x0d, 0xa1, 0x17, 0x46, 0xc1, 0x6a,
-	0xd2, 0x51, 0x20, 0x76, 0x43, 0x37, 0x9a, 0x74, 0x37, 0x87, 0xfa, 0x1f, 0xf5, 0xf9, 0x3f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x93, 0x75, 0xdb, 0x09, 0x73, 0x07, 0x00, 0x00,
+	// 899 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5b, 0x6f, 0xe3, 0x44,
+	0x14, 0xae, 0x73, 0x69, 0x92, 0xe3, 0x5e, 0xd2, 0xa1, 0x5d, 0xba, 0x51, 0x2b, 0x15, 0x4b, 0x40,
+	0xb4, 0xac, 0x22, 0x91, 0xe5, 0xf2, 0x04, 0x52, 0x9a, 0x04, 0x36, 0xa2, 0x9b, 0x44, 0xe3, 0xc0,
+	0x0a, 0x09, 0x29, 0x9a, 0xd8, 0xb3, 0x65, 0x90, 0xed, 0xb1, 0x3c, 0xb3, 0xcb, 0x96, 0x1f, 0xc0,
+	0x03, 0x12, 0x3f, 0x80, 0x77, 0xfe, 0x09, 0x8f, 0xfc, 0x25, 0x1e, 0xd0, 0x5c, 0x9c, 0xd4, 0xeb,
+	0x44, 0xf0, 0xb0, 0x2f, 0xd1, 0x39, 0x9f, 0xbf, 0xf3, 0xcd, 0xb9, 0xcd, 0x28, 0xd0, 0x12, 0x2c,
+	0xee, 0xa5, 0x19, 0x97, 0x1c, 0xed, 0xd3, 0x8c, 0xae, 0x5e, 0x8a, 0x8e, 0x2b, 0xef, 0x52, 0x2a,
+	0x0c, 0xe8, 0xfd, 0xe1, 0x00, 0xf8, 0x34, 0x11, 0x3c, 0x5b, 0xdc, 0xa5, 0xd4, 0xfb, 0xad, 0xe0,
+	0x22, 0x17, 0x1a, 0xdf, 0x4e, 0xbf, 0x99, 0xce, 0x9e, 0x4f, 0xdb, 0x7b, 0xe8, 0x1d, 0x38, 0x1e,
+	0x4d, 0xfc, 0xc5, 0x60, 0x3a, 0x1c, 0x2f, 0xfd, 0xf1, 0xd4, 0x9f, 0xe1, 0xb6, 0xa3, 0xc0, 0xf9,
+	0xcc, 0x9f, 0x2c, 0x26, 0xb3, 0x69, 0x0e, 0x56, 0x14, 0x38, 0x99, 0x8e, 0xf1, 0x62, 0x32, 0xb8,
+	0xc9, 0xc1, 0x2a, 0x3a, 0x81, 0xc3, 0xe1, 0xe0, 0xd9, 0x18, 0x0f, 0x72, 0xa8, 0x86, 0x2e, 0xe1,
+	0xa1, 0x85, 0xf0, 0x78, 0x38, 0xfb, 0x7a, 0x5a, 0x90, 0xa9, 0x7b, 0xbf, 0x37, 0xf2, 0x64, 0x46,
+	0x44, 0x12, 0x84, 0xa0, 0x96, 0x90, 0x98, 0x9e, 0x3b, 0x57, 0x4e, 0xb7, 0x85, 0xb5, 0x8d, 0xbe,
+	0x87, 0xd3, 0x90, 0x09, 0x49, 0x92, 0x80, 0x2e, 0x85, 0xa6, 0x2e, 0x43, 0x22, 0xc9, 0x79, 0xe5,
+	0xca, 0xe9, 0xba, 0xfd, 0xf7, 0x7b, 0xa6, 0xe4, 0xde, 0x46, 0xa5, 0x37, 0xb2, 0xf4, 0x0d, 0xf4,
+	0x74, 0x0f, 0xa3, 0xb0, 0x84, 0x2a, 0xe9, 0x94, 0x0b, 0x26, 0x19, 0x4f, 0x0a, 0xd2, 0xd5, 0x9d,
+	0xd2, 0x73, 0x4b, 0x2f, 0x4a, 0xa7, 0x25, 0x54, 0x49, 0xb3, 0x84, 0x66, 0x92, 0x91, 0xa8, 0x20,
+	0x5d, 0xdb, 0x29, 0x3d, 0xb1, 0xf4, 0xa2, 0x34, 0x2b, 0xa1, 0x68, 0x05, 0xef, 0x06, 0x24, 0xa6,
+	0x19, 0x59, 0x66, 0x34, 0xe0, 0xb7, 0x89, 0xc9, 0x5f, 0xab, 0xd7, 0xb5, 0x7a, 0x77, 0x8b, 0xfa,
+	0x50, 0x47, 0xe0, 0x4d, 0x80, 0x3d, 0xe0, 0x2c, 0xd8, 0xf6, 0xa1, 0xf3, 0x08, 0x50, 0xb9, 0x8b,
+	0xe8, 0x14, 0xea, 0xaf, 0x48, 0xf4, 0xd2, 0xcc, 0xc7, 0xc1, 0xc6, 0x51, 0xdc, 0x72, 0x5b, 0x76,
+	0x70, 0xe7, 0x80, 0xca, 0x75, 0xaa, 0xb1, 0x67, 0x3c, 0x8a, 0x2c, 0x55, 0xdb, 0x2a, 0x3e, 0x65,
+	0x32, 0xf8, 0x51, 0xcf, 0xd9, 0xc1, 0xc6, 0x41, 0x6d, 0xa8, 0xde, 0x91, 0x9f, 0xf5, 0x80, 0x1c,
+	0xac, 0xcc, 0xce, 0x5f, 0x15, 0x38, 0xdb, 0x5a, 0x1c, 0xfa, 0x01, 0x1a, 0x7c, 0xf5, 0x13, 0x0d,
+	0xa4, 0x38, 0x77, 0xae, 0xaa, 0x5d, 0xb7, 0x7f, 0xfd, 0x7f, 0xfb, 0xd2, 0x7b, 0xbe, 0x2a, 0xe1,
+	0x33, 0x2d, 0x85, 0x73, 0xc9, 0xce, 0xdf, 0x0e, 0x3c, 0xdc, 0x49, 0x43, 0x47, 0x50, 0x61, 0xa1,
+	0xae, 0xa7, 0x8e, 0x2b, 0x2c, 0x44, 0x5f, 0xc1, 0xc9, 0x7a, 0xd3, 0x78, 0xb2, 0x64, 0x31, 0xb9,
+	0xa5, 0x76, 0x83, 0x3b, 0x79, 0x56, 0x43, 0x92, 0x49, 0x2a, 0x18, 0x49, 0x26, 0x89, 0x7c, 0xd2,
+	0x9f, 0x13, 0x96, 0xe1, 0xe3, 0x3c, 0x68, 0x96, 0x4c, 0x54, 0x08, 0xfa, 0x12, 0x0e, 0x05, 0xfb,
+	0x85, 0x6e, 0x34, 0xaa, 0xff, 0xa9, 0xe1, 0xaa, 0x80, 0x3c, 0xfe, 0x01, 0xec, 0x07, 0x3c, 0xe2,
+	0x99, 0x38, 0xaf, 0x5d, 0x55, 0xbb, 0x0e, 0xb6, 0xde, 0xf5, 0x3e, 0xd4, 0xd4, 0x02, 0x79, 0xbf,
+	0x3a, 0x70, 0x6a, 0xba, 0xe3, 0x93, 0x38, 0x8d, 0x58, 0x72, 0x3b, 0xa7, 0x19, 0xe3, 0xe1, 0xd6,
+	0x9b, 0xf9, 0x31, 0xd4, 0xd4, 0x3b, 0xa3, 0xeb, 0x38, 0xea, 0x5f, 0x16, 0xbb, 0xab, 0x1e, 0x97,
+	0x7b, 0x26, 0xd6, 0x54, 0xf4, 0x21, 0x1c, 0x0b, 0x2b, 0xbc, 0x4c, 0xb5, 0xb2, 0xae, 0xa0, 0x8e,
+	0x8f, 0x44, 0xe1, 0x3c, 0x6f, 0x06, 0x67, 0xdb, 0xf2, 0x10, 0xe8, 0x33, 0x68, 0x98, 0xc0, 0x7c,
+	0xaa, 0x17, 0xc5, 0x73, 0x8b, 0x7c, 0x9c, 0x93, 0x3d, 0x3f, 0x7f, 0x68, 0x26, 0xc9, 0x0b, 0xfe,
+	0x96, 0xca, 0xf1, 0x7c, 0x70, 0x0d, 0x26, 0xf4, 0xc6, 0x7d, 0x60, 0xba, 0x68, 0x13, 0x43, 0xe5,
+	0x75, 0xc3, 0xfa, 0x3b, 0xba, 0x80, 0x96, 0x64, 0x31, 0x15, 0x92, 0xc4, 0xa9, 0xdd, 0xef, 0x0d,
+	0xe0, 0xfd, 0xe3, 0x40, 0x63, 0xc8, 0xe3, 0x98, 0x24, 0xdb, 0xdb, 0xfe, 0x05, 0xb8, 0x11, 0x0d,
+	0x97, 0x81, 0xa1, 0x94, 0xb6, 0xc8, 0xc0, 0xbd, 0x9b, 0xf1, 0xc8, 0x9a, 0x4f, 0xf7, 0x30, 0x44,
+	0x34, 0xcc, 0x25, 0x87, 0x70, 0x18, 0x73, 0xc9, 0xb3, 0xb5, 0x80, 0x59, 0xa1, 0x8b, 0x37, 0x05,
+	0x9e, 0x29, 0xd2, 0x46, 0xe2, 0x20, 0xbe, 0xe7, 0x77, 0x1e, 0xc1, 0xc1, 0xfd, 0xef, 0xa8, 0x03,
+	0xcd, 0x57, 0x34, 0xe2, 0x01, 0x93, 0x77, 0xf6, 0x16, 0xaf, 0xfd, 0x8e, 0x07, 0xb0, 0x49, 0x46,
+	0xdd, 0x6b, 0x21, 0x89, 0xa4, 0xf6, 0x72, 0x18, 0xe7, 0xba, 0x05, 0x0d, 0x9b, 0x8e, 0xf7, 0x39,
+	0x34, 0x2d, 0x57, 0xa0, 0x8f, 0xa0, 0x69, 0xe1, 0x7c, 0xda, 0xc7, 0x6f, 0xa4, 0x89, 0xd7, 0x04,
+	0xef, 0x1a, 0x5a, 0x3a, 0xa7, 0x9d, 0x03, 0x7e, 0x0f, 0x0e, 0x62, 0xf2, 0x7a, 0xb9, 0x4e, 0xd4,
+	0x74, 0xde, 0x8d, 0xc9, 0xeb, 0xef, 0x2c, 0xe4, 0x5d, 0x42, 0xe3, 0x66, 0x3c, 0xda, 0xa5, 0xe0,
+	0xfd, 0xe9, 0x40, 0x0b, 0xf3, 0x15, 0x97, 0x9a, 0xf1, 0x29, 0x1c, 0xd8, 0xa7, 0x9d, 0x25, 0x2f,
+	0xb8, 0xd8, 0x3e, 0x76, 0xc5, 0xc4, 0xae, 0x58, 0xdb, 0x02, 0xf5, 0xc1, 0x35, 0x03, 0x30, 0x51,
+	0x15, 0x1d, 0x75, 0x92, 0x47, 0xad, 0x4b, 0xc0, 0x10, 0xe7, 0xa6, 0x40, 0x8f, 0xa1, 0xa5, 0x66,
+	0x6e, 0x22, 0xaa, 0xc5, 0x4e, 0xd8, 0x84, 0x71, 0x33, 0xa2, 0xa1, 0x66, 0x7b, 0x09, 0x34, 0x7d,
+	0x16, 0xfb, 0xaa, 0xb3, 0xe8, 0xf1, 0xfd, 0x7e, 0x1f, 0xf5, 0x1f, 0xac, 0xb3, 0xb3, 0x84, 0x9e,
+	0xfe, 0xb5, 0x73, 0xf0, 0x3e, 0x81, 0xba, 0x09, 0x2b, 0xfc, 0x2d, 0x68, 0x41, 0xdd, 0x5f, 0x0c,
+	0xf0, 0xa2, 0xed, 0xa0, 0x26, 0xd4, 0xfc, 0xc5, 0x6c, 0xde, 0xae, 0x28, 0x10, 0x8f, 0xfd, 0xf1,
+	0xa2, 0x5d, 0x5d, 0xed, 0xeb, 0x3f, 0x1a, 0x4f, 0xfe, 0x1d, 0x00, 0x8e, 0x8a, 0x62, 0x22, 0x8a,
+	0x08, 0x00, 0x00,
 }
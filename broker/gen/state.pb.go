@@ -0,0 +1,234 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: state.proto
+
+package erebus
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// The broker's durable state, which it saves to its state file on every
+// change and restores from it on startup
+type BrokerState struct {
+	SavedAt  *timestamp.Timestamp `protobuf:"bytes,1,opt,name=saved_at,json=savedAt,proto3" json:"saved_at,omitempty"`
+	SimState *SimState            `protobuf:"bytes,2,opt,name=sim_state,json=simState,proto3" json:"sim_state,omitempty"`
+	// Connections to re-establish once their client and robot have registered
+	// again, ordered by client name
+	Connections          []*BrokerState_Connection `protobuf:"bytes,3,rep,name=connections,proto3" json:"connections,omitempty"`
+	PairingRules         []string                  `protobuf:"bytes,4,rep,name=pairing_rules,json=pairingRules,proto3" json:"pairing_rules,omitempty"`
+	Match                *BrokerState_Match        `protobuf:"bytes,5,opt,name=match,proto3" json:"match,omitempty"`
+	Scores               *Scores                   `protobuf:"bytes,6,opt,name=scores,proto3" json:"scores,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *BrokerState) Reset()         { *m = BrokerState{} }
+func (m *BrokerState) String() string { return proto.CompactTextString(m) }
+func (*BrokerState) ProtoMessage()    {}
+func (*BrokerState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{0}
+}
+
+func (m *BrokerState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokerState.Unmarshal(m, b)
+}
+func (m *BrokerState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokerState.Marshal(b, m, deterministic)
+}
+func (m *BrokerState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokerState.Merge(m, src)
+}
+func (m *BrokerState) XXX_Size() int {
+	return xxx_messageInfo_BrokerState.Size(m)
+}
+func (m *BrokerState) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokerState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokerState proto.InternalMessageInfo
+
+func (m *BrokerState) GetSavedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.SavedAt
+	}
+	return nil
+}
+
+func (m *BrokerState) GetSimState() *SimState {
+	if m != nil {
+		return m.SimState
+	}
+	return nil
+}
+
+func (m *BrokerState) GetConnections() []*BrokerState_Connection {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+func (m *BrokerState) GetPairingRules() []string {
+	if m != nil {
+		return m.PairingRules
+	}
+	return nil
+}
+
+func (m *BrokerState) GetMatch() *BrokerState_Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+func (m *BrokerState) GetScores() *Scores {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type BrokerState_Connection struct {
+	ClientName           string   `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	RobotName            string   `protobuf:"bytes,2,opt,name=robot_name,json=robotName,proto3" json:"robot_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BrokerState_Connection) Reset()         { *m = BrokerState_Connection{} }
+func (m *BrokerState_Connection) String() string { return proto.CompactTextString(m) }
+func (*BrokerState_Connection) ProtoMessage()    {}
+func (*BrokerState_Connection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{0, 0}
+}
+
+func (m *BrokerState_Connection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokerState_Connection.Unmarshal(m, b)
+}
+func (m *BrokerState_Connection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokerState_Connection.Marshal(b, m, deterministic)
+}
+func (m *BrokerState_Connection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokerState_Connection.Merge(m, src)
+}
+func (m *BrokerState_Connection) XXX_Size() int {
+	return xxx_messageInfo_BrokerState_Connection.Size(m)
+}
+func (m *BrokerState_Connection) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokerState_Connection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokerState_Connection proto.InternalMessageInfo
+
+func (m *BrokerState_Connection) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+func (m *BrokerState_Connection) GetRobotName() string {
+	if m != nil {
+		return m.RobotName
+	}
+	return ""
+}
+
+type BrokerState_Match struct {
+	Elapsed              *duration.Duration `protobuf:"bytes,1,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	Expired              bool               `protobuf:"varint,2,opt,name=expired,proto3" json:"expired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BrokerState_Match) Reset()         { *m = BrokerState_Match{} }
+func (m *BrokerState_Match) String() string { return proto.CompactTextString(m) }
+func (*BrokerState_Match) ProtoMessage()    {}
+func (*BrokerState_Match) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{0, 1}
+}
+
+func (m *BrokerState_Match) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokerState_Match.Unmarshal(m, b)
+}
+func (m *BrokerState_Match) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokerState_Match.Marshal(b, m, deterministic)
+}
+func (m *BrokerState_Match) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokerState_Match.Merge(m, src)
+}
+func (m *BrokerState_Match) XXX_Size() int {
+	return xxx_messageInfo_BrokerState_Match.Size(m)
+}
+func (m *BrokerState_Match) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokerState_Match.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokerState_Match proto.InternalMessageInfo
+
+func (m *BrokerState_Match) GetElapsed() *duration.Duration {
+	if m != nil {
+		return m.Elapsed
+	}
+	return nil
+}
+
+func (m *BrokerState_Match) GetExpired() bool {
+	if m != nil {
+		return m.Expired
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*BrokerState)(nil), "erebus.BrokerState")
+	proto.RegisterType((*BrokerState_Connection)(nil), "erebus.BrokerState.Connection")
+	proto.RegisterType((*BrokerState_Match)(nil), "erebus.BrokerState.Match")
+}
+
+func init() { proto.RegisterFile("state.proto", fileDescriptor_a888679467bb7853) }
+
+var fileDescriptor_a888679467bb7853 = []byte{
+	// 352 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0x4d, 0x6f, 0x9b, 0x40,
+	0x10, 0x95, 0x4d, 0x8d, 0xcd, 0xd0, 0x56, 0xd6, 0x9e, 0x30, 0x52, 0x6d, 0xab, 0x95, 0x2a, 0x5f,
+	0x8a, 0x25, 0x5b, 0xbd, 0xf7, 0x23, 0xc7, 0x24, 0x87, 0x75, 0x94, 0x2b, 0x5a, 0x60, 0xe2, 0xac,
+	0x02, 0x2c, 0xda, 0x5d, 0xa2, 0xfc, 0xd5, 0xfc, 0x9b, 0x88, 0x61, 0x71, 0xac, 0x24, 0xb7, 0xe5,
+	0x7d, 0xcc, 0x7b, 0x33, 0x40, 0x68, 0xac, 0xb0, 0x98, 0x34, 0x5a, 0x59, 0xc5, 0x7c, 0xd4, 0x98,
+	0xb5, 0x26, 0x5e, 0x1e, 0x95, 0x3a, 0x96, 0xb8, 0x25, 0x34, 0x6b, 0xef, 0xb6, 0x45, 0xab, 0x85,
+	0x95, 0xaa, 0xee, 0x75, 0xf1, 0xea, 0x2d, 0x6f, 0x65, 0x85, 0xc6, 0x8a, 0xaa, 0x71, 0x82, 0xc0,
+	0xc8, 0xca, 0x3d, 0xe7, 0x26, 0x57, 0x1a, 0x33, 0x25, 0x74, 0xd1, 0x23, 0xdf, 0x9f, 0x3d, 0x08,
+	0xff, 0x69, 0xf5, 0x80, 0xfa, 0xd0, 0x65, 0xb3, 0xdf, 0x30, 0x33, 0xe2, 0x11, 0x8b, 0x54, 0xd8,
+	0x68, 0xb4, 0x1e, 0x6d, 0xc2, 0x5d, 0x9c, 0xf4, 0x01, 0xc9, 0x10, 0x90, 0xdc, 0x0c, 0x01, 0x7c,
+	0x4a, 0xda, 0xbf, 0x96, 0xfd, 0x82, 0x2e, 0x25, 0xa5, 0xfe, 0xd1, 0x98, 0x7c, 0xf3, 0xa4, 0x5f,
+	0x20, 0x39, 0xc8, 0x8a, 0x66, 0xf3, 0x99, 0x71, 0x2f, 0xf6, 0x07, 0xc2, 0x5c, 0xd5, 0x35, 0xe6,
+	0xdd, 0x1e, 0x26, 0xf2, 0xd6, 0xde, 0x26, 0xdc, 0x2d, 0x07, 0xc3, 0x59, 0x9f, 0xe4, 0xff, 0x49,
+	0xc6, 0xcf, 0x2d, 0xec, 0x07, 0x7c, 0x69, 0x84, 0xd4, 0xb2, 0x3e, 0xa6, 0xba, 0x2d, 0xd1, 0x44,
+	0x9f, 0xd6, 0xde, 0x26, 0xe0, 0x9f, 0x1d, 0xc8, 0x3b, 0x8c, 0x6d, 0x61, 0x52, 0x09, 0x9b, 0xdf,
+	0x47, 0x13, 0x6a, 0xb4, 0xf8, 0x28, 0xe0, 0xaa, 0x13, 0xf0, 0x5e, 0xc7, 0x7e, 0x82, 0x4f, 0x17,
+	0x32, 0x91, 0x4f, 0x8e, 0xaf, 0xa7, 0x1d, 0x08, 0xe5, 0x8e, 0x8d, 0x2f, 0x01, 0x5e, 0x8b, 0xb1,
+	0x15, 0x84, 0x79, 0x29, 0xb1, 0xb6, 0x69, 0x2d, 0x2a, 0xa4, 0xb3, 0x05, 0x1c, 0x7a, 0xe8, 0x5a,
+	0x54, 0xc8, 0xbe, 0x01, 0x68, 0x95, 0x29, 0xc7, 0x8f, 0x89, 0x0f, 0x08, 0xe9, 0xe8, 0xf8, 0x16,
+	0x26, 0xd4, 0x82, 0xed, 0x61, 0x8a, 0xa5, 0x68, 0x0c, 0x16, 0xee, 0xf6, 0x8b, 0x77, 0xb7, 0xbf,
+	0x70, 0x3f, 0x9f, 0x0f, 0x4a, 0x16, 0xc1, 0x14, 0x9f, 0x1a, 0xa9, 0xb1, 0xa0, 0xc9, 0x33, 0x3e,
+	0x7c, 0x66, 0x3e, 0xb9, 0xf6, 0x2f, 0x03, 0x00, 0x89, 0xda, 0x50, 0x88, 0x57, 0x02, 0x00, 0x00,
+}
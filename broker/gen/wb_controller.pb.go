@@ -25,11 +25,17 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type WbControllerHandshake struct {
-	RobotName            string     `protobuf:"bytes,1,opt,name=robot_name,json=robotName,proto3" json:"robot_name,omitempty"`
-	RobotInfo            *RobotInfo `protobuf:"bytes,2,opt,name=robot_info,json=robotInfo,proto3" json:"robot_info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	RobotName string     `protobuf:"bytes,1,opt,name=robot_name,json=robotName,proto3" json:"robot_name,omitempty"`
+	RobotInfo *RobotInfo `protobuf:"bytes,2,opt,name=robot_info,json=robotInfo,proto3" json:"robot_info,omitempty"`
+	// Token from a previous session's handshake response, to resume that
+	// session (and the connection it was bound in) after a disconnect
+	ResumeToken string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// Arena the robot is in; may be left empty if the broker runs a single
+	// arena
+	Arena                string   `protobuf:"bytes,4,opt,name=arena,proto3" json:"arena,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *WbControllerHandshake) Reset()         { *m = WbControllerHandshake{} }
@@ -71,6 +77,20 @@ func (m *WbControllerHandshake) GetRobotInfo() *RobotInfo {
 	return nil
 }
 
+func (m *WbControllerHandshake) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *WbControllerHandshake) GetArena() string {
+	if m != nil {
+		return m.Arena
+	}
+	return ""
+}
+
 type WbControllerHandshakeResponse struct {
 	// Types that are valid to be assigned to Data:
 	//	*WbControllerHandshakeResponse_Error
@@ -152,7 +172,11 @@ func (*WbControllerHandshakeResponse) XXX_OneofWrappers() []interface{} {
 }
 
 type WbControllerHandshakeResponse_Ok struct {
-	Timestep             int32    `protobuf:"varint,1,opt,name=timestep,proto3" json:"timestep,omitempty"`
+	Timestep int32 `protobuf:"varint,1,opt,name=timestep,proto3" json:"timestep,omitempty"`
+	// Token to resume this session with if it disconnects, or empty if
+	// the broker doesn't allow resuming sessions
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Resumed              bool     `protobuf:"varint,3,opt,name=resumed,proto3" json:"resumed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -190,6 +214,20 @@ func (m *WbControllerHandshakeResponse_Ok) GetTimestep() int32 {
 	return 0
 }
 
+func (m *WbControllerHandshakeResponse_Ok) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *WbControllerHandshakeResponse_Ok) GetResumed() bool {
+	if m != nil {
+		return m.Resumed
+	}
+	return false
+}
+
 type WbControllerBound struct {
 	IsSync               bool     `protobuf:"varint,1,opt,name=is_sync,json=isSync,proto3" json:"is_sync,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -395,6 +433,8 @@ type WbControllerMessage_ServerMessage struct {
 	//	*WbControllerMessage_ServerMessage_WbControllerBound
 	//	*WbControllerMessage_ServerMessage_WbControllerUnbound
 	//	*WbControllerMessage_ServerMessage_Commands
+	//	*WbControllerMessage_ServerMessage_SensorSamplingPeriods
+	//	*WbControllerMessage_ServerMessage_ServerShuttingDown
 	Message              isWbControllerMessage_ServerMessage_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
 	XXX_unrecognized     []byte                                      `json:"-"`
@@ -454,6 +494,14 @@ type WbControllerMessage_ServerMessage_Commands struct {
 	Commands *Commands `protobuf:"bytes,6,opt,name=commands,proto3,oneof"`
 }
 
+type WbControllerMessage_ServerMessage_SensorSamplingPeriods struct {
+	SensorSamplingPeriods *SensorSamplingPeriods `protobuf:"bytes,7,opt,name=sensor_sampling_periods,json=sensorSamplingPeriods,proto3,oneof"`
+}
+
+type WbControllerMessage_ServerMessage_ServerShuttingDown struct {
+	ServerShuttingDown *ServerShuttingDown `protobuf:"bytes,8,opt,name=server_shutting_down,json=serverShuttingDown,proto3,oneof"`
+}
+
 func (*WbControllerMessage_ServerMessage_WbControllerHandshakeResponse) isWbControllerMessage_ServerMessage_Message() {
 }
 
@@ -470,6 +518,12 @@ func (*WbControllerMessage_ServerMessage_WbControllerUnbound) isWbControllerMess
 
 func (*WbControllerMessage_ServerMessage_Commands) isWbControllerMessage_ServerMessage_Message() {}
 
+func (*WbControllerMessage_ServerMessage_SensorSamplingPeriods) isWbControllerMessage_ServerMessage_Message() {
+}
+
+func (*WbControllerMessage_ServerMessage_ServerShuttingDown) isWbControllerMessage_ServerMessage_Message() {
+}
+
 func (m *WbControllerMessage_ServerMessage) GetMessage() isWbControllerMessage_ServerMessage_Message {
 	if m != nil {
 		return m.Message
@@ -519,6 +573,20 @@ func (m *WbControllerMessage_ServerMessage) GetCommands() *Commands {
 	return nil
 }
 
+func (m *WbControllerMessage_ServerMessage) GetSensorSamplingPeriods() *SensorSamplingPeriods {
+	if x, ok := m.GetMessage().(*WbControllerMessage_ServerMessage_SensorSamplingPeriods); ok {
+		return x.SensorSamplingPeriods
+	}
+	return nil
+}
+
+func (m *WbControllerMessage_ServerMessage) GetServerShuttingDown() *ServerShuttingDown {
+	if x, ok := m.GetMessage().(*WbControllerMessage_ServerMessage_ServerShuttingDown); ok {
+		return x.ServerShuttingDown
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*WbControllerMessage_ServerMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -528,6 +596,8 @@ func (*WbControllerMessage_ServerMessage) XXX_OneofWrappers() []interface{} {
 		(*WbControllerMessage_ServerMessage_WbControllerBound)(nil),
 		(*WbControllerMessage_ServerMessage_WbControllerUnbound)(nil),
 		(*WbControllerMessage_ServerMessage_Commands)(nil),
+		(*WbControllerMessage_ServerMessage_SensorSamplingPeriods)(nil),
+		(*WbControllerMessage_ServerMessage_ServerShuttingDown)(nil),
 	}
 }
 
@@ -545,42 +615,49 @@ func init() {
 func init() { proto.RegisterFile("wb_controller.proto", fileDescriptor_9cf94763f0fd18bb) }
 
 var fileDescriptor_9cf94763f0fd18bb = []byte{
-	// 547 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x51, 0x8b, 0xda, 0x40,
-	0x10, 0xc7, 0x13, 0xef, 0xf4, 0x74, 0x3c, 0xcb, 0xb9, 0x62, 0x2f, 0x4d, 0x11, 0x24, 0x50, 0xb0,
-	0x50, 0xc2, 0x61, 0xa1, 0x0f, 0xa5, 0x4f, 0xda, 0x87, 0x2d, 0xa5, 0xbd, 0x76, 0xa5, 0xdc, 0x63,
-	0xd8, 0xc4, 0x3d, 0x5d, 0x34, 0xbb, 0xb2, 0x1b, 0x2b, 0xf7, 0x61, 0xfa, 0xde, 0x4f, 0xd1, 0xaf,
-	0xd0, 0xaf, 0x54, 0xb2, 0x59, 0xc5, 0xf4, 0x52, 0xe9, 0x5b, 0x66, 0xe6, 0xbf, 0xff, 0xf1, 0xb7,
-	0x33, 0x2e, 0xf4, 0x76, 0x71, 0x94, 0x48, 0x91, 0x29, 0xb9, 0x5e, 0x33, 0x15, 0x6e, 0x94, 0xcc,
-	0x24, 0x6a, 0x30, 0xc5, 0xe2, 0xad, 0xf6, 0x5b, 0x9a, 0xa7, 0x45, 0xca, 0xef, 0x68, 0xa6, 0x35,
-	0x97, 0xa2, 0x08, 0x83, 0x25, 0xf4, 0xef, 0xe2, 0xe9, 0xe1, 0x1c, 0xa6, 0x62, 0xae, 0x97, 0x74,
-	0xc5, 0xd0, 0x00, 0x40, 0xc9, 0x58, 0x66, 0x91, 0xa0, 0x29, 0xf3, 0xdc, 0xa1, 0x3b, 0x6a, 0x91,
-	0x96, 0xc9, 0x7c, 0xa6, 0x29, 0x43, 0x37, 0xfb, 0x32, 0x17, 0xf7, 0xd2, 0xab, 0x0d, 0xdd, 0x51,
-	0x7b, 0xdc, 0x0d, 0x8b, 0x76, 0x21, 0xc9, 0x2b, 0x1f, 0xc4, 0xbd, 0xb4, 0x27, 0xf2, 0xcf, 0xe0,
-	0x87, 0x0b, 0x83, 0xca, 0x56, 0x84, 0xe9, 0x8d, 0x14, 0x9a, 0xa1, 0xa7, 0x50, 0x67, 0x4a, 0x49,
-	0x55, 0x74, 0xc3, 0x0e, 0x29, 0x42, 0xf4, 0x16, 0x6a, 0x72, 0x65, 0x7b, 0x8c, 0xf6, 0x3d, 0x4e,
-	0x5a, 0x85, 0xb7, 0x2b, 0xec, 0x90, 0x9a, 0x5c, 0xf9, 0x43, 0xa8, 0xdd, 0xae, 0x90, 0x0f, 0xcd,
-	0x8c, 0xa7, 0x4c, 0x67, 0x6c, 0x63, 0xcc, 0xeb, 0xe4, 0x10, 0x4f, 0x1a, 0x70, 0x3e, 0xa7, 0x19,
-	0x0d, 0x5e, 0x41, 0xf7, 0xd8, 0x73, 0x22, 0xb7, 0x62, 0x8e, 0xae, 0xe1, 0x82, 0xeb, 0x48, 0x3f,
-	0x88, 0xc4, 0x9c, 0x6b, 0x92, 0x06, 0xd7, 0xb3, 0x07, 0x91, 0x04, 0x7d, 0xe8, 0x1d, 0xab, 0xbf,
-	0x89, 0x38, 0xd7, 0x07, 0x3f, 0xeb, 0xe5, 0xfc, 0x27, 0xa6, 0x35, 0x5d, 0x30, 0xff, 0xb7, 0x0b,
-	0x9d, 0xe9, 0x9a, 0x33, 0x91, 0xd9, 0x0c, 0xba, 0x83, 0xeb, 0xd2, 0xc4, 0xa2, 0xe5, 0x1e, 0xc2,
-	0x74, 0x6a, 0x8f, 0x07, 0x27, 0x49, 0xb1, 0x43, 0xfa, 0xbb, 0xca, 0xc1, 0x05, 0x70, 0xbe, 0x91,
-	0x62, 0x61, 0xef, 0xeb, 0x72, 0xef, 0xf2, 0x45, 0x8a, 0x05, 0x76, 0x88, 0xa9, 0xa1, 0x37, 0xd0,
-	0xd6, 0x4c, 0x68, 0xa9, 0xa2, 0x1c, 0xdd, 0x3b, 0x33, 0xd2, 0xde, 0x5e, 0x3a, 0x33, 0x25, 0xfd,
-	0x9e, 0x66, 0x14, 0x3b, 0x04, 0x0a, 0x65, 0x1e, 0x4d, 0x5a, 0x70, 0x91, 0x5a, 0xa2, 0x5f, 0x67,
-	0xd0, 0x99, 0x31, 0xf5, 0xfd, 0xc0, 0x88, 0x36, 0x30, 0xfc, 0x07, 0x51, 0xa4, 0xec, 0x5c, 0x2c,
-	0xda, 0x8b, 0xff, 0x1a, 0x22, 0x76, 0xc8, 0x60, 0x77, 0x72, 0x61, 0x72, 0x54, 0x5e, 0x81, 0xca,
-	0x2d, 0x2a, 0x17, 0x0b, 0xf4, 0x0e, 0xae, 0x34, 0x4f, 0x23, 0x9d, 0xd1, 0x8c, 0x45, 0xc9, 0x92,
-	0x8a, 0x05, 0xb3, 0xbc, 0x57, 0x07, 0x5e, 0x9e, 0xce, 0xf2, 0x32, 0x76, 0xc8, 0x13, 0x6d, 0xbf,
-	0xa7, 0x46, 0x89, 0x3e, 0xfe, 0xf5, 0xbf, 0x8a, 0xcc, 0x98, 0xbd, 0x73, 0x63, 0xf0, 0xac, 0x0a,
-	0xc3, 0xec, 0x0d, 0x76, 0x48, 0x77, 0xf7, 0x68, 0x99, 0xbe, 0x42, 0xbf, 0x6c, 0xb6, 0x2d, 0xb6,
-	0xc6, 0xab, 0x1b, 0xbb, 0xe7, 0x55, 0x76, 0x76, 0xb1, 0xb0, 0x43, 0x7a, 0xbb, 0xc7, 0x69, 0x14,
-	0x42, 0x33, 0x91, 0x69, 0x9a, 0xdf, 0x8c, 0xd7, 0x28, 0x53, 0x4d, 0x6d, 0x1e, 0x3b, 0xe4, 0xa0,
-	0x39, 0x1a, 0xe0, 0x58, 0xc3, 0xe5, 0x71, 0x23, 0x94, 0xc0, 0xc5, 0xac, 0x78, 0x1a, 0xd0, 0xcb,
-	0xaa, 0x5f, 0x62, 0xc7, 0x1c, 0x96, 0xd6, 0xd8, 0x3f, 0x29, 0x2d, 0xed, 0xc7, 0xc8, 0xbd, 0x71,
-	0xe3, 0x86, 0x79, 0x75, 0x5e, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x1b, 0xd4, 0x52, 0xa2, 0xae,
-	0x04, 0x00, 0x00,
+	// 663 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x6b, 0xdb, 0x4a,
+	0x14, 0x95, 0xfc, 0xfc, 0x79, 0x9d, 0x3c, 0x92, 0x71, 0xfc, 0xa2, 0xe7, 0x87, 0x21, 0xcf, 0x50,
+	0x70, 0xa1, 0x98, 0xe0, 0x42, 0x17, 0xa5, 0xab, 0x38, 0x0b, 0x95, 0xd2, 0x24, 0x1d, 0xb7, 0x64,
+	0x55, 0xc4, 0xd8, 0x9e, 0xd8, 0x83, 0xad, 0x19, 0x31, 0x57, 0xae, 0xc8, 0xcf, 0xe9, 0x2f, 0x2a,
+	0xdd, 0xf6, 0x0f, 0xf4, 0x6f, 0x14, 0x8d, 0xc6, 0xae, 0x55, 0xab, 0xa6, 0x3b, 0xdd, 0x73, 0x8f,
+	0x8e, 0xee, 0xb9, 0x73, 0x46, 0xd0, 0x4a, 0x26, 0xc1, 0x54, 0xc9, 0x58, 0xab, 0xd5, 0x8a, 0xeb,
+	0x41, 0xa4, 0x55, 0xac, 0x48, 0x95, 0x6b, 0x3e, 0x59, 0x63, 0xa7, 0x81, 0x22, 0xcc, 0xa0, 0xce,
+	0x31, 0x72, 0x44, 0xa1, 0x64, 0x56, 0xf6, 0x3e, 0xbb, 0xd0, 0xbe, 0x9f, 0x8c, 0xb6, 0x2f, 0xfa,
+	0x4c, 0xce, 0x70, 0xc1, 0x96, 0x9c, 0x74, 0x01, 0xb4, 0x9a, 0xa8, 0x38, 0x90, 0x2c, 0xe4, 0x9e,
+	0x7b, 0xe1, 0xf6, 0x1b, 0xb4, 0x61, 0x90, 0x1b, 0x16, 0x72, 0x72, 0xb9, 0x69, 0x0b, 0xf9, 0xa0,
+	0xbc, 0xd2, 0x85, 0xdb, 0x6f, 0x0e, 0x4f, 0x07, 0xd9, 0xf7, 0x06, 0x34, 0xed, 0xbc, 0x96, 0x0f,
+	0xca, 0xbe, 0x91, 0x3e, 0x92, 0xff, 0xe1, 0x48, 0x73, 0x5c, 0x87, 0x3c, 0x88, 0xd5, 0x92, 0x4b,
+	0xef, 0x2f, 0x23, 0xd9, 0xcc, 0xb0, 0xf7, 0x29, 0x44, 0xce, 0xa0, 0xc2, 0x34, 0x97, 0xcc, 0x2b,
+	0x9b, 0x5e, 0x56, 0xf4, 0xbe, 0xb9, 0xd0, 0x2d, 0x9c, 0x91, 0x72, 0x8c, 0x94, 0x44, 0x4e, 0xfe,
+	0x81, 0x0a, 0xd7, 0x5a, 0xe9, 0x6c, 0x4c, 0xdf, 0xa1, 0x59, 0x49, 0x5e, 0x42, 0x49, 0x2d, 0xed,
+	0x70, 0xfd, 0xcd, 0x70, 0x07, 0xa5, 0x06, 0xb7, 0x4b, 0xdf, 0xa1, 0x25, 0xb5, 0xec, 0x7c, 0x84,
+	0xd2, 0xed, 0x92, 0x74, 0xa0, 0x1e, 0x8b, 0x90, 0x63, 0xcc, 0x23, 0x23, 0x5e, 0xa1, 0xdb, 0x7a,
+	0xcf, 0x50, 0x69, 0xdf, 0x90, 0x07, 0xb5, 0xac, 0x9c, 0x19, 0xbb, 0x75, 0xba, 0x29, 0xaf, 0xaa,
+	0x50, 0x9e, 0xb1, 0x98, 0xf5, 0x9e, 0xc1, 0xe9, 0xee, 0x40, 0x57, 0x6a, 0x2d, 0x67, 0xe4, 0x1c,
+	0x6a, 0x02, 0x03, 0x7c, 0x94, 0x53, 0xf3, 0xd1, 0x3a, 0xad, 0x0a, 0x1c, 0x3f, 0xca, 0x69, 0xaf,
+	0x0d, 0xad, 0x5d, 0xf6, 0x07, 0x39, 0x49, 0xf9, 0xbd, 0xaf, 0xd5, 0x3c, 0xfe, 0x96, 0x23, 0xb2,
+	0x39, 0xef, 0x7c, 0x71, 0xe1, 0x78, 0xb4, 0x12, 0x5c, 0xc6, 0x16, 0x21, 0xf7, 0x70, 0x9e, 0x0b,
+	0x4a, 0xb0, 0xd8, 0x6c, 0xc0, 0x7c, 0xa9, 0x39, 0xec, 0x1e, 0x5c, 0x93, 0xef, 0xd0, 0x76, 0x52,
+	0x18, 0x97, 0x1e, 0x94, 0x23, 0x25, 0xe7, 0x76, 0xd9, 0x47, 0x1b, 0x95, 0x3b, 0x25, 0xe7, 0xbe,
+	0x43, 0x4d, 0x8f, 0xbc, 0x80, 0x26, 0x72, 0x89, 0x4a, 0x07, 0xa9, 0x75, 0xb3, 0x91, 0xe6, 0xb0,
+	0xb5, 0xa1, 0x8e, 0x4d, 0x0b, 0xaf, 0x59, 0xcc, 0x7c, 0x87, 0x42, 0xc6, 0x4c, 0xab, 0xab, 0x06,
+	0xd4, 0x42, 0xeb, 0xe8, 0x7b, 0x19, 0x8e, 0xc7, 0x5c, 0x7f, 0xda, 0x7a, 0x24, 0x11, 0x5c, 0xfc,
+	0xc6, 0x51, 0xa0, 0xed, 0xa1, 0x5a, 0x6b, 0x4f, 0xfe, 0x28, 0x01, 0xbe, 0x43, 0xbb, 0xc9, 0xc1,
+	0xb4, 0xa5, 0x56, 0x45, 0x81, 0x55, 0x61, 0xad, 0x0a, 0x39, 0x27, 0xaf, 0xe0, 0x04, 0x45, 0x18,
+	0x60, 0xcc, 0x62, 0x1e, 0x4c, 0x17, 0x4c, 0xce, 0xb9, 0xf5, 0x7b, 0xb2, 0xf5, 0x2b, 0xc2, 0x71,
+	0xda, 0xf6, 0x1d, 0xfa, 0x37, 0xda, 0xe7, 0x91, 0x61, 0x92, 0x37, 0xbf, 0x5c, 0xe7, 0xc0, 0x1c,
+	0xb3, 0xb9, 0x15, 0xcd, 0xe1, 0xbf, 0x45, 0x36, 0x4c, 0x6e, 0x7c, 0x87, 0x9e, 0x26, 0x7b, 0x61,
+	0x7a, 0x07, 0xed, 0xbc, 0xd8, 0x3a, 0x4b, 0x8d, 0x57, 0x31, 0x72, 0xff, 0x15, 0xc9, 0xd9, 0x60,
+	0xf9, 0x0e, 0x6d, 0x25, 0xfb, 0x30, 0x19, 0x40, 0x7d, 0xaa, 0xc2, 0x30, 0xdd, 0x8c, 0x57, 0xcd,
+	0xbb, 0x1a, 0x59, 0xdc, 0x77, 0xe8, 0x96, 0x93, 0xa6, 0xce, 0x1e, 0x3c, 0xb2, 0x30, 0x5a, 0x09,
+	0x39, 0x0f, 0x22, 0xae, 0x85, 0x9a, 0xa1, 0x57, 0xcb, 0xa7, 0x2e, 0x0b, 0xc1, 0xd8, 0xb2, 0xee,
+	0x32, 0x52, 0x9a, 0x3a, 0x2c, 0x6a, 0x90, 0x1b, 0x38, 0x43, 0x93, 0x86, 0x00, 0x17, 0xeb, 0x38,
+	0x4e, 0x85, 0x67, 0x2a, 0x91, 0x5e, 0xdd, 0xa8, 0x76, 0x7e, 0xaa, 0xa6, 0x9c, 0xb1, 0xa5, 0x5c,
+	0xab, 0x44, 0xfa, 0x0e, 0x25, 0xb8, 0x87, 0xee, 0x24, 0x6d, 0x88, 0x70, 0xb4, 0xbb, 0x11, 0x32,
+	0x85, 0xda, 0x38, 0xfb, 0x75, 0x92, 0xa7, 0x45, 0x2b, 0xb3, 0x79, 0x1c, 0xe4, 0xee, 0x5b, 0xe7,
+	0x20, 0x35, 0x17, 0xe4, 0xbe, 0x7b, 0xe9, 0x4e, 0xaa, 0xe6, 0xaf, 0xfc, 0xfc, 0xc7, 0x00, 0x82,
+	0xd8, 0xd4, 0x79, 0xce, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// heartbeat tracks the Ping / Pong exchange with the peer of a session. It is
+// not safe for concurrent use, and is meant to be driven from a session loop.
+type heartbeat struct {
+	interval  time.Duration
+	maxMissed int
+	logger    *logrus.Entry
+
+	ticker *time.Ticker
+	nonce  int32
+	// Send times of pings which have not been answered yet
+	sent   map[int32]time.Time
+	missed int
+	rtt    time.Duration
+
+	// Observes the round trip time of each answered ping, if set
+	rttObserver prometheus.Observer
+}
+
+// newHeartbeat creates a heartbeat which pings every interval, and gives up
+// on the peer once maxMissed pings in a row have gone unanswered. An interval
+// of 0 disables the heartbeat.
+func newHeartbeat(interval time.Duration, maxMissed int, logger *logrus.Entry) *heartbeat {
+	h := &heartbeat{
+		interval:  interval,
+		maxMissed: maxMissed,
+		logger:    logger,
+		sent:      make(map[int32]time.Time),
+	}
+	if interval > 0 {
+		h.ticker = time.NewTicker(interval)
+	}
+	return h
+}
+
+// C returns the channel which receives a value each time a ping is due, or
+// nil if the heartbeat is disabled
+func (h *heartbeat) C() <-chan time.Time {
+	if h.ticker == nil {
+		return nil
+	}
+	return h.ticker.C
+}
+
+// stop releases the heartbeat's ticker
+func (h *heartbeat) stop() {
+	if h.ticker != nil {
+		h.ticker.Stop()
+	}
+}
+
+// tick counts a missed heartbeat if the previous ping is still unanswered,
+// and returns the next ping to send. It returns false if the peer has missed
+// too many heartbeats, and should be evicted.
+func (h *heartbeat) tick() (*pb.Ping, bool) {
+	if len(h.sent) > 0 {
+		h.missed++
+		h.logger.WithField("missed", h.missed).Debug("Peer missed heartbeat")
+		if h.missed >= h.maxMissed {
+			return nil, false
+		}
+	}
+	h.nonce++
+	h.sent[h.nonce] = time.Now()
+	return &pb.Ping{Nonce: h.nonce}, true
+}
+
+// pong handles the peer's answer to a ping. Answering a ping also answers all
+// the pings sent before it.
+func (h *heartbeat) pong(pong *pb.Pong) {
+	sentAt, ok := h.sent[pong.GetNonce()]
+	if !ok {
+		h.logger.WithField("nonce", pong.GetNonce()).Debug("Ignoring pong for unknown ping")
+		return
+	}
+	for nonce := range h.sent {
+		if nonce <= pong.GetNonce() {
+			delete(h.sent, nonce)
+		}
+	}
+	h.missed = 0
+	h.rtt = time.Since(sentAt)
+	if h.rttObserver != nil {
+		h.rttObserver.Observe(h.rtt.Seconds())
+	}
+	h.logger.WithField("rtt", h.rtt).Debug("Heartbeat")
+}
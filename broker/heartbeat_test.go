@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type HeartbeatSuite struct {
+	suite.Suite
+	hb *heartbeat
+}
+
+func (suite *HeartbeatSuite) SetupTest() {
+	suite.hb = newHeartbeat(0, 3, logrus.NewEntry(logrus.New()))
+}
+
+func (suite *HeartbeatSuite) TearDownTest() {
+	suite.hb.stop()
+}
+
+func (suite *HeartbeatSuite) TestDisabled() {
+	suite.Nil(suite.hb.C())
+}
+
+func (suite *HeartbeatSuite) TestAnsweredPings() {
+	for i := 0; i < 5; i++ {
+		ping, ok := suite.hb.tick()
+		suite.Require().True(ok)
+		suite.hb.pong(&pb.Pong{Nonce: ping.GetNonce()})
+	}
+	suite.Zero(suite.hb.missed)
+	suite.Empty(suite.hb.sent)
+}
+
+func (suite *HeartbeatSuite) TestNoncesDiffer() {
+	first, _ := suite.hb.tick()
+	second, _ := suite.hb.tick()
+	suite.NotEqual(first.GetNonce(), second.GetNonce())
+}
+
+func (suite *HeartbeatSuite) TestEviction() {
+	for i := 0; i < 3; i++ {
+		_, ok := suite.hb.tick()
+		suite.Require().True(ok)
+	}
+	_, ok := suite.hb.tick()
+	suite.False(ok)
+}
+
+func (suite *HeartbeatSuite) TestLatePongResetsMisses() {
+	first, _ := suite.hb.tick()
+	suite.hb.tick()
+	suite.hb.tick()
+	suite.Equal(2, suite.hb.missed)
+	suite.hb.pong(&pb.Pong{Nonce: first.GetNonce()})
+	suite.Zero(suite.hb.missed)
+	// Pings sent after the answered one are still outstanding
+	suite.Len(suite.hb.sent, 2)
+	_, ok := suite.hb.tick()
+	suite.True(ok)
+}
+
+func (suite *HeartbeatSuite) TestUnknownPong() {
+	suite.hb.tick()
+	suite.hb.pong(&pb.Pong{Nonce: 1234})
+	suite.Len(suite.hb.sent, 1)
+}
+
+// A peer evicted for missing heartbeats is unregistered straight away, rather
+// than left to resume its session
+func (suite *HeartbeatSuite) TestEvictionEndsSessionForGood() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := NewBroker(ctx, SimInfo{
+		timestep:          32,
+		heartbeatInterval: 10 * time.Millisecond,
+		heartbeatMisses:   2,
+		resumeGrace:       time.Minute,
+	})
+	server := grpc.NewServer()
+	defer server.Stop()
+	pb.RegisterWbControllerServer(server, NewWbControllerServer(broker))
+	pb.RegisterClientControllerServer(server, NewClientControllerServer(broker, nil))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	go server.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	suite.Require().NoError(err)
+	defer conn.Close()
+
+	// Neither session answers its pings
+	robot, err := pb.NewWbControllerClient(conn).Session(ctx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(robot.Send(&pb.WbControllerMessage_ClientMessage{Message: &pb.WbControllerMessage_ClientMessage_WbControllerHandshake{
+		WbControllerHandshake: &pb.WbControllerHandshake{RobotName: "robot"},
+	}}))
+	client, err := pb.NewClientControllerClient(conn).Session(ctx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(client.Send(&pb.ClientControllerMessage_ControllerMessage{Message: &pb.ClientControllerMessage_ControllerMessage_ClientControllerHandshake{
+		ClientControllerHandshake: &pb.ClientControllerHandshake{ClientName: "client"},
+	}}))
+	for {
+		if _, err := robot.Recv(); err != nil {
+			break
+		}
+	}
+	for {
+		if _, err := client.Recv(); err != nil {
+			break
+		}
+	}
+	time.Sleep(closeTimeout)
+	suite.Empty(broker.GetRobotNames())
+	suite.Empty(broker.GetClientNames())
+}
+
+func TestHeartbeatSuite(t *testing.T) {
+	suite.Run(t, new(HeartbeatSuite))
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// Interceptor sits between a robot and its client on a bound connection, and
+// can observe, mutate, delay or drop the messages passing between them.
+//
+// Each method is called with the connection's context, which is done once the
+// connection is unbound, and returns the message to pass on, or nil to drop
+// it. Methods may block to delay a message, but must return promptly once the
+// context is done.
+type Interceptor interface {
+	// InterceptSensorsData is called for each sensor frame sent by the robot
+	InterceptSensorsData(ctx context.Context, sd *pb.SensorsData) *pb.SensorsData
+	// InterceptCommands is called for each commands message sent by the client
+	InterceptCommands(ctx context.Context, cmd *pb.Commands) *pb.Commands
+}
+
+// PolicyObserver may also be implemented by an Interceptor to see the commands
+// a sync connection's policy sends to the robot in place of the client's reply.
+// These don't pass through InterceptCommands, so interceptors comparing the
+// client's answers aren't misled by them.
+type PolicyObserver interface {
+	ObservePolicyCommands(ctx context.Context, cmd *pb.Commands)
+}
+
+// InterceptorFactory creates the interceptor to insert into a new connection,
+// or returns nil to leave the connection alone. ctx is done once the connection
+// is unbound.
+type InterceptorFactory func(ctx context.Context, info ConnectionInfo) Interceptor
+
+// InterceptorFuncs is an Interceptor and PolicyObserver built from functions; a
+// nil function passes messages through unchanged
+type InterceptorFuncs struct {
+	SensorsData    func(ctx context.Context, sd *pb.SensorsData) *pb.SensorsData
+	Commands       func(ctx context.Context, cmd *pb.Commands) *pb.Commands
+	PolicyCommands func(ctx context.Context, cmd *pb.Commands)
+}
+
+func (f InterceptorFuncs) InterceptSensorsData(ctx context.Context, sd *pb.SensorsData) *pb.SensorsData {
+	if f.SensorsData == nil {
+		return sd
+	}
+	return f.SensorsData(ctx, sd)
+}
+
+func (f InterceptorFuncs) InterceptCommands(ctx context.Context, cmd *pb.Commands) *pb.Commands {
+	if f.Commands == nil {
+		return cmd
+	}
+	return f.Commands(ctx, cmd)
+}
+
+func (f InterceptorFuncs) ObservePolicyCommands(ctx context.Context, cmd *pb.Commands) {
+	if f.PolicyCommands != nil {
+		f.PolicyCommands(ctx, cmd)
+	}
+}
+
+type namedInterceptorFactory struct {
+	name    string
+	factory InterceptorFactory
+}
+
+// interceptorChain is the ordered set of interceptors on a connection. Sensor
+// data passes through it in order from the robot, and commands pass through it
+// in reverse order from the client, so that the first interceptor is always
+// the one closest to the robot.
+type interceptorChain []Interceptor
+
+func (c interceptorChain) sensorsData(ctx context.Context, sd *pb.SensorsData) *pb.SensorsData {
+	for _, interceptor := range c {
+		if sd = interceptor.InterceptSensorsData(ctx, sd); sd == nil {
+			return nil
+		}
+	}
+	return sd
+}
+
+func (c interceptorChain) commands(ctx context.Context, cmd *pb.Commands) *pb.Commands {
+	for i := len(c) - 1; i >= 0; i-- {
+		if cmd = c[i].InterceptCommands(ctx, cmd); cmd == nil {
+			return nil
+		}
+	}
+	return cmd
+}
+
+// policyCommands shows the commands sent by a sync policy to the interceptors
+// which observe them, in the same order as commands
+func (c interceptorChain) policyCommands(ctx context.Context, cmd *pb.Commands) {
+	for i := len(c) - 1; i >= 0; i-- {
+		if observer, ok := c[i].(PolicyObserver); ok {
+			observer.ObservePolicyCommands(ctx, cmd)
+		}
+	}
+}
+
+// AddInterceptor registers an interceptor factory under the given name. The
+// factory is called for every connection bound after it is added, and its
+// interceptors are chained in the order they were added.
+func (b *Broker) AddInterceptor(name string, factory InterceptorFactory) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, entry := range b.interceptors {
+		if entry.name == name {
+			return errors.New("Interceptor already added")
+		}
+	}
+	b.interceptors = append(b.interceptors, namedInterceptorFactory{name: name, factory: factory})
+	return nil
+}
+
+// RemoveInterceptor unregisters the interceptor factory with the given name.
+// Connections which are already bound keep their interceptors.
+func (b *Broker) RemoveInterceptor(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, entry := range b.interceptors {
+		if entry.name == name {
+			b.interceptors = append(b.interceptors[:i], b.interceptors[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("Interceptor not found")
+}
+
+// buildInterceptorChain creates the interceptor chain for a new connection.
+// Factories are called without b.mu held, so they are free to use the broker.
+func (b *Broker) buildInterceptorChain(ctx context.Context, info ConnectionInfo) interceptorChain {
+	b.mu.RLock()
+	factories := append([]namedInterceptorFactory(nil), b.interceptors...)
+	b.mu.RUnlock()
+	var chain interceptorChain
+	for _, entry := range factories {
+		if interceptor := entry.factory(ctx, info); interceptor != nil {
+			chain = append(chain, interceptor)
+		}
+	}
+	return chain
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type InterceptorSuite struct {
+	suite.Suite
+	ctx      context.Context
+	ctxClose context.CancelFunc
+}
+
+func (suite *InterceptorSuite) SetupTest() {
+	suite.ctx, suite.ctxClose = context.WithCancel(context.Background())
+}
+
+func (suite *InterceptorSuite) TearDownTest() {
+	suite.ctxClose()
+}
+
+// tagInterceptor appends its tag to the timestamp of sensor data and to the
+// name of the first command, so the order interceptors are applied in can be
+// observed
+func tagInterceptor(tag float64, name string) Interceptor {
+	return InterceptorFuncs{
+		SensorsData: func(_ context.Context, sd *pb.SensorsData) *pb.SensorsData {
+			return &pb.SensorsData{Timestamp: sd.GetTimestamp()*10 + tag}
+		},
+		Commands: func(_ context.Context, cmd *pb.Commands) *pb.Commands {
+			return &pb.Commands{Commands: []*pb.Command{{Name: cmd.GetCommands()[0].GetName() + name}}}
+		},
+	}
+}
+
+func (suite *InterceptorSuite) TestChainOrder() {
+	chain := interceptorChain{tagInterceptor(1, "a"), tagInterceptor(2, "b")}
+	suite.Equal(12.0, chain.sensorsData(suite.ctx, &pb.SensorsData{}).GetTimestamp())
+	cmd := &pb.Commands{Commands: []*pb.Command{{Name: ""}}}
+	suite.Equal("ba", chain.commands(suite.ctx, cmd).GetCommands()[0].GetName())
+}
+
+func (suite *InterceptorSuite) TestChainDrop() {
+	called := false
+	chain := interceptorChain{
+		InterceptorFuncs{
+			SensorsData: func(context.Context, *pb.SensorsData) *pb.SensorsData { return nil },
+		},
+		InterceptorFuncs{
+			SensorsData: func(_ context.Context, sd *pb.SensorsData) *pb.SensorsData {
+				called = true
+				return sd
+			},
+		},
+	}
+	suite.Nil(chain.sensorsData(suite.ctx, &pb.SensorsData{}))
+	suite.False(called, "Interceptor after a drop was called")
+}
+
+func (suite *InterceptorSuite) TestAsyncRelay() {
+	robotSd := make(chan *pb.SensorsData)
+	clientSd := make(chan *pb.SensorsData)
+	clientCmd := make(chan *pb.Commands)
+	robotCmd := make(chan *pb.Commands)
+	r := &relay{
+		ctx:    suite.ctx,
+		logger: log.WithField("test", suite.T().Name()),
+		chain:  interceptorChain{tagInterceptor(1, "a")},
+		sdIn:   robotSd,
+		sdOut:  clientSd,
+		cmdIn:  clientCmd,
+		cmdOut: robotCmd,
+	}
+	go r.runAsync()
+	robotSd <- &pb.SensorsData{Timestamp: 1}
+	suite.Equal(11.0, (<-clientSd).GetTimestamp())
+	clientCmd <- &pb.Commands{Commands: []*pb.Command{{Name: "cmd"}}}
+	suite.Equal("cmda", (<-robotCmd).GetCommands()[0].GetName())
+}
+
+func (suite *InterceptorSuite) TestRegistry() {
+	globalCtx, globalCtxClose := context.WithCancel(context.Background())
+	defer globalCtxClose()
+	broker := NewBroker(globalCtx, SimInfo{timestep: 32})
+	var infos []ConnectionInfo
+	suite.Require().NoError(broker.AddInterceptor("test", func(_ context.Context, info ConnectionInfo) Interceptor {
+		infos = append(infos, info)
+		return nil
+	}))
+	suite.Error(broker.AddInterceptor("test", func(context.Context, ConnectionInfo) Interceptor { return nil }))
+	robot := broker.RegisterRobot("robot", globalCtx, nil)
+	client := broker.RegisterClient("client", globalCtx, false)
+	go func() { <-robot.GetConnection() }()
+	go func() { <-client.GetConnection() }()
+	suite.Require().NoError(broker.ConnectClientToRobot("client", "robot"))
+	suite.Require().Len(infos, 1)
+	suite.Equal("robot", infos[0].RobotName)
+	suite.Equal("client", infos[0].ClientName)
+	suite.NoError(broker.RemoveInterceptor("test"))
+	suite.Error(broker.RemoveInterceptor("test"))
+}
+
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorSuite))
+}
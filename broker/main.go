@@ -5,51 +5,450 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	pb "github.com/ethanwu10/erebus/broker/gen"
 )
 
 var log *logrus.Logger
 
-func run(port int) {
-	netAddr := fmt.Sprintf(":%d", port)
-	lis, err := net.Listen("tcp", netAddr)
-	if err != nil {
-		log.Fatalf("Failed to listen on %s", netAddr)
+// options holds the broker's command line options
+type options struct {
+	listen   []string
+	timestep int
+	// Arenas to run, each with a broker of its own
+	arenas      []ArenaSpec
+	syncTimeout time.Duration
+	syncPolicy  SyncPolicy
+	record      bool
+	recordDir   string
+
+	heartbeatInterval time.Duration
+	heartbeatMisses   int
+	resumeGrace       time.Duration
+
+	// Team tokens clients must present, if any
+	tokensFile string
+	// Token required for the Control service, if any
+	adminToken string
+
+	// Serve over TLS if tlsCert is set, requiring client certificates signed
+	// by tlsClientCA if it is set
+	tlsCert     string
+	tlsKey      string
+	tlsClientCA string
+
+	// Address to serve Prometheus metrics on, if any
+	metricsAddr string
+	// Address to serve the web dashboard on, if any
+	dashboardAddr string
+	// Address to serve the REST gateway to the Control service on, if any
+	gatewayAddr string
+
+	// Rules binding clients to robots automatically
+	pairingRules []PairingRule
+
+	// Time the simulation may run for before it is stopped, or 0 for no limit
+	matchDuration time.Duration
+
+	// Time sessions and streams have to end in once the broker is shutting
+	// down, before they are cut off
+	shutdownTimeout time.Duration
+
+	// File to save the broker's state to and restore it from, if any
+	stateFile string
+
+	// Recording to replay from startup, if any
+	replayPath  string
+	replayName  string
+	replayArena string
+	replay      ReplayOptions
+}
+
+func run(opts options) {
+	listeners := make([]net.Listener, len(opts.listen))
+	for i, addr := range opts.listen {
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			log.Fatalf("Failed to listen on %s", addr)
+		}
+		log.Printf("Listening on %s", addr)
+		listeners[i] = lis
 	}
-	log.Printf("Listening on %s", netAddr)
 	logrusEntry := logrus.NewEntry(log)
 	// Shared options for the logger, with a custom gRPC code to log level function.
-	opts := []grpc_logrus.Option{}
+	logOpts := []grpc_logrus.Option{}
 	grpc_logrus.ReplaceGrpcLogger(logrusEntry)
-	server := grpc.NewServer(
+	admin := adminAuth{token: opts.adminToken}
+	if opts.adminToken == "" {
+		log.Warn("No admin token set, the Control service is open to anyone")
+	}
+	serverOpts := []grpc.ServerOption{}
+	if opts.tlsCert != "" {
+		tlsConfig, err := loadServerTLS(opts.tlsCert, opts.tlsKey, opts.tlsClientCA)
+		if err != nil {
+			log.Fatalf("Failed to load TLS configuration: %s", err.Error())
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else {
+		log.Warn("TLS disabled, all traffic is unencrypted")
+	}
+	server := grpc.NewServer(append(serverOpts,
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_logrus.UnaryServerInterceptor(logrusEntry, opts...),
+			grpc_logrus.UnaryServerInterceptor(logrusEntry, logOpts...),
+			admin.UnaryServerInterceptor(),
 		)),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_logrus.StreamServerInterceptor(logrusEntry, opts...),
+			grpc_logrus.StreamServerInterceptor(logrusEntry, logOpts...),
+			admin.StreamServerInterceptor(),
 		)),
-	)
-	broker := NewBroker(context.Background(), SimInfo{
-		timestep: 32,
-	})
-	pb.RegisterWbControllerServer(server, NewWbControllerServer(broker))
-	pb.RegisterClientControllerServer(server, NewClientControllerServer(broker))
-	pb.RegisterControlServer(server, NewControlServer(broker))
-	server.Serve(lis)
+	)...)
+	var tokens *TokenStore
+	if opts.tokensFile != "" {
+		var err error
+		if tokens, err = LoadTokens(opts.tokensFile); err != nil {
+			log.Fatalf("Failed to load tokens: %s", err.Error())
+		}
+	} else {
+		log.Warn("No tokens file set, clients may connect under any name")
+	}
+	registry := newMetricsRegistry()
+	arenaList := make([]*Arena, len(opts.arenas))
+	for i, spec := range opts.arenas {
+		arenaList[i] = setUpArena(opts, spec, registry, tokens)
+	}
+	arenas, err := NewArenas(arenaList...)
+	if err != nil {
+		log.Fatalf("Failed to set up arenas: %s", err.Error())
+	}
+	if opts.replayPath != "" {
+		arena, err := arenas.Select(opts.replayArena)
+		if err != nil {
+			log.Fatalf("Failed to start replay: %s", err.Error())
+		}
+		if _, err := arena.replayer.Start(opts.replayName, opts.replayPath, opts.replay); err != nil {
+			log.Fatalf("Failed to start replay: %s", err.Error())
+		}
+	}
+	pb.RegisterWbControllerServer(server, NewArenaWbControllerServer(arenas))
+	pb.RegisterClientControllerServer(server, NewArenaClientControllerServer(arenas))
+	control := NewArenaControlServer(arenas)
+	pb.RegisterControlServer(server, control)
+	pb.RegisterScoreboardServer(server, NewArenaScoreboardServer(arenas))
+	if opts.metricsAddr != "" {
+		go serveMetrics(opts.metricsAddr, arenas.Metrics())
+	}
+	if opts.dashboardAddr != "" || opts.gatewayAddr != "" {
+		gateway, err := NewGateway(control, opts.adminToken)
+		if err != nil {
+			log.Fatalf("Failed to set up gateway: %s", err.Error())
+		}
+		if opts.dashboardAddr != "" {
+			go serveHTTP(opts, "dashboard", opts.dashboardAddr, NewDashboard(gateway))
+		}
+		if opts.gatewayAddr != "" {
+			go serveHTTP(opts, "gateway", opts.gatewayAddr, gateway)
+		}
+	}
+	for _, lis := range listeners {
+		go func(lis net.Listener) {
+			if err := server.Serve(lis); err != nil {
+				log.Fatalf("Failed to serve on %s: %s", lis.Addr(), err.Error())
+			}
+		}(lis)
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	select {
+	case sig := <-signals:
+		arenas.Shutdown(fmt.Sprintf("Broker received %s", sig))
+	case <-arenas.ShuttingDown():
+	}
+	shutDown(server, arenas, signals, opts.shutdownTimeout)
+}
+
+// setUpArena creates the broker of an arena, and everything operating it
+func setUpArena(opts options, spec ArenaSpec, registry *prometheus.Registry, tokens *TokenStore) *Arena {
+	timestep := opts.timestep
+	if spec.Timestep != 0 {
+		timestep = spec.Timestep
+	}
+	broker := newBroker(context.Background(), SimInfo{
+		timestep:          timestep,
+		syncTimeout:       opts.syncTimeout,
+		syncPolicy:        opts.syncPolicy,
+		heartbeatInterval: opts.heartbeatInterval,
+		heartbeatMisses:   opts.heartbeatMisses,
+		resumeGrace:       opts.resumeGrace,
+	}, newArenaMetrics(registry, spec.Name))
+	// Each arena records into a directory of its own
+	recorder, err := NewRecorder(broker, filepath.Join(opts.recordDir, spec.Name))
+	if err != nil {
+		log.Fatalf("Failed to set up recorder: %s", err.Error())
+	}
+	if opts.record {
+		if err := recorder.Start(""); err != nil {
+			log.Fatalf("Failed to start recording: %s", err.Error())
+		}
+	}
+	replayer := NewReplayer(broker)
+	pairer := NewPairer(broker, opts.pairingRules)
+	clock, err := NewMatchClock(broker, opts.matchDuration)
+	if err != nil {
+		log.Fatalf("Failed to set up match clock: %s", err.Error())
+	}
+	scoreboard := NewScoreboard(broker)
+	if opts.stateFile != "" {
+		if _, err := NewStateStore(arenaPath(opts.stateFile, spec.Name), broker, pairer, clock, scoreboard); err != nil {
+			log.Fatalf("Failed to set up state file: %s", err.Error())
+		}
+	}
+	if spec.Name != "" {
+		log.WithFields(logrus.Fields{
+			"arena":    spec.Name,
+			"timestep": timestep,
+		}).Info("Arena set up")
+	}
+	return NewArena(spec.Name, broker, recorder, replayer, pairer, clock, scoreboard, tokens)
+}
+
+// shutDown finishes shutting the broker down once it has started to: it waits
+// for every session in every arena to end, closes any recordings, then stops
+// the server. Whatever is left is cut off after timeout, or as soon as another
+// signal arrives.
+func shutDown(server *grpc.Server, arenas *Arenas, signals <-chan os.Signal, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	if !arenas.WaitSessions(timeout) {
+		log.Warn("Not every session ended in time")
+	}
+	for _, arena := range arenas.List() {
+		if recording, _ := arena.recorder.IsRecording(); recording {
+			if err := arena.recorder.Stop(); err != nil {
+				log.Errorf("Failed to stop recording: %s", err.Error())
+			}
+		}
+	}
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Until(deadline)):
+		log.Warn("Server didn't stop in time, closing the remaining streams")
+		server.Stop()
+	case <-signals:
+		log.Warn("Interrupted again, closing the remaining streams")
+		server.Stop()
+	}
+	log.Info("Broker shut down")
+}
+
+// serveMetrics serves the broker's metrics, those of every arena, over HTTP at
+// /metrics
+func serveMetrics(addr string, metrics *Metrics) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	log.Printf("Serving metrics on %s", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("Failed to serve metrics on %s: %s", addr, err.Error())
+	}
+}
+
+// serveHTTP serves the dashboard or gateway, over TLS with the broker's
+// certificate if it has one
+func serveHTTP(opts options, name string, addr string, handler http.Handler) {
+	log.Printf("Serving %s on %s", name, addr)
+	var err error
+	if opts.tlsCert != "" {
+		err = http.ListenAndServeTLS(addr, opts.tlsCert, opts.tlsKey, handler)
+	} else {
+		err = http.ListenAndServe(addr, handler)
+	}
+	if err != nil {
+		log.Fatalf("Failed to serve %s on %s: %s", name, addr, err.Error())
+	}
 }
 
 func main() {
 	log = logrus.New()
-	port := flag.Int("port", 51512, "port to listen on")
-	flag.Parse()
+	// "broker replay [flags] RECORDING" runs the broker with a replay robot
+	// playing back RECORDING
+	args := os.Args[1:]
+	isReplay := len(args) > 0 && args[0] == "replay"
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	if isReplay {
+		args = args[1:]
+		flags = flag.NewFlagSet(os.Args[0]+" replay", flag.ExitOnError)
+	}
+	configPath := flags.String("config", os.Getenv("EREBUS_CONFIG"), "YAML config file setting any of the other options by name (default: $EREBUS_CONFIG)")
+	printCfg := flags.Bool("print-config", false, "print the options after applying the config file and environment, and exit")
+	port := flags.Int("port", 51512, "port to listen on, if listen isn't set")
+	listen := flags.String("listen", "", "comma separated addresses to listen on (default: \":PORT\")")
+	timestep := flags.Int("timestep", 32, "simulation timestep in ms, which must match the world's basicTimeStep")
+	arenaSpecs := flags.String("arenas", "", "comma separated NAME or NAME:TIMESTEP arenas to run, each with its own robots, clients, simulation state and timestep (default: a single unnamed arena)")
+	logLevel := flags.String("log-level", "debug", "lowest level of messages to log (trace, debug, info, warn or error)")
+	logFormat := flags.String("log-format", "text", "format of log messages (text or json)")
+	syncTimeout := flags.Duration("sync-timeout", time.Second, "time a client in sync mode has to answer each sensor frame")
+	syncPolicyName := flags.String("sync-policy", "repeat", "commands sent to a robot when its sync client is late (repeat, zero or drop)")
+	record := flags.Bool("record", false, "record every bound connection from startup")
+	recordDir := flags.String("record-dir", "recordings", "directory to write session recordings to")
+	heartbeatInterval := flags.Duration("heartbeat-interval", 5*time.Second, "time between pings to each robot and client (0 to disable)")
+	heartbeatMisses := flags.Int("heartbeat-misses", 3, "number of pings in a row a robot or client may miss before it is evicted")
+	resumeGrace := flags.Duration("resume-grace", 10*time.Second, "time a robot or client whose session dropped may resume it in, staying bound meanwhile (0 to disable)")
+	tokensFile := flags.String("tokens-file", "", "file of team names and the tokens their clients must present (default: no client authentication)")
+	tlsCert := flags.String("tls-cert", "", "certificate to serve TLS with (default: no TLS)")
+	tlsKey := flags.String("tls-key", "", "private key of the TLS certificate")
+	tlsClientCA := flags.String("tls-client-ca", "", "CA certificates which must have signed every peer's certificate (default: no client certificates)")
+	metricsAddr := flags.String("metrics-addr", "", "address to serve Prometheus metrics on, e.g. :9090 (default: no metrics)")
+	dashboardAddr := flags.String("dashboard-addr", "", "address to serve the web dashboard on, e.g. :8080 (default: no dashboard)")
+	gatewayAddr := flags.String("gateway-addr", "", "address to serve the REST gateway to the Control service on, e.g. :8081 (default: no gateway)")
+	matchDuration := flags.Duration("match-duration", 0, "time the simulation may run for between resets before it is stopped, counted in simulation time (0 for no limit)")
+	pair := flags.String("pair", "", "comma separated CLIENT=ROBOT rules binding clients to robots automatically, by name, glob or /regexp/")
+	adminToken := flags.String("admin-token", "", "token required to use the Control service (default: none)")
+	stateFile := flags.String("state-file", "", "file to save pairings, the simulation state, the match clock and scores to, and restore them from on startup (default: none)")
+	shutdownTimeout := flags.Duration("shutdown-timeout", 10*time.Second, "time sessions have to end in when the broker shuts down, before they are cut off")
+	var replayName *string
+	var replayArena *string
+	var replaySpeed *float64
+	var replayStep *bool
+	if isReplay {
+		replayName = flags.String("name", "", "name of the replay robot (default: the recorded robot's name)")
+		replayArena = flags.String("arena", "", "arena to run the replay robot in, which must be given if the broker runs several")
+		replaySpeed = flags.Float64("speed", 1, "playback speed relative to the recording (0: as fast as the client answers)")
+		replayStep = flags.Bool("step", false, "only play frames released with broker-control-cli replay step")
+	}
+	flags.Parse(args)
+
+	// Every option can also be set by the config file, or by environment
+	// variables named after it, e.g. EREBUS_SYNC_TIMEOUT
+	if err := applyConfig(flags, *configPath, os.LookupEnv); err != nil {
+		log.Fatalf("Invalid configuration: %s", err.Error())
+	}
+
+	level, err := logrus.ParseLevel(*logLevel)
+	if err != nil {
+		log.Fatalf("log-level: %s", err.Error())
+	}
+	log.SetLevel(level)
+	switch *logFormat {
+	case "text":
+	case "json":
+		log.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		log.Fatalf("log-format: unknown format \"%s\"", *logFormat)
+	}
+
+	syncPolicy, err := ParseSyncPolicy(*syncPolicyName)
+	if err != nil {
+		log.Fatalf("sync-policy: %s", err.Error())
+	}
+
+	if *timestep < 1 {
+		log.Fatal("timestep must be at least 1")
+	}
 
-	log.SetLevel(logrus.DebugLevel)
+	if *matchDuration < 0 {
+		log.Fatal("match-duration must not be negative")
+	}
+
+	if *shutdownTimeout < 0 {
+		log.Fatal("shutdown-timeout must not be negative")
+	}
+
+	if *heartbeatMisses < 1 {
+		log.Fatal("heartbeat-misses must be at least 1")
+	}
+
+	if (*tlsCert == "") != (*tlsKey == "") {
+		log.Fatal("tls-cert and tls-key must be set together")
+	}
+	if *tlsClientCA != "" && *tlsCert == "" {
+		log.Fatal("tls-client-ca requires tls-cert")
+	}
+
+	arenas := []ArenaSpec{{}}
+	if *arenaSpecs != "" {
+		arenas = nil
+		for _, s := range strings.Split(*arenaSpecs, ",") {
+			spec, err := ParseArenaSpec(s)
+			if err != nil {
+				log.Fatalf("arenas: %s", err.Error())
+			}
+			arenas = append(arenas, spec)
+		}
+	}
+
+	var pairingRules []PairingRule
+	if *pair != "" {
+		for _, r := range strings.Split(*pair, ",") {
+			rule, err := ParsePairingRule(r)
+			if err != nil {
+				log.Fatalf("pair: %s", err.Error())
+			}
+			pairingRules = append(pairingRules, rule)
+		}
+	}
+
+	if *printCfg {
+		if err := printConfig(os.Stdout, flags); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	listenAddrs := []string{fmt.Sprintf(":%d", *port)}
+	if *listen != "" {
+		listenAddrs = strings.Split(*listen, ",")
+	}
+
+	opts := options{
+		listen:            listenAddrs,
+		timestep:          *timestep,
+		arenas:            arenas,
+		syncTimeout:       *syncTimeout,
+		syncPolicy:        syncPolicy,
+		record:            *record,
+		recordDir:         *recordDir,
+		heartbeatInterval: *heartbeatInterval,
+		heartbeatMisses:   *heartbeatMisses,
+		resumeGrace:       *resumeGrace,
+		tokensFile:        *tokensFile,
+		adminToken:        *adminToken,
+		tlsCert:           *tlsCert,
+		tlsKey:            *tlsKey,
+		tlsClientCA:       *tlsClientCA,
+		metricsAddr:       *metricsAddr,
+		dashboardAddr:     *dashboardAddr,
+		gatewayAddr:       *gatewayAddr,
+		pairingRules:      pairingRules,
+		matchDuration:     *matchDuration,
+		shutdownTimeout:   *shutdownTimeout,
+		stateFile:         *stateFile,
+	}
+	if isReplay {
+		if flags.NArg() != 1 {
+			log.Fatal("replay requires exactly one recording")
+		}
+		opts.replayPath = flags.Arg(0)
+		opts.replayName = *replayName
+		opts.replayArena = *replayArena
+		opts.replay = ReplayOptions{Speed: *replaySpeed, StepMode: *replayStep}
+	}
 
-	run(*port)
+	run(opts)
 }
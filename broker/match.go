@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// How often a running match clock checks whether time is up
+const matchClockResolution = 100 * time.Millisecond
+
+// MatchClock times the match, counting only while the simulation is started,
+// and stops the simulation once the match duration is up. Time is counted in
+// simulation time, from the timestamps of the sensor frames of bound robots,
+// or in wall time in runs where no frames have passed yet. Resetting the
+// simulation resets the clock.
+type MatchClock struct {
+	broker *Broker
+	// 0 for matches with no time limit
+	duration time.Duration
+
+	mu sync.Mutex
+	// Time counted before the current run
+	elapsed time.Duration
+	running bool
+	// Wall time the current run started at
+	runStart time.Time
+	// Sim time counted in the current run, used instead of the wall time
+	// once a frame has been seen in the run
+	runSim   time.Duration
+	simTimed bool
+	// Latest sim timestamp seen in seconds, or negative if none has been seen
+	// since the last reset
+	lastSim float64
+	expired bool
+}
+
+// MatchStatus describes the match clock
+type MatchStatus struct {
+	Duration  time.Duration
+	Elapsed   time.Duration
+	Remaining time.Duration
+	Running   bool
+	Expired   bool
+	// Whether the current or last run was timed in simulation time
+	SimTimed bool
+}
+
+// NewMatchClock creates a match clock of the given duration and installs it
+// on the broker. It runs until the broker's context is done.
+func NewMatchClock(broker *Broker, duration time.Duration) (*MatchClock, error) {
+	c := &MatchClock{
+		broker:   broker,
+		duration: duration,
+		lastSim:  -1,
+	}
+	if err := broker.AddInterceptor("match-clock", c.intercept); err != nil {
+		return nil, err
+	}
+	events := broker.GetEventQueue(broker.ctx)
+	state := broker.GetSimState()
+	c.simStateChanged(state.GetState(), time.Now())
+	go func() {
+		var ticker *time.Ticker
+		var tick <-chan time.Time
+		defer func() {
+			if ticker != nil {
+				ticker.Stop()
+			}
+		}()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if event.Type != EventSimStateChanged {
+					continue
+				}
+				c.simStateChanged(event.SimState.GetState(), event.Time)
+			case <-tick:
+				c.checkExpiry()
+				continue
+			}
+			c.mu.Lock()
+			running := c.running
+			c.mu.Unlock()
+			if running && ticker == nil {
+				ticker = time.NewTicker(matchClockResolution)
+				tick = ticker.C
+			} else if !running && ticker != nil {
+				ticker.Stop()
+				ticker, tick = nil, nil
+			}
+		}
+	}()
+	return c, nil
+}
+
+// Status returns the state of the match clock
+func (c *MatchClock) Status() MatchStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elapsed := c.elapsedAt(time.Now())
+	status := MatchStatus{
+		Duration: c.duration,
+		Elapsed:  elapsed,
+		Running:  c.running,
+		Expired:  c.expired,
+		SimTimed: c.simTimed,
+	}
+	if c.duration > 0 && elapsed < c.duration {
+		status.Remaining = c.duration - elapsed
+	}
+	return status
+}
+
+// Restore restores the time counted before the current run, and whether time
+// is up, from a saved state
+func (c *MatchClock) Restore(elapsed time.Duration, expired bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.elapsed = elapsed
+	c.expired = expired
+}
+
+// elapsedAt returns the time counted as of now. c.mu must be held.
+func (c *MatchClock) elapsedAt(now time.Time) time.Duration {
+	if !c.running {
+		return c.elapsed
+	}
+	if c.simTimed {
+		return c.elapsed + c.runSim
+	}
+	return c.elapsed + now.Sub(c.runStart)
+}
+
+func (c *MatchClock) simStateChanged(state pb.SimState_State, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch state {
+	case pb.SimState_START:
+		if c.running {
+			return
+		}
+		if c.expired {
+			log.Warn("Match time is up, reset the simulation to start a new match")
+			go c.stopSim()
+			return
+		}
+		c.running = true
+		c.runStart = at
+		c.runSim = 0
+		c.simTimed = false
+	case pb.SimState_STOP:
+		c.stop(at)
+	case pb.SimState_RESET:
+		c.running = false
+		c.elapsed = 0
+		c.simTimed = false
+		c.lastSim = -1
+		c.expired = false
+	}
+}
+
+// stop ends the current run. c.mu must be held.
+func (c *MatchClock) stop(at time.Time) {
+	if !c.running {
+		return
+	}
+	c.elapsed = c.elapsedAt(at)
+	c.running = false
+}
+
+// checkExpiry stops the simulation if the match is running and its time is up
+func (c *MatchClock) checkExpiry() {
+	c.mu.Lock()
+	now := time.Now()
+	if !c.running || c.duration <= 0 || c.elapsedAt(now) < c.duration {
+		c.mu.Unlock()
+		return
+	}
+	c.stop(now)
+	c.expired = true
+	c.mu.Unlock()
+	log.WithField("duration", c.duration).Info("Match time is up, stopping the simulation")
+	c.stopSim()
+}
+
+func (c *MatchClock) stopSim() {
+	c.broker.SetSimState(pb.SimState{State: pb.SimState_STOP})
+}
+
+// observeTimestamp counts the sim time up to a sensor frame's timestamp
+func (c *MatchClock) observeTimestamp(timestamp float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Frames of different robots arrive slightly out of order, so only time
+	// moving forwards counts
+	if timestamp <= c.lastSim {
+		return
+	}
+	if c.running && c.lastSim >= 0 {
+		if c.simTimed {
+			c.runSim += time.Duration((timestamp - c.lastSim) * float64(time.Second))
+		} else {
+			// Switch from wall time, keeping the time counted so far
+			c.runSim = time.Since(c.runStart)
+			c.simTimed = true
+		}
+	}
+	c.lastSim = timestamp
+}
+
+func (c *MatchClock) intercept(context.Context, ConnectionInfo) Interceptor {
+	return InterceptorFuncs{
+		SensorsData: func(_ context.Context, sd *pb.SensorsData) *pb.SensorsData {
+			if sd.GetTimestamp() > 0 {
+				c.observeTimestamp(sd.GetTimestamp())
+				c.checkExpiry()
+			}
+			return sd
+		},
+	}
+}
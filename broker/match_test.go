@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type MatchSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+}
+
+func (suite *MatchSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+}
+
+func (suite *MatchSuite) TearDownTest() {
+	suite.globalCtxClose()
+}
+
+func (suite *MatchSuite) clock(duration time.Duration) *MatchClock {
+	clock, err := NewMatchClock(suite.broker, duration)
+	suite.Require().NoError(err)
+	return clock
+}
+
+func (suite *MatchSuite) setSimState(state pb.SimState_State) {
+	suite.broker.SetSimState(pb.SimState{State: state})
+	time.Sleep(closeTimeout)
+}
+
+func (suite *MatchSuite) TestCountsOnlyWhileStarted() {
+	clock := suite.clock(0)
+	suite.Equal(time.Duration(0), clock.Status().Elapsed)
+	suite.setSimState(pb.SimState_START)
+	time.Sleep(20 * time.Millisecond)
+	suite.setSimState(pb.SimState_STOP)
+	status := clock.Status()
+	suite.False(status.Running)
+	suite.False(status.SimTimed)
+	suite.InDelta(30*time.Millisecond, status.Elapsed, float64(10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	suite.Equal(status.Elapsed, clock.Status().Elapsed)
+	suite.Equal(time.Duration(0), status.Remaining)
+
+	suite.setSimState(pb.SimState_RESET)
+	suite.Equal(time.Duration(0), clock.Status().Elapsed)
+}
+
+func (suite *MatchSuite) TestExpiry() {
+	clock := suite.clock(50 * time.Millisecond)
+	suite.setSimState(pb.SimState_START)
+	suite.True(clock.Status().Running)
+	suite.True(clock.Status().Remaining > 0)
+	time.Sleep(50*time.Millisecond + 2*matchClockResolution)
+	suite.Equal(pb.SimState_STOP, suite.broker.GetSimState().State)
+	status := clock.Status()
+	suite.True(status.Expired)
+	suite.False(status.Running)
+	suite.Equal(time.Duration(0), status.Remaining)
+
+	// The match can't be restarted without a reset
+	suite.setSimState(pb.SimState_START)
+	time.Sleep(closeTimeout)
+	suite.Equal(pb.SimState_STOP, suite.broker.GetSimState().State)
+	suite.setSimState(pb.SimState_RESET)
+	suite.setSimState(pb.SimState_START)
+	suite.Equal(pb.SimState_START, suite.broker.GetSimState().State)
+	suite.False(clock.Status().Expired)
+}
+
+func (suite *MatchSuite) TestSimTime() {
+	clock := suite.clock(time.Second)
+	suite.setSimState(pb.SimState_START)
+	clock.observeTimestamp(10)
+	suite.False(clock.Status().SimTimed)
+	clock.observeTimestamp(10.5)
+	suite.True(clock.Status().SimTimed)
+	// Frames from a robot which is behind don't count
+	clock.observeTimestamp(10.25)
+	clock.observeTimestamp(10.75)
+	status := clock.Status()
+	suite.InDelta(time.Duration(0.25*float64(time.Second))+closeTimeout, status.Elapsed, float64(10*time.Millisecond))
+	clock.observeTimestamp(12)
+	clock.checkExpiry()
+	suite.True(clock.Status().Expired)
+	suite.Equal(pb.SimState_STOP, suite.broker.GetSimState().State)
+}
+
+func TestMatchSuite(t *testing.T) {
+	suite.Run(t, new(MatchSuite))
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the broker's Prometheus metrics. Broker state is tracked from
+// the events the broker publishes, and traffic from the session loops.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	robots      prometheus.Gauge
+	clients     prometheus.Gauge
+	connections prometheus.Gauge
+
+	simStateChanges     *prometheus.CounterVec
+	handshakeRejections *prometheus.CounterVec
+	heartbeatRTT        *prometheus.HistogramVec
+
+	sensorFrames   *prometheus.CounterVec
+	commands       *prometheus.CounterVec
+	commandLatency *prometheus.HistogramVec
+}
+
+// newMetrics creates the metrics of a broker, with a registry of their own
+func newMetrics() *Metrics {
+	return newArenaMetrics(newMetricsRegistry(), "")
+}
+
+// newMetricsRegistry creates a registry holding the process' metrics, which
+// the metrics of every arena are added to
+func newMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// newArenaMetrics creates the metrics of an arena's broker in registry,
+// labelled with the arena's name unless it is empty
+func newArenaMetrics(registry *prometheus.Registry, arena string) *Metrics {
+	m := &Metrics{
+		registry: registry,
+		robots: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "erebus_robots_registered",
+			Help: "Number of robots registered with the broker.",
+		}),
+		clients: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "erebus_clients_registered",
+			Help: "Number of clients registered with the broker.",
+		}),
+		connections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "erebus_connections_bound",
+			Help: "Number of bound connections between clients and robots.",
+		}),
+		simStateChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "erebus_sim_state_changes_total",
+			Help: "Number of simulation state changes, by new state.",
+		}, []string{"state"}),
+		handshakeRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "erebus_handshake_rejections_total",
+			Help: "Number of rejected session handshakes, by peer kind and reason.",
+		}, []string{"peer", "reason"}),
+		heartbeatRTT: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "erebus_heartbeat_rtt_seconds",
+			Help:    "Round trip time of heartbeats, by peer kind.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"peer"}),
+		sensorFrames: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "erebus_sensor_frames_forwarded_total",
+			Help: "Number of sensor frames forwarded to clients, by connection.",
+		}, []string{"robot", "client"}),
+		commands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "erebus_commands_forwarded_total",
+			Help: "Number of commands messages forwarded to robots, by connection.",
+		}, []string{"robot", "client"}),
+		commandLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "erebus_command_latency_seconds",
+			Help:    "Time from a sensor frame being sent to a client to its first commands in reply, by connection.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 12),
+		}, []string{"robot", "client"}),
+	}
+	var registerer prometheus.Registerer = registry
+	if arena != "" {
+		registerer = prometheus.WrapRegistererWith(prometheus.Labels{"arena": arena}, registry)
+	}
+	registerer.MustRegister(
+		m.robots,
+		m.clients,
+		m.connections,
+		m.simStateChanges,
+		m.handshakeRejections,
+		m.heartbeatRTT,
+		m.sensorFrames,
+		m.commands,
+		m.commandLatency,
+	)
+	return m
+}
+
+// Handler returns an HTTP handler serving the metrics to Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// observeEvent updates the metrics tracking the state of the broker
+func (m *Metrics) observeEvent(event Event) {
+	switch event.Type {
+	case EventRobotRegistered:
+		m.robots.Inc()
+	case EventRobotUnregistered:
+		m.robots.Dec()
+	case EventClientRegistered:
+		m.clients.Inc()
+	case EventClientUnregistered:
+		m.clients.Dec()
+	case EventConnectionBound:
+		m.connections.Inc()
+	case EventConnectionUnbound:
+		m.connections.Dec()
+		// Drop the series of the connection, so they don't pile up over an
+		// event
+		m.sensorFrames.DeleteLabelValues(event.RobotName, event.ClientName)
+		m.commands.DeleteLabelValues(event.RobotName, event.ClientName)
+		m.commandLatency.DeleteLabelValues(event.RobotName, event.ClientName)
+	case EventSimStateChanged:
+		m.simStateChanges.WithLabelValues(event.SimState.GetState().String()).Inc()
+	}
+}
+
+// rejectHandshake counts a rejected handshake from a peer of the given kind
+// ("robot" or "client")
+func (m *Metrics) rejectHandshake(peer, reason string) {
+	m.handshakeRejections.WithLabelValues(peer, reason).Inc()
+}
+
+// connection returns the metrics of a connection between a robot and a client
+func (m *Metrics) connection(robotName, clientName string) *ConnectionMetrics {
+	return &ConnectionMetrics{
+		sensorFrames:   m.sensorFrames.WithLabelValues(robotName, clientName),
+		commands:       m.commands.WithLabelValues(robotName, clientName),
+		commandLatency: m.commandLatency.WithLabelValues(robotName, clientName),
+	}
+}
+
+// ConnectionMetrics holds the metrics of a single connection, shared by the
+// sessions of its robot and client. A nil ConnectionMetrics
+// records nothing.
+type ConnectionMetrics struct {
+	// Time the last sensor frame was sent to the client in Unix nanoseconds,
+	// or 0 once the client has replied to it. Accessed atomically, so kept
+	// first for alignment.
+	lastFrame int64
+
+	sensorFrames   prometheus.Counter
+	commands       prometheus.Counter
+	commandLatency prometheus.Observer
+}
+
+// SensorFrameSent counts a sensor frame sent to the client
+func (c *ConnectionMetrics) SensorFrameSent() {
+	if c == nil {
+		return
+	}
+	c.sensorFrames.Inc()
+	atomic.StoreInt64(&c.lastFrame, time.Now().UnixNano())
+}
+
+// CommandsSent counts commands sent to the robot
+func (c *ConnectionMetrics) CommandsSent() {
+	if c == nil {
+		return
+	}
+	c.commands.Inc()
+}
+
+// CommandsReceived observes the latency of commands from the client, if they
+// are the first since the last sensor frame
+func (c *ConnectionMetrics) CommandsReceived() {
+	if c == nil {
+		return
+	}
+	if sent := atomic.SwapInt64(&c.lastFrame, 0); sent != 0 {
+		c.commandLatency.Observe(time.Since(time.Unix(0, sent)).Seconds())
+	}
+}
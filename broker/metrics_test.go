@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+}
+
+func (suite *MetricsSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+}
+
+func (suite *MetricsSuite) TearDownTest() {
+	suite.globalCtxClose()
+}
+
+func (suite *MetricsSuite) TestBrokerState() {
+	metrics := suite.broker.metrics
+	robotCtx, robotCtxClose := context.WithCancel(suite.globalCtx)
+	robot := suite.broker.RegisterRobot("robot", robotCtx, nil)
+	suite.Require().NotNil(robot)
+	client := suite.broker.RegisterClient("client", suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	suite.Equal(1.0, testutil.ToFloat64(metrics.robots))
+	suite.Equal(1.0, testutil.ToFloat64(metrics.clients))
+	go func() { <-robot.GetConnection() }()
+	go func() { <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	suite.Equal(1.0, testutil.ToFloat64(metrics.connections))
+	robotCtxClose()
+	time.Sleep(closeTimeout)
+	suite.Equal(0.0, testutil.ToFloat64(metrics.robots))
+	suite.Equal(1.0, testutil.ToFloat64(metrics.clients))
+	suite.Equal(0.0, testutil.ToFloat64(metrics.connections))
+}
+
+func (suite *MetricsSuite) TestSimStateChanges() {
+	suite.broker.SetSimState(pb.SimState{State: pb.SimState_START})
+	suite.broker.SetSimState(pb.SimState{State: pb.SimState_STOP})
+	suite.broker.SetSimState(pb.SimState{State: pb.SimState_START})
+	suite.Equal(2.0, testutil.ToFloat64(suite.broker.metrics.simStateChanges.WithLabelValues("START")))
+	suite.Equal(1.0, testutil.ToFloat64(suite.broker.metrics.simStateChanges.WithLabelValues("STOP")))
+}
+
+func (suite *MetricsSuite) TestConnectionMetrics() {
+	metrics := suite.broker.metrics.connection("robot", "client")
+	metrics.SensorFrameSent()
+	metrics.CommandsReceived()
+	// Only the first reply to a frame counts towards the latency
+	metrics.CommandsReceived()
+	metrics.CommandsSent()
+	metrics.CommandsSent()
+	suite.Equal(1.0, testutil.ToFloat64(metrics.sensorFrames))
+	suite.Equal(2.0, testutil.ToFloat64(metrics.commands))
+	latency := &dto.Metric{}
+	suite.Require().NoError(metrics.commandLatency.(prometheus.Histogram).Write(latency))
+	suite.Equal(uint64(1), latency.GetHistogram().GetSampleCount())
+	// A nil ConnectionMetrics records nothing
+	var none *ConnectionMetrics
+	none.SensorFrameSent()
+	none.CommandsReceived()
+	none.CommandsSent()
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// buildOpenAPI generates the OpenAPI document describing the gateway's
+// routes, with schemas generated from the descriptors of their messages
+func (g *Gateway) buildOpenAPI() (map[string]interface{}, error) {
+	schemas := newSchemaBuilder()
+	paths := make(map[string]map[string]interface{})
+	for _, route := range g.routes {
+		request, err := schemas.messageRef(route.request)
+		if err != nil {
+			return nil, err
+		}
+		response, err := schemas.messageRef(route.response)
+		if err != nil {
+			return nil, err
+		}
+		op := map[string]interface{}{
+			"operationId": route.rpc,
+			"summary":     route.summary,
+			"responses": map[string]interface{}{
+				"default": map[string]interface{}{
+					"description": "The call failed",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+						},
+					},
+				},
+			},
+		}
+		if route.stream != nil {
+			op["responses"].(map[string]interface{})["200"] = map[string]interface{}{
+				"description": "Server-sent events, each carrying a message as JSON, until the request is closed",
+				"content": map[string]interface{}{
+					"text/event-stream": map[string]interface{}{"schema": response},
+				},
+			}
+		} else {
+			op["responses"].(map[string]interface{})["200"] = map[string]interface{}{
+				"description": "The RPC's response; RPCs which can fail set either error or ok",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": response},
+				},
+			}
+		}
+		var params []interface{}
+		for _, segment := range strings.Split(route.path, "/") {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				params = append(params, map[string]interface{}{
+					"name":     segment[1 : len(segment)-1],
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]interface{}{"type": "string"},
+				})
+			}
+		}
+		if route.rpc != "GetArenas" && route.rpc != "Shutdown" {
+			params = append(params, map[string]interface{}{
+				"name":        "arena",
+				"in":          "query",
+				"description": "Arena the call applies to; may be left out if the broker runs a single arena",
+				"schema":      map[string]interface{}{"type": "string"},
+			})
+		}
+		if params != nil {
+			op["parameters"] = params
+		}
+		if route.method == http.MethodPost || route.method == http.MethodPut {
+			op["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": request},
+				},
+			}
+		}
+		if paths[route.path] == nil {
+			paths[route.path] = make(map[string]interface{})
+		}
+		paths[route.path][strings.ToLower(route.method)] = op
+	}
+	schemas.schemas["Error"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"error": map[string]interface{}{"type": "string"},
+		},
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Erebus broker control",
+			"description": "REST gateway to the broker's Control service",
+			"version":     "1",
+		},
+		"servers": []interface{}{map[string]interface{}{"url": gatewayPrefix}},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.schemas,
+			"securitySchemes": map[string]interface{}{
+				"adminToken": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []interface{}{map[string]interface{}{"adminToken": []interface{}{}}},
+	}, nil
+}
+
+// schemaBuilder generates OpenAPI schemas for protobuf messages in their JSON
+// encoding, from the descriptors of the files they were compiled from
+type schemaBuilder struct {
+	files    map[string]bool
+	messages map[string]*descpb.DescriptorProto
+	enums    map[string]*descpb.EnumDescriptorProto
+	// Schemas generated so far, by message or enum name
+	schemas map[string]interface{}
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		files:    make(map[string]bool),
+		messages: make(map[string]*descpb.DescriptorProto),
+		enums:    make(map[string]*descpb.EnumDescriptorProto),
+		schemas:  make(map[string]interface{}),
+	}
+}
+
+// messageRef returns a reference to the schema of msg
+func (b *schemaBuilder) messageRef(msg proto.Message) (map[string]interface{}, error) {
+	described, ok := msg.(descriptor.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T has no descriptor", msg)
+	}
+	file, _ := descriptor.ForMessage(described)
+	if err := b.addFile(file.GetName()); err != nil {
+		return nil, err
+	}
+	return b.typeRef("." + proto.MessageName(msg)), nil
+}
+
+// addFile indexes the messages and enums of a registered file and its
+// dependencies
+func (b *schemaBuilder) addFile(name string) error {
+	if b.files[name] {
+		return nil
+	}
+	b.files[name] = true
+	gz := proto.FileDescriptor(name)
+	if gz == nil {
+		return fmt.Errorf("no descriptor registered for %s", name)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return err
+	}
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	file := &descpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(raw, file); err != nil {
+		return err
+	}
+	scope := ""
+	if file.GetPackage() != "" {
+		scope = "." + file.GetPackage()
+	}
+	for _, msg := range file.GetMessageType() {
+		b.addMessage(scope, msg)
+	}
+	for _, enum := range file.GetEnumType() {
+		b.enums[scope+"."+enum.GetName()] = enum
+	}
+	for _, dep := range file.GetDependency() {
+		if err := b.addFile(dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *schemaBuilder) addMessage(scope string, msg *descpb.DescriptorProto) {
+	name := scope + "." + msg.GetName()
+	b.messages[name] = msg
+	for _, nested := range msg.GetNestedType() {
+		b.addMessage(name, nested)
+	}
+	for _, enum := range msg.GetEnumType() {
+		b.enums[name+"."+enum.GetName()] = enum
+	}
+}
+
+// typeRef returns a reference to the schema of a message or enum, given its
+// fully qualified name, generating the schema if it hasn't been yet
+func (b *schemaBuilder) typeRef(typeName string) map[string]interface{} {
+	// Well-known types have their own JSON encodings
+	switch typeName {
+	case ".google.protobuf.Timestamp":
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case ".google.protobuf.Duration":
+		return map[string]interface{}{"type": "string", "pattern": "^-?[0-9]+(\\.[0-9]+)?s$"}
+	}
+	name := strings.TrimPrefix(typeName, ".")
+	if _, ok := b.schemas[name]; !ok {
+		if enum, ok := b.enums[typeName]; ok {
+			values := make([]interface{}, 0, len(enum.GetValue()))
+			for _, value := range enum.GetValue() {
+				values = append(values, value.GetName())
+			}
+			b.schemas[name] = map[string]interface{}{"type": "string", "enum": values}
+		} else if msg, ok := b.messages[typeName]; ok {
+			// Reserve the name first, as messages may refer to themselves
+			b.schemas[name] = nil
+			b.schemas[name] = b.messageSchema(typeName, msg)
+		} else {
+			return map[string]interface{}{"type": "object"}
+		}
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func (b *schemaBuilder) messageSchema(name string, msg *descpb.DescriptorProto) map[string]interface{} {
+	properties := make(map[string]interface{})
+	oneofs := make([][]string, len(msg.GetOneofDecl()))
+	for _, field := range msg.GetField() {
+		properties[field.GetJsonName()] = b.fieldSchema(field)
+		if field.OneofIndex != nil {
+			oneofs[field.GetOneofIndex()] = append(oneofs[field.GetOneofIndex()], field.GetJsonName())
+		}
+	}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	var notes []string
+	for _, fields := range oneofs {
+		notes = append(notes, "At most one of "+strings.Join(fields, ", ")+" is set.")
+	}
+	if notes != nil {
+		schema["description"] = strings.Join(notes, " ")
+	}
+	return schema
+}
+
+func (b *schemaBuilder) fieldSchema(field *descpb.FieldDescriptorProto) map[string]interface{} {
+	var schema map[string]interface{}
+	switch field.GetType() {
+	case descpb.FieldDescriptorProto_TYPE_DOUBLE:
+		schema = map[string]interface{}{"type": "number", "format": "double"}
+	case descpb.FieldDescriptorProto_TYPE_FLOAT:
+		schema = map[string]interface{}{"type": "number", "format": "float"}
+	case descpb.FieldDescriptorProto_TYPE_INT32, descpb.FieldDescriptorProto_TYPE_SINT32, descpb.FieldDescriptorProto_TYPE_SFIXED32:
+		schema = map[string]interface{}{"type": "integer", "format": "int32"}
+	case descpb.FieldDescriptorProto_TYPE_UINT32, descpb.FieldDescriptorProto_TYPE_FIXED32:
+		schema = map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case descpb.FieldDescriptorProto_TYPE_INT64, descpb.FieldDescriptorProto_TYPE_SINT64, descpb.FieldDescriptorProto_TYPE_SFIXED64,
+		descpb.FieldDescriptorProto_TYPE_UINT64, descpb.FieldDescriptorProto_TYPE_FIXED64:
+		// 64 bit integers are encoded as strings, as JSON numbers can't hold
+		// them exactly
+		schema = map[string]interface{}{"type": "string", "format": "int64"}
+	case descpb.FieldDescriptorProto_TYPE_BOOL:
+		schema = map[string]interface{}{"type": "boolean"}
+	case descpb.FieldDescriptorProto_TYPE_STRING:
+		schema = map[string]interface{}{"type": "string"}
+	case descpb.FieldDescriptorProto_TYPE_BYTES:
+		schema = map[string]interface{}{"type": "string", "format": "byte"}
+	case descpb.FieldDescriptorProto_TYPE_ENUM:
+		schema = b.typeRef(field.GetTypeName())
+	case descpb.FieldDescriptorProto_TYPE_MESSAGE:
+		if entry, ok := b.messages[field.GetTypeName()]; ok && entry.GetOptions().GetMapEntry() {
+			// Maps are encoded as objects, keyed by their keys as strings
+			return map[string]interface{}{"type": "object", "additionalProperties": b.fieldSchema(entry.GetField()[1])}
+		}
+		schema = b.typeRef(field.GetTypeName())
+	default:
+		schema = map[string]interface{}{}
+	}
+	if field.GetLabel() == descpb.FieldDescriptorProto_LABEL_REPEATED {
+		return map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// namePattern matches robot or client names. Patterns enclosed in slashes are
+// regular expressions which must match the whole name; any other pattern is a
+// glob, which is an exact name unless it contains *, ? or [...].
+type namePattern struct {
+	source string
+	regex  *regexp.Regexp
+}
+
+func parseNamePattern(source string) (namePattern, error) {
+	if source == "" {
+		return namePattern{}, errors.New("empty pattern")
+	}
+	if len(source) > 1 && strings.HasPrefix(source, "/") && strings.HasSuffix(source, "/") {
+		regex, err := regexp.Compile("^(?:" + source[1:len(source)-1] + ")$")
+		if err != nil {
+			return namePattern{}, err
+		}
+		return namePattern{source: source, regex: regex}, nil
+	}
+	if _, err := path.Match(source, ""); err != nil {
+		return namePattern{}, fmt.Errorf("invalid glob \"%s\"", source)
+	}
+	return namePattern{source: source}, nil
+}
+
+func (p namePattern) match(name string) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(name)
+	}
+	ok, _ := path.Match(p.source, name)
+	return ok
+}
+
+// PairingRule pairs clients whose names match one pattern with robots whose
+// names match another. If the client pattern is a regular expression, the
+// robot pattern may refer to its groups as $1 or ${name}, which are replaced by
+// the text they matched in the client's name.
+type PairingRule struct {
+	client namePattern
+	robot  string
+}
+
+// ParsePairingRule parses a rule of the form CLIENT=ROBOT
+func ParsePairingRule(rule string) (PairingRule, error) {
+	// A regular expression may itself contain "="
+	split := strings.Index(rule, "=")
+	if strings.HasPrefix(rule, "/") {
+		if end := strings.Index(rule[1:], "/="); end >= 0 {
+			split = end + 2
+		}
+	}
+	if split < 0 {
+		return PairingRule{}, fmt.Errorf("pairing rule \"%s\" isn't of the form CLIENT=ROBOT", rule)
+	}
+	client, err := parseNamePattern(rule[:split])
+	if err != nil {
+		return PairingRule{}, fmt.Errorf("pairing rule \"%s\": client: %s", rule, err.Error())
+	}
+	r := PairingRule{client: client, robot: rule[split+1:]}
+	if client.regex == nil {
+		// Check the robot pattern now, as it can't change with the client
+		if _, err := parseNamePattern(r.robot); err != nil {
+			return PairingRule{}, fmt.Errorf("pairing rule \"%s\": robot: %s", rule, err.Error())
+		}
+	} else if r.robot == "" {
+		return PairingRule{}, fmt.Errorf("pairing rule \"%s\": robot: empty pattern", rule)
+	}
+	return r, nil
+}
+
+func (r PairingRule) String() string {
+	return r.client.source + "=" + r.robot
+}
+
+// robotPattern returns the pattern of robots the rule pairs the client with,
+// and whether the rule applies to the client at all
+func (r PairingRule) robotPattern(clientName string) (namePattern, bool) {
+	if r.client.regex == nil {
+		if !r.client.match(clientName) {
+			return namePattern{}, false
+		}
+		robot, _ := parseNamePattern(r.robot)
+		return robot, true
+	}
+	submatches := r.client.regex.FindStringSubmatchIndex(clientName)
+	if submatches == nil {
+		return namePattern{}, false
+	}
+	expanded := string(r.client.regex.ExpandString(nil, r.robot, clientName, submatches))
+	robot, err := parseNamePattern(expanded)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"rule":   r.String(),
+			"client": clientName,
+		}).Warnf("Invalid robot pattern \"%s\": %s", expanded, err.Error())
+		return namePattern{}, false
+	}
+	return robot, true
+}
+
+// PairingRuleStatus describes a pairing rule and what it has done
+type PairingRuleStatus struct {
+	Rule string
+	// Bound connections made by the rule
+	Pairs []ConnectionInfo
+	// Unbound clients which the rule is the first to apply to
+	WaitingClients []string
+}
+
+// Pairer binds clients to robots automatically following a list of pairing
+// rules, as soon as a client and a robot matching a rule are both registered
+// and unbound. Rules are tried in order, and robots and clients in name order.
+// Clients an operator disconnects are left unbound until they register again.
+// It also re-establishes connections restored from a saved state.
+type Pairer struct {
+	broker *Broker
+
+	mu    sync.Mutex
+	rules []PairingRule
+	// Rule which made the connection of each client it paired
+	paired map[string]string
+	// Restored connections yet to be re-established, robot by client name
+	pending map[string]string
+
+	wake    chan struct{}
+	changed chan struct{}
+}
+
+// NewPairer creates a pairer applying rules, which runs until the broker's
+// context is done
+func NewPairer(broker *Broker, rules []PairingRule) *Pairer {
+	// Copied, as the pairers of several arenas may be given the same rules
+	rules = append([]PairingRule(nil), rules...)
+	p := &Pairer{
+		broker:  broker,
+		rules:   rules,
+		paired:  make(map[string]string),
+		pending: make(map[string]string),
+		wake:    make(chan struct{}, 1),
+		changed: make(chan struct{}, 1),
+	}
+	events := broker.GetEventQueue(broker.ctx)
+	go func() {
+		p.pair()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				switch event.Type {
+				case EventRobotRegistered, EventClientRegistered, EventConnectionUnbound:
+				default:
+					continue
+				}
+			case <-p.wake:
+			}
+			p.pair()
+		}
+	}()
+	return p
+}
+
+// Add appends a rule to the pairer's rules, applying it straight away
+func (p *Pairer) Add(rule PairingRule) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, r := range p.rules {
+		if r.String() == rule.String() {
+			return errors.New("Pairing rule already exists")
+		}
+	}
+	p.rules = append(p.rules, rule)
+	p.notify()
+	log.WithField("rule", rule.String()).Info("Pairing rule added")
+	return nil
+}
+
+// Remove removes a rule from the pairer's rules. Connections it made are left
+// bound.
+func (p *Pairer) Remove(rule string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, r := range p.rules {
+		if r.String() == rule {
+			p.rules = append(p.rules[:i:i], p.rules[i+1:]...)
+			p.notify()
+			log.WithField("rule", rule).Info("Pairing rule removed")
+			return nil
+		}
+	}
+	return errors.New("Pairing rule not found")
+}
+
+// Restore replaces the pairer's rules with rules restored from a saved state,
+// and re-establishes the restored connections, robot by client name, as soon
+// as their client and robot are both registered. Clients awaiting a restored
+// connection aren't paired by the rules.
+func (p *Pairer) Restore(rules []PairingRule, connections map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rules = rules
+	for client, robot := range connections {
+		p.pending[client] = robot
+	}
+	p.notify()
+}
+
+// Pending returns the restored connections yet to be re-established, robot by
+// client name
+func (p *Pairer) Pending() map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pending := make(map[string]string, len(p.pending))
+	for client, robot := range p.pending {
+		pending[client] = robot
+	}
+	return pending
+}
+
+// Changed returns a channel which receives a value after the rules or the
+// restored connections awaiting their peers change
+func (p *Pairer) Changed() <-chan struct{} {
+	return p.changed
+}
+
+// notify wakes the pairer up to apply a change, and signals the change. p.mu
+// must be held.
+func (p *Pairer) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Rules returns the status of every rule, in order
+func (p *Pairer) Rules() []PairingRuleStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	statuses := make([]PairingRuleStatus, len(p.rules))
+	index := make(map[string]int, len(p.rules))
+	for i, rule := range p.rules {
+		statuses[i].Rule = rule.String()
+		index[rule.String()] = i
+	}
+	for _, conn := range p.broker.GetConnections() {
+		if i, ok := index[p.paired[conn.ClientName]]; ok {
+			statuses[i].Pairs = append(statuses[i].Pairs, conn)
+		}
+	}
+	clients, _ := p.broker.unboundNames()
+	for _, client := range clients {
+		for i, rule := range p.rules {
+			if _, ok := rule.robotPattern(client); ok {
+				statuses[i].WaitingClients = append(statuses[i].WaitingClients, client)
+				break
+			}
+		}
+	}
+	return statuses
+}
+
+// pair binds every unbound client awaiting a restored connection to its robot,
+// and every other unbound client a rule applies to to the first unbound robot
+// matching the rule. p.mu isn't held while connecting, as binding waits for the
+// client's and the robot's sessions.
+func (p *Pairer) pair() {
+	p.mu.Lock()
+	if len(p.pending) > 0 {
+		// Restored connections are given up on once their client has been
+		// connected some other way
+		for _, conn := range p.broker.GetConnections() {
+			if _, ok := p.pending[conn.ClientName]; ok {
+				delete(p.pending, conn.ClientName)
+				p.notify()
+			}
+		}
+	}
+	rules := append([]PairingRule(nil), p.rules...)
+	pending := make(map[string]string, len(p.pending))
+	for client, robot := range p.pending {
+		pending[client] = robot
+	}
+	p.mu.Unlock()
+	clients, robots := p.broker.unboundNames()
+	if len(clients) == 0 || len(robots) == 0 || (len(rules) == 0 && len(pending) == 0) {
+		return
+	}
+	for _, client := range clients {
+		if robot, ok := pending[client]; ok {
+			if i := sort.SearchStrings(robots, robot); i < len(robots) && robots[i] == robot {
+				logger := log.WithFields(logrus.Fields{
+					"client": client,
+					"robot":  robot,
+				})
+				if err := p.broker.ConnectClientToRobot(client, robot); err != nil {
+					logger.Warnf("Couldn't restore connection: %s", err.Error())
+					continue
+				}
+				logger.Info("Connection restored")
+				p.mu.Lock()
+				delete(p.pending, client)
+				p.notify()
+				p.mu.Unlock()
+				robots = append(robots[:i:i], robots[i+1:]...)
+			}
+			continue
+		}
+		p.mu.Lock()
+		delete(p.paired, client)
+		p.mu.Unlock()
+	LRules:
+		for _, rule := range rules {
+			robotPattern, ok := rule.robotPattern(client)
+			if !ok {
+				continue
+			}
+			for i, robot := range robots {
+				if !robotPattern.match(robot) {
+					continue
+				}
+				logger := log.WithFields(logrus.Fields{
+					"rule":   rule.String(),
+					"client": client,
+					"robot":  robot,
+				})
+				if err := p.broker.ConnectClientToRobot(client, robot); err != nil {
+					logger.Warnf("Couldn't pair client: %s", err.Error())
+					continue
+				}
+				logger.Info("Client paired")
+				p.mu.Lock()
+				p.paired[client] = rule.String()
+				p.mu.Unlock()
+				robots = append(robots[:i:i], robots[i+1:]...)
+				break LRules
+			}
+		}
+	}
+}
+
+// unboundNames returns the names of the registered clients and robots which
+// aren't bound or awaiting resumption, in order. Clients disconnected by hand
+// are left out, as they aren't to be paired again.
+func (b *Broker) unboundNames() (clients []string, robots []string) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	boundRobots := make(map[string]bool, len(b.connections))
+	for _, conn := range b.connections {
+		boundRobots[conn.robotName] = true
+	}
+	for name, client := range b.clients {
+		if _, ok := b.connections[name]; !ok && !client.session.isDetached() && !client.heldUnbound {
+			clients = append(clients, name)
+		}
+	}
+	for name, robot := range b.robots {
+		if !boundRobots[name] && !robot.session.isDetached() {
+			robots = append(robots, name)
+		}
+	}
+	sort.Strings(clients)
+	sort.Strings(robots)
+	return clients, robots
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PairingSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+}
+
+func (suite *PairingSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+}
+
+func (suite *PairingSuite) TearDownTest() {
+	suite.globalCtxClose()
+}
+
+func (suite *PairingSuite) rules(rules ...string) []PairingRule {
+	parsed := make([]PairingRule, len(rules))
+	for i, rule := range rules {
+		var err error
+		parsed[i], err = ParsePairingRule(rule)
+		suite.Require().NoError(err)
+	}
+	return parsed
+}
+
+// register registers robots and clients which accept a connection when bound
+func (suite *PairingSuite) register(robots []string, clients []string) {
+	for _, name := range robots {
+		robot := suite.broker.RegisterRobot(name, suite.globalCtx, nil)
+		suite.Require().NotNil(robot)
+		go func() { <-robot.GetConnection() }()
+	}
+	for _, name := range clients {
+		client := suite.broker.RegisterClient(name, suite.globalCtx, false)
+		suite.Require().NotNil(client)
+		go func() { <-client.GetConnection() }()
+	}
+}
+
+func (suite *PairingSuite) pairs() map[string]string {
+	pairs := make(map[string]string)
+	for _, conn := range suite.broker.GetConnections() {
+		pairs[conn.ClientName] = conn.RobotName
+	}
+	return pairs
+}
+
+func (suite *PairingSuite) TestParsePairingRule() {
+	for _, rule := range []string{"team-a=robot0", "team-*=robot?", "/team-(.*)/=robot-$1", "/a=b/=c"} {
+		parsed, err := ParsePairingRule(rule)
+		suite.NoError(err, rule)
+		suite.Equal(rule, parsed.String())
+	}
+	for _, rule := range []string{"team-a", "=robot0", "team-a=", "[=robot0", "team-a=[", "/(/=robot0"} {
+		_, err := ParsePairingRule(rule)
+		suite.Error(err, rule)
+	}
+}
+
+func (suite *PairingSuite) TestRobotPattern() {
+	rule := suite.rules("/team-(?P<n>[0-9])-.*/=robot${n}")[0]
+	robot, ok := rule.robotPattern("team-3-foo")
+	suite.Require().True(ok)
+	suite.True(robot.match("robot3"))
+	suite.False(robot.match("robot4"))
+	_, ok = rule.robotPattern("team-a-foo")
+	suite.False(ok)
+}
+
+func (suite *PairingSuite) TestPairOnRegister() {
+	suite.register([]string{"robot0"}, []string{"team-b"})
+	NewPairer(suite.broker, suite.rules("team-a=robot0", "team-b=robot1"))
+	time.Sleep(closeTimeout)
+	suite.Empty(suite.pairs())
+	suite.register([]string{"robot1"}, []string{"team-a"})
+	time.Sleep(closeTimeout)
+	suite.Equal(map[string]string{"team-a": "robot0", "team-b": "robot1"}, suite.pairs())
+}
+
+func (suite *PairingSuite) TestPatternOrder() {
+	suite.register([]string{"robot1", "robot0", "spare"}, []string{"team-b", "team-a", "team-c"})
+	NewPairer(suite.broker, suite.rules("team-*=robot*", "team-*=spare"))
+	time.Sleep(closeTimeout)
+	suite.Equal(map[string]string{"team-a": "robot0", "team-b": "robot1", "team-c": "spare"}, suite.pairs())
+}
+
+func (suite *PairingSuite) TestRulesStatus() {
+	suite.register([]string{"robot0"}, []string{"team-a", "team-b"})
+	pairer := NewPairer(suite.broker, suite.rules("team-*=robot*"))
+	suite.Require().NoError(pairer.Add(suite.rules("other=robot0")[0]))
+	suite.Error(pairer.Add(suite.rules("other=robot0")[0]))
+	time.Sleep(closeTimeout)
+	rules := pairer.Rules()
+	suite.Require().Len(rules, 2)
+	suite.Equal("team-*=robot*", rules[0].Rule)
+	suite.Require().Len(rules[0].Pairs, 1)
+	suite.Equal("team-a", rules[0].Pairs[0].ClientName)
+	suite.Equal([]string{"team-b"}, rules[0].WaitingClients)
+	suite.Empty(rules[1].Pairs)
+
+	suite.NoError(pairer.Remove("team-*=robot*"))
+	suite.Error(pairer.Remove("team-*=robot*"))
+	suite.Require().NoError(suite.broker.DisconnectClientFromRobot("team-a"))
+	suite.register([]string{"robot1"}, nil)
+	time.Sleep(closeTimeout)
+	suite.Empty(suite.pairs())
+}
+
+func (suite *PairingSuite) TestDisconnectHoldsClient() {
+	suite.register([]string{"robot0"}, []string{"team-a"})
+	NewPairer(suite.broker, suite.rules("team-*=robot*"))
+	time.Sleep(closeTimeout)
+	suite.Equal(map[string]string{"team-a": "robot0"}, suite.pairs())
+
+	// The rule still applies, but the client isn't paired again
+	suite.Require().NoError(suite.broker.DisconnectClientFromRobot("team-a"))
+	suite.register([]string{"robot1"}, nil)
+	time.Sleep(closeTimeout)
+	suite.Empty(suite.pairs())
+
+	// Until it registers again
+	suite.Require().NoError(suite.broker.UnregisterClient("team-a"))
+	time.Sleep(closeTimeout)
+	suite.register(nil, []string{"team-a"})
+	time.Sleep(closeTimeout)
+	suite.Equal(map[string]string{"team-a": "robot0"}, suite.pairs())
+}
+
+func (suite *PairingSuite) TestSlowBindDoesNotBlockRules() {
+	// The robot's session doesn't pick its connection up until the end
+	robot := suite.broker.RegisterRobot("robot0", suite.globalCtx, nil)
+	suite.Require().NotNil(robot)
+	suite.register(nil, []string{"team-a"})
+	pairer := NewPairer(suite.broker, suite.rules("team-*=robot*"))
+	time.Sleep(closeTimeout)
+
+	done := make(chan struct{})
+	go func() {
+		pairer.Rules()
+		suite.NoError(pairer.Add(suite.rules("other=robot1")[0]))
+		pairer.Pending()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("Pairer blocked while binding")
+	}
+	<-robot.GetConnection()
+	time.Sleep(closeTimeout)
+	suite.Equal(map[string]string{"team-a": "robot0"}, suite.pairs())
+}
+
+func TestPairingSuite(t *testing.T) {
+	suite.Run(t, new(PairingSuite))
+}
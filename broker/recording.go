@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// File extension of session recordings
+const recordingExt = ".erebusrec"
+
+// Recorder records the traffic of every bound connection to a file per
+// connection while it is enabled. It is installed on the broker as an
+// interceptor which only observes messages.
+type Recorder struct {
+	broker *Broker
+
+	defaultDir string
+
+	mu         sync.Mutex
+	dir        string
+	enabled    bool
+	recordings map[*sessionRecording]struct{}
+}
+
+// sessionRecording records a single connection. Its file is opened lazily, so
+// that connections bound before recording was started are picked up too.
+type sessionRecording struct {
+	recorder *Recorder
+	info     ConnectionInfo
+	logger   *logrus.Entry
+
+	mu      sync.Mutex
+	file    *os.File
+	writer  *bufio.Writer
+	simTime float64
+}
+
+// NewRecorder creates a recorder and installs it on the broker. Recording
+// starts disabled, and records into defaultDir unless told otherwise.
+func NewRecorder(broker *Broker, defaultDir string) (*Recorder, error) {
+	r := &Recorder{
+		broker:     broker,
+		defaultDir: defaultDir,
+		recordings: make(map[*sessionRecording]struct{}),
+	}
+	if err := broker.AddInterceptor("recorder", r.intercept); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Start enables recording into dir, or the default directory if dir is empty.
+// Connections which are already bound are recorded from their next message
+// onwards.
+func (r *Recorder) Start(dir string) error {
+	if dir == "" {
+		dir = r.defaultDir
+	}
+	if dir == "" {
+		return errors.New("No recording directory given")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.enabled {
+		return errors.New("Already recording")
+	}
+	r.dir = dir
+	r.enabled = true
+	log.WithField("dir", dir).Info("Recording started")
+	return nil
+}
+
+// Stop disables recording and closes all open recordings
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	if !r.enabled {
+		r.mu.Unlock()
+		return errors.New("Not recording")
+	}
+	r.enabled = false
+	recordings := make([]*sessionRecording, 0, len(r.recordings))
+	for rec := range r.recordings {
+		recordings = append(recordings, rec)
+	}
+	// Recordings take r.mu while holding their own lock, so it can't be held
+	// while closing them
+	r.mu.Unlock()
+	for _, rec := range recordings {
+		rec.close()
+	}
+	log.Info("Recording stopped")
+	return nil
+}
+
+// IsRecording returns whether recording is enabled, and the directory
+// recordings are written to
+func (r *Recorder) IsRecording() (bool, string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled, r.dir
+}
+
+func (r *Recorder) intercept(ctx context.Context, info ConnectionInfo) Interceptor {
+	rec := &sessionRecording{
+		recorder: r,
+		info:     info,
+		logger: log.WithFields(logrus.Fields{
+			"robot":  info.RobotName,
+			"client": info.ClientName,
+		}),
+	}
+	r.mu.Lock()
+	r.recordings[rec] = struct{}{}
+	r.mu.Unlock()
+	rec.mu.Lock()
+	rec.open()
+	rec.mu.Unlock()
+	simStateChanges := r.broker.GetSimStateListener(ctx)
+	go func() {
+		for {
+			select {
+			case ssc := <-simStateChanges:
+				rec.write(&pb.RecordingEntry{Entry: &pb.RecordingEntry_SimStateChange{SimStateChange: ssc}})
+			case <-ctx.Done():
+				rec.write(&pb.RecordingEntry{Entry: &pb.RecordingEntry_Unbound_{Unbound: &pb.RecordingEntry_Unbound{}}})
+				r.mu.Lock()
+				delete(r.recordings, rec)
+				r.mu.Unlock()
+				rec.close()
+				return
+			}
+		}
+	}()
+	return InterceptorFuncs{
+		SensorsData: func(_ context.Context, sd *pb.SensorsData) *pb.SensorsData {
+			rec.write(&pb.RecordingEntry{Entry: &pb.RecordingEntry_SensorData{SensorData: sd}})
+			return sd
+		},
+		Commands: func(_ context.Context, cmd *pb.Commands) *pb.Commands {
+			rec.write(&pb.RecordingEntry{Entry: &pb.RecordingEntry_Commands{Commands: cmd}})
+			return cmd
+		},
+		PolicyCommands: func(_ context.Context, cmd *pb.Commands) {
+			rec.write(&pb.RecordingEntry{Entry: &pb.RecordingEntry_PolicyCommands{PolicyCommands: cmd}})
+		},
+	}
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// open opens the recording's file if recording is enabled and it is not yet
+// open, and reports whether the file is open. rec.mu must be held.
+func (rec *sessionRecording) open() bool {
+	if rec.file != nil {
+		return true
+	}
+	enabled, dir := rec.recorder.IsRecording()
+	if !enabled {
+		return false
+	}
+	name := fmt.Sprintf("%s_%s_%s%s",
+		time.Now().Format("20060102-150405"),
+		unsafeFilenameChars.ReplaceAllString(rec.info.RobotName, "_"),
+		unsafeFilenameChars.ReplaceAllString(rec.info.ClientName, "_"),
+		recordingExt,
+	)
+	path := filepath.Join(dir, name)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		rec.logger.Errorf("Couldn't create recording: %s", err.Error())
+		return false
+	}
+	rec.file = file
+	rec.writer = bufio.NewWriter(file)
+	rec.logger.WithField("file", path).Info("Recording connection")
+	// Every recording begins with the connection's details, even if it was
+	// started mid-session
+	if err := writeRecordingEntry(rec.writer, rec.stamp(&pb.RecordingEntry{Entry: &pb.RecordingEntry_Bound_{Bound: &pb.RecordingEntry_Bound{
+		RobotName:  rec.info.RobotName,
+		ClientName: rec.info.ClientName,
+		IsSync:     rec.info.IsSync,
+		RobotInfo:  rec.info.RobotInfo,
+	}}})); err != nil {
+		rec.logger.Errorf("Couldn't write to recording: %s", err.Error())
+	}
+	return true
+}
+
+func (rec *sessionRecording) stamp(entry *pb.RecordingEntry) *pb.RecordingEntry {
+	entry.Time, _ = ptypes.TimestampProto(time.Now())
+	entry.SimTime = rec.simTime
+	return entry
+}
+
+func (rec *sessionRecording) write(entry *pb.RecordingEntry) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if sd := entry.GetSensorData(); sd != nil {
+		rec.simTime = sd.GetTimestamp()
+	}
+	if !rec.open() {
+		return
+	}
+	err := writeRecordingEntry(rec.writer, rec.stamp(entry))
+	if err == nil {
+		err = rec.writer.Flush()
+	}
+	if err != nil {
+		rec.logger.Errorf("Couldn't write to recording: %s", err.Error())
+	}
+}
+
+// close flushes and closes the recording's file, if it is open
+func (rec *sessionRecording) close() {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.file == nil {
+		return
+	}
+	if err := rec.writer.Flush(); err != nil {
+		rec.logger.Errorf("Couldn't flush recording: %s", err.Error())
+	}
+	if err := rec.file.Sync(); err != nil {
+		rec.logger.Errorf("Couldn't sync recording: %s", err.Error())
+	}
+	if err := rec.file.Close(); err != nil {
+		rec.logger.Errorf("Couldn't close recording: %s", err.Error())
+	}
+	rec.file = nil
+	rec.writer = nil
+}
+
+// writeRecordingEntry writes a length-delimited recording entry
+func writeRecordingEntry(w io.Writer, entry *pb.RecordingEntry) error {
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(proto.EncodeVarint(uint64(len(data)))); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// readRecordingEntry reads a length-delimited recording entry, returning
+// io.EOF at the end of the recording
+func readRecordingEntry(r *bufio.Reader) (*pb.RecordingEntry, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	entry := &pb.RecordingEntry{}
+	if err := proto.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
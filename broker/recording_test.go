@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type RecordingSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	dir            string
+}
+
+func (suite *RecordingSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+	dir, err := ioutil.TempDir("", "erebus-recording")
+	suite.Require().NoError(err)
+	suite.dir = dir
+}
+
+func (suite *RecordingSuite) TearDownTest() {
+	suite.globalCtxClose()
+	os.RemoveAll(suite.dir)
+}
+
+func (suite *RecordingSuite) readRecordings() [][]*pb.RecordingEntry {
+	paths, err := filepath.Glob(filepath.Join(suite.dir, "*"+recordingExt))
+	suite.Require().NoError(err)
+	var recordings [][]*pb.RecordingEntry
+	for _, path := range paths {
+		file, err := os.Open(path)
+		suite.Require().NoError(err)
+		reader := bufio.NewReader(file)
+		var entries []*pb.RecordingEntry
+		for {
+			entry, err := readRecordingEntry(reader)
+			if err == io.EOF {
+				break
+			}
+			suite.Require().NoError(err)
+			entries = append(entries, entry)
+		}
+		file.Close()
+		recordings = append(recordings, entries)
+	}
+	return recordings
+}
+
+func (suite *RecordingSuite) TestEntryRoundTrip() {
+	var buf bytes.Buffer
+	entries := []*pb.RecordingEntry{
+		{SimTime: 1, Entry: &pb.RecordingEntry_SensorData{SensorData: &pb.SensorsData{Timestamp: 1}}},
+		{SimTime: 1, Entry: &pb.RecordingEntry_Commands{Commands: &pb.Commands{}}},
+	}
+	for _, entry := range entries {
+		suite.Require().NoError(writeRecordingEntry(&buf, entry))
+	}
+	reader := bufio.NewReader(&buf)
+	for _, entry := range entries {
+		read, err := readRecordingEntry(reader)
+		suite.Require().NoError(err)
+		suite.Equal(entry.GetSimTime(), read.GetSimTime())
+	}
+	_, err := readRecordingEntry(reader)
+	suite.Equal(io.EOF, err)
+}
+
+func (suite *RecordingSuite) TestRecordConnection() {
+	recorder, err := NewRecorder(suite.broker, suite.dir)
+	suite.Require().NoError(err)
+	suite.Require().NoError(recorder.Start(""))
+	info := &pb.RobotInfo{MotorInfos: []*pb.MotorInfo{{Name: "left wheel"}}}
+	robot := suite.broker.RegisterRobot("robot", suite.globalCtx, info)
+	client := suite.broker.RegisterClient("client", suite.globalCtx, false)
+	robotConns := make(chan RobotConnection, 1)
+	clientConns := make(chan ClientConnection, 1)
+	go func() { robotConns <- <-robot.GetConnection() }()
+	go func() { clientConns <- <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	robotConn, clientConn := <-robotConns, <-clientConns
+	robotConn.SdOut <- &pb.SensorsData{Timestamp: 1.5}
+	<-clientConn.SdIn
+	clientConn.CmdOut <- &pb.Commands{}
+	<-robotConn.CmdIn
+	suite.Require().NoError(suite.broker.DisconnectClientFromRobot("client"))
+	<-robotConn.Ctx.Done()
+	time.Sleep(closeTimeout)
+
+	recordings := suite.readRecordings()
+	suite.Require().Len(recordings, 1)
+	entries := recordings[0]
+	suite.Require().Len(entries, 4)
+	suite.Equal("robot", entries[0].GetBound().GetRobotName())
+	suite.Equal("client", entries[0].GetBound().GetClientName())
+	suite.True(proto.Equal(info, entries[0].GetBound().GetRobotInfo()))
+	suite.Equal(1.5, entries[1].GetSensorData().GetTimestamp())
+	suite.NotNil(entries[2].GetCommands())
+	suite.Equal(1.5, entries[2].GetSimTime())
+	suite.NotNil(entries[3].GetUnbound())
+}
+
+func (suite *RecordingSuite) TestRecordPolicyCommands() {
+	suite.broker.simInfo.syncTimeout = closeTimeout
+	recorder, err := NewRecorder(suite.broker, suite.dir)
+	suite.Require().NoError(err)
+	suite.Require().NoError(recorder.Start(""))
+	robot := suite.broker.RegisterRobot("robot", suite.globalCtx, nil)
+	client := suite.broker.RegisterClient("client", suite.globalCtx, true)
+	robotConns := make(chan RobotConnection, 1)
+	clientConns := make(chan ClientConnection, 1)
+	go func() { robotConns <- <-robot.GetConnection() }()
+	go func() { clientConns <- <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	robotConn, clientConn := <-robotConns, <-clientConns
+	robotConn.SdOut <- &pb.SensorsData{Timestamp: 1.5}
+	<-clientConn.SdIn
+	// The client doesn't answer, so the sync policy does
+	<-robotConn.CmdIn
+	suite.Require().NoError(suite.broker.DisconnectClientFromRobot("client"))
+	<-robotConn.Ctx.Done()
+	time.Sleep(closeTimeout)
+
+	recordings := suite.readRecordings()
+	suite.Require().Len(recordings, 1)
+	entries := recordings[0]
+	suite.Require().Len(entries, 4)
+	suite.Nil(entries[2].GetCommands())
+	suite.NotNil(entries[2].GetPolicyCommands())
+	suite.Empty(recordedFrames(entries)[0].commands)
+}
+
+func (suite *RecordingSuite) TestStopRecording() {
+	recorder, err := NewRecorder(suite.broker, suite.dir)
+	suite.Require().NoError(err)
+	suite.Error(recorder.Stop())
+	suite.Require().NoError(recorder.Start(""))
+	suite.Error(recorder.Start(""))
+	suite.NoError(recorder.Stop())
+	recording, _ := recorder.IsRecording()
+	suite.False(recording)
+}
+
+func TestRecordingSuite(t *testing.T) {
+	suite.Run(t, new(RecordingSuite))
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// RegressionOptions controls how a client's commands are compared against a
+// recording
+type RegressionOptions struct {
+	// Name of the replay robot; empty to use the name of the recorded robot
+	RobotName string
+	// How long to wait for the client to register; 0 waits indefinitely
+	ClientTimeout time.Duration
+	// Largest difference in motor velocity which is not reported
+	VelocityTolerance float64
+	// Largest amount by which the client may be slower to answer a frame than
+	// in the recording; 0 disables timing comparison
+	LatencyTolerance time.Duration
+}
+
+// CommandDiffKind is the kind of difference between a recorded and a replayed
+// command
+type CommandDiffKind int
+
+const (
+	// CommandMissing is a device commanded in the recording but not the replay
+	CommandMissing CommandDiffKind = iota + 1
+	// CommandExtra is a device commanded in the replay but not the recording
+	CommandExtra
+	// CommandVelocity is a motor commanded to a different velocity
+	CommandVelocity
+	// CommandLED is an LED commanded to a different state
+	CommandLED
+	// CommandType is a device sent a different type of command
+	CommandType
+)
+
+// CommandDiff is a difference between the commands sent to a single device
+type CommandDiff struct {
+	Kind     CommandDiffKind
+	Device   string
+	Expected *pb.Command // nil for CommandExtra
+	Actual   *pb.Command // nil for CommandMissing
+	// Actual minus expected velocity, for CommandVelocity
+	VelocityDelta float64
+}
+
+// FrameDiff describes how the client's answer to a single sensor frame
+// differed from the recording
+type FrameDiff struct {
+	Index     int
+	Timestamp float64
+	// Set if the client did not answer the frame in time; no command
+	// differences are reported for the frame
+	MissedReply     bool
+	ExpectedLatency time.Duration
+	ActualLatency   time.Duration
+	LatencyExceeded bool
+	Commands        []CommandDiff
+}
+
+func (d *FrameDiff) failed() bool {
+	return d.MissedReply || d.LatencyExceeded || len(d.Commands) > 0
+}
+
+// RegressionReport is the result of replaying a recording to a client
+type RegressionReport struct {
+	RobotName string
+	Frames    int
+	// Frames with at least one difference, in order
+	Failures            []FrameDiff
+	MaxVelocityDelta    float64
+	MeanExpectedLatency time.Duration
+	MeanActualLatency   time.Duration
+	MaxActualLatency    time.Duration
+}
+
+// Passed returns whether the replay matched the recording
+func (r *RegressionReport) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// recordedFrame is a sensor frame in a recording, and the commands the client
+// answered it with
+type recordedFrame struct {
+	timestamp float64
+	// Commands by device name, merging all the commands sent before the next
+	// frame
+	commands map[string]*pb.Command
+	// Time between the frame and the first commands sent after it, or -1 if
+	// there were none or the recording has no wall-clock times
+	latency time.Duration
+}
+
+// replayedFrame is the client's answer to a sensor frame during a regression
+type replayedFrame struct {
+	sent     time.Time
+	answered bool
+	latency  time.Duration
+	commands map[string]*pb.Command
+}
+
+// recordedFrames extracts the sensor frames of a recording with their replies
+func recordedFrames(entries []*pb.RecordingEntry) []recordedFrame {
+	var frames []recordedFrame
+	var frameTime time.Time
+	for _, entry := range entries {
+		entryTime, err := ptypes.Timestamp(entry.GetTime())
+		hasTime := err == nil && entry.GetTime() != nil
+		if sd := entry.GetSensorData(); sd != nil {
+			frames = append(frames, recordedFrame{
+				timestamp: sd.GetTimestamp(),
+				commands:  make(map[string]*pb.Command),
+				latency:   -1,
+			})
+			frameTime = time.Time{}
+			if hasTime {
+				frameTime = entryTime
+			}
+			continue
+		}
+		cmds := entry.GetCommands()
+		if cmds == nil || len(frames) == 0 {
+			continue
+		}
+		frame := &frames[len(frames)-1]
+		if frame.latency < 0 && hasTime && !frameTime.IsZero() {
+			frame.latency = entryTime.Sub(frameTime)
+		}
+		for _, cmd := range cmds.GetCommands() {
+			frame.commands[cmd.GetName()] = cmd
+		}
+	}
+	return frames
+}
+
+// diffCommands compares the commands sent to each device, in device order
+func diffCommands(expected, actual map[string]*pb.Command, tolerance float64) []CommandDiff {
+	devices := make(map[string]struct{})
+	for name := range expected {
+		devices[name] = struct{}{}
+	}
+	for name := range actual {
+		devices[name] = struct{}{}
+	}
+	names := make([]string, 0, len(devices))
+	for name := range devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var diffs []CommandDiff
+	for _, name := range names {
+		exp, act := expected[name], actual[name]
+		diff := CommandDiff{Device: name, Expected: exp, Actual: act}
+		switch {
+		case act == nil:
+			diff.Kind = CommandMissing
+		case exp == nil:
+			diff.Kind = CommandExtra
+		case exp.GetMotorCommand() != nil && act.GetMotorCommand() != nil:
+			diff.VelocityDelta = act.GetMotorCommand().GetVelocity() - exp.GetMotorCommand().GetVelocity()
+			if math.Abs(diff.VelocityDelta) <= tolerance {
+				continue
+			}
+			diff.Kind = CommandVelocity
+		case exp.GetLedCommand() != nil && act.GetLedCommand() != nil:
+			if exp.GetLedCommand().GetState() == act.GetLedCommand().GetState() {
+				continue
+			}
+			diff.Kind = CommandLED
+		default:
+			if proto.Equal(exp, act) {
+				continue
+			}
+			diff.Kind = CommandType
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// awaitClient waits until a client with the given name is registered
+func (b *Broker) awaitClient(ctx context.Context, name string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := b.GetEventQueue(ctx)
+	if _, ok := b.clientRequestsSync(name); ok {
+		return nil
+	}
+	for {
+		select {
+		case event := <-events:
+			if event.Type == EventClientRegistered && event.ClientName == name {
+				return nil
+			}
+		case <-ctx.Done():
+			return errors.New("Client did not register")
+		}
+	}
+}
+
+// Regress replays the recording at path to the named client in lockstep, and
+// compares the commands it answers each frame with against the recorded ones.
+// It waits for the client to register if it hasn't yet, and stops the replay if
+// ctx is done before it finishes. The client must request lockstep mode, so
+// that every frame is answered exactly once.
+func (r *Replayer) Regress(ctx context.Context, path string, clientName string, opts RegressionOptions) (*RegressionReport, error) {
+	entries, err := LoadRecording(path)
+	if err != nil {
+		return nil, err
+	}
+	expected := recordedFrames(entries)
+	awaitCtx, cancel := ctx, context.CancelFunc(func() {})
+	if opts.ClientTimeout > 0 {
+		awaitCtx, cancel = context.WithTimeout(ctx, opts.ClientTimeout)
+	}
+	err = r.broker.awaitClient(awaitCtx, clientName)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if requestsSync, _ := r.broker.clientRequestsSync(clientName); !requestsSync {
+		return nil, errors.New("Client must be in sync mode")
+	}
+	robot, err := r.start(opts.RobotName, entries, ReplayOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var actual []replayedFrame
+	interceptorName := "regress:" + robot.name
+	if err := r.broker.AddInterceptor(interceptorName, func(_ context.Context, info ConnectionInfo) Interceptor {
+		if info.RobotName != robot.name {
+			return nil
+		}
+		return InterceptorFuncs{
+			SensorsData: func(_ context.Context, sd *pb.SensorsData) *pb.SensorsData {
+				mu.Lock()
+				defer mu.Unlock()
+				actual = append(actual, replayedFrame{sent: time.Now()})
+				return sd
+			},
+			Commands: func(_ context.Context, cmd *pb.Commands) *pb.Commands {
+				mu.Lock()
+				defer mu.Unlock()
+				if len(actual) == 0 {
+					return cmd
+				}
+				frame := &actual[len(actual)-1]
+				if !frame.answered {
+					frame.answered = true
+					frame.latency = time.Since(frame.sent)
+					frame.commands = make(map[string]*pb.Command)
+					for _, c := range cmd.GetCommands() {
+						frame.commands[c.GetName()] = c
+					}
+				}
+				return cmd
+			},
+		}
+	}); err != nil {
+		robot.handle.cancel()
+		return nil, err
+	}
+	defer r.broker.RemoveInterceptor(interceptorName)
+
+	if err := r.broker.ConnectClientToRobot(clientName, robot.name); err != nil {
+		robot.handle.cancel()
+		return nil, err
+	}
+	select {
+	case <-robot.handle.ctx.Done():
+	case <-ctx.Done():
+		robot.handle.cancel()
+		return nil, errors.New("Regression cancelled")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(actual) < len(expected) {
+		return nil, errors.New("Replay was stopped before it finished")
+	}
+	return buildRegressionReport(robot.name, expected, actual, opts), nil
+}
+
+// buildRegressionReport compares the replayed frames against the recorded ones
+func buildRegressionReport(robotName string, expected []recordedFrame, actual []replayedFrame, opts RegressionOptions) *RegressionReport {
+	report := &RegressionReport{RobotName: robotName, Frames: len(expected)}
+	var expectedTotal, actualTotal time.Duration
+	var expectedCount, actualCount int
+	for i, exp := range expected {
+		act := actual[i]
+		diff := FrameDiff{
+			Index:           i,
+			Timestamp:       exp.timestamp,
+			MissedReply:     !act.answered,
+			ExpectedLatency: exp.latency,
+			ActualLatency:   act.latency,
+		}
+		if exp.latency >= 0 {
+			expectedTotal += exp.latency
+			expectedCount++
+		}
+		if act.answered {
+			actualTotal += act.latency
+			actualCount++
+			if act.latency > report.MaxActualLatency {
+				report.MaxActualLatency = act.latency
+			}
+			if opts.LatencyTolerance > 0 && exp.latency >= 0 && act.latency-exp.latency > opts.LatencyTolerance {
+				diff.LatencyExceeded = true
+			}
+			diff.Commands = diffCommands(exp.commands, act.commands, opts.VelocityTolerance)
+			for _, cmdDiff := range diff.Commands {
+				if delta := math.Abs(cmdDiff.VelocityDelta); delta > report.MaxVelocityDelta {
+					report.MaxVelocityDelta = delta
+				}
+			}
+		}
+		if diff.failed() {
+			report.Failures = append(report.Failures, diff)
+		}
+	}
+	if expectedCount > 0 {
+		report.MeanExpectedLatency = expectedTotal / time.Duration(expectedCount)
+	}
+	if actualCount > 0 {
+		report.MeanActualLatency = actualTotal / time.Duration(actualCount)
+	}
+	return report
+}
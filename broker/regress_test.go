@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type RegressSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	replayer       *Replayer
+	dir            string
+	recording      string
+}
+
+func wheelCommands(velocities map[string]float64) *pb.Commands {
+	cmds := &pb.Commands{}
+	for name, velocity := range velocities {
+		cmds.Commands = append(cmds.Commands, &pb.Command{
+			Name:    name,
+			Command: &pb.Command_MotorCommand_{MotorCommand: &pb.Command_MotorCommand{Velocity: velocity}},
+		})
+	}
+	return cmds
+}
+
+func (suite *RegressSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32, syncTimeout: time.Second})
+	suite.replayer = NewReplayer(suite.broker)
+	dir, err := ioutil.TempDir("", "erebus-regress")
+	suite.Require().NoError(err)
+	suite.dir = dir
+	suite.recording = filepath.Join(dir, "test"+recordingExt)
+	file, err := os.Create(suite.recording)
+	suite.Require().NoError(err)
+	start := time.Now()
+	at := func(offset time.Duration) *pb.RecordingEntry {
+		ts, _ := ptypes.TimestampProto(start.Add(offset))
+		return &pb.RecordingEntry{Time: ts}
+	}
+	entries := []*pb.RecordingEntry{
+		{Entry: &pb.RecordingEntry_Bound_{Bound: &pb.RecordingEntry_Bound{RobotName: "robot", ClientName: "client", IsSync: true}}},
+		{Entry: &pb.RecordingEntry_SensorData{SensorData: &pb.SensorsData{Timestamp: 0.032}}},
+		{Entry: &pb.RecordingEntry_Commands{Commands: wheelCommands(map[string]float64{"left": 1, "right": 1})}},
+		{Entry: &pb.RecordingEntry_SensorData{SensorData: &pb.SensorsData{Timestamp: 0.064}}},
+		{Entry: &pb.RecordingEntry_Commands{Commands: wheelCommands(map[string]float64{"left": 2, "right": 2})}},
+	}
+	for i, entry := range entries {
+		stamped := at(time.Duration(i) * time.Millisecond)
+		stamped.Entry = entry.Entry
+		suite.Require().NoError(writeRecordingEntry(file, stamped))
+	}
+	suite.Require().NoError(file.Close())
+}
+
+func (suite *RegressSuite) TearDownTest() {
+	suite.globalCtxClose()
+	os.RemoveAll(suite.dir)
+}
+
+// runClient registers a sync client which answers each frame with the next of
+// replies, and ignores frames once it runs out
+func (suite *RegressSuite) runClient(replies ...*pb.Commands) {
+	client := suite.broker.RegisterClient("client", suite.globalCtx, true)
+	suite.Require().NotNil(client)
+	go func() {
+		conn := <-client.GetConnection()
+		for _, reply := range replies {
+			select {
+			case <-conn.SdIn:
+			case <-conn.Ctx.Done():
+				return
+			}
+			select {
+			case conn.CmdOut <- reply:
+			case <-conn.Ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case <-conn.SdIn:
+			case <-conn.Ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (suite *RegressSuite) TestMatchingClient() {
+	suite.runClient(
+		wheelCommands(map[string]float64{"left": 1, "right": 1}),
+		wheelCommands(map[string]float64{"left": 2, "right": 2.005}),
+	)
+	report, err := suite.replayer.Regress(suite.globalCtx, suite.recording, "client", RegressionOptions{VelocityTolerance: 0.01})
+	suite.Require().NoError(err)
+	suite.Equal("robot", report.RobotName)
+	suite.Equal(2, report.Frames)
+	suite.True(report.Passed(), "%+v", report.Failures)
+	suite.Equal(time.Millisecond, report.MeanExpectedLatency)
+	time.Sleep(closeTimeout)
+	suite.NotContains(suite.broker.GetRobotNames(), "robot")
+}
+
+func (suite *RegressSuite) TestDifferingClient() {
+	suite.runClient(
+		wheelCommands(map[string]float64{"left": 1, "back": 1}),
+		wheelCommands(map[string]float64{"left": 1.5, "right": 2}),
+	)
+	report, err := suite.replayer.Regress(suite.globalCtx, suite.recording, "client", RegressionOptions{})
+	suite.Require().NoError(err)
+	suite.False(report.Passed())
+	suite.Require().Len(report.Failures, 2)
+
+	first := report.Failures[0]
+	suite.Equal(0, first.Index)
+	suite.Require().Len(first.Commands, 2)
+	suite.Equal("back", first.Commands[0].Device)
+	suite.Equal(CommandExtra, first.Commands[0].Kind)
+	suite.Equal("right", first.Commands[1].Device)
+	suite.Equal(CommandMissing, first.Commands[1].Kind)
+
+	second := report.Failures[1]
+	suite.Equal(0.064, second.Timestamp)
+	suite.Require().Len(second.Commands, 1)
+	suite.Equal(CommandVelocity, second.Commands[0].Kind)
+	suite.Equal(-0.5, second.Commands[0].VelocityDelta)
+	suite.Equal(0.5, report.MaxVelocityDelta)
+}
+
+func (suite *RegressSuite) TestMissedReply() {
+	suite.broker.simInfo.syncTimeout = closeTimeout
+	suite.runClient(wheelCommands(map[string]float64{"left": 1, "right": 1}))
+	report, err := suite.replayer.Regress(suite.globalCtx, suite.recording, "client", RegressionOptions{})
+	suite.Require().NoError(err)
+	suite.Require().Len(report.Failures, 1)
+	suite.Equal(1, report.Failures[0].Index)
+	suite.True(report.Failures[0].MissedReply)
+}
+
+func (suite *RegressSuite) TestWaitsForClient() {
+	go func() {
+		time.Sleep(closeTimeout)
+		suite.runClient(
+			wheelCommands(map[string]float64{"left": 1, "right": 1}),
+			wheelCommands(map[string]float64{"left": 2, "right": 2}),
+		)
+	}()
+	report, err := suite.replayer.Regress(suite.globalCtx, suite.recording, "client", RegressionOptions{ClientTimeout: time.Second})
+	suite.Require().NoError(err)
+	suite.True(report.Passed())
+}
+
+func (suite *RegressSuite) TestClientTimeout() {
+	_, err := suite.replayer.Regress(suite.globalCtx, suite.recording, "client", RegressionOptions{ClientTimeout: closeTimeout})
+	suite.Error(err)
+	suite.Empty(suite.broker.GetRobotNames())
+}
+
+func (suite *RegressSuite) TestAsyncClient() {
+	suite.Require().NotNil(suite.broker.RegisterClient("client", suite.globalCtx, false))
+	_, err := suite.replayer.Regress(suite.globalCtx, suite.recording, "client", RegressionOptions{})
+	suite.Error(err)
+	suite.Empty(suite.broker.GetRobotNames())
+}
+
+func TestRegressSuite(t *testing.T) {
+	suite.Run(t, new(RegressSuite))
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// relay carries sensor data and commands between the sessions of a robot and
+// a client bound to each other, passing them through the connection's
+// interceptors, and dropping sensor entries the client hasn't asked for
+type relay struct {
+	ctx      context.Context
+	logger   *logrus.Entry
+	chain    interceptorChain
+	sampling *SensorSampling
+	tap      *trafficTap
+
+	sdIn   <-chan *pb.SensorsData // from the robot
+	sdOut  chan<- *pb.SensorsData // to the client
+	cmdIn  <-chan *pb.Commands    // from the client
+	cmdOut chan<- *pb.Commands    // to the robot
+}
+
+// runAsync forwards sensor data and commands as soon as they arrive
+func (r *relay) runAsync() {
+	go func() {
+		for {
+			select {
+			case cmd := <-r.cmdIn:
+				if cmd = r.chain.commands(r.ctx, cmd); cmd == nil {
+					continue
+				}
+				r.tap.commands(cmd)
+				select {
+				case r.cmdOut <- cmd:
+				case <-r.ctx.Done():
+					return
+				}
+			case <-r.ctx.Done():
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case sd := <-r.sdIn:
+			if sd = r.chain.sensorsData(r.ctx, sd); sd == nil {
+				continue
+			}
+			r.tap.sensorsData(sd)
+			sd = r.sampling.filter(sd)
+			select {
+			case r.sdOut <- sd:
+			case <-r.ctx.Done():
+				return
+			}
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// runSync forwards sensor data and commands in lockstep: each sensor frame is
+// answered by exactly one commands message to the robot, which is the client's
+// reply if it arrives within timeout, and is otherwise decided by policy.
+// Replies arriving after their frame has timed out are discarded. A frame or
+// reply dropped by an interceptor is also answered by policy, but isn't the
+// client's fault, so isn't reported as a missed deadline.
+func (r *relay) runSync(timeout time.Duration, policy SyncPolicy) {
+	var last *pb.Commands
+	for {
+		var sd *pb.SensorsData
+	LAwaitFrame:
+		for {
+			select {
+			case sd = <-r.sdIn:
+				break LAwaitFrame
+			case <-r.cmdIn:
+				r.logger.Debug("Discarding late commands from client")
+			case <-r.ctx.Done():
+				return
+			}
+		}
+		var reply *pb.Commands
+		timedOut := false
+		if forwarded := r.chain.sensorsData(r.ctx, sd); forwarded != nil {
+			r.tap.sensorsData(forwarded)
+			select {
+			case r.sdOut <- r.sampling.filter(forwarded):
+			case <-r.ctx.Done():
+				return
+			}
+			reply, timedOut = r.awaitReply(timeout)
+			if reply != nil {
+				last = reply
+			}
+		}
+		if r.ctx.Err() != nil {
+			return
+		}
+		if reply == nil {
+			logger := r.logger.WithFields(logrus.Fields{
+				"timestamp": sd.GetTimestamp(),
+				"policy":    policy,
+			})
+			if timedOut {
+				logger.Warn("Client missed tick deadline")
+			} else {
+				logger.Debug("Tick dropped by an interceptor")
+			}
+			reply = policy.commands(last)
+			r.chain.policyCommands(r.ctx, reply)
+			r.tap.policyCommands(reply)
+		} else {
+			r.tap.commands(reply)
+		}
+		select {
+		case r.cmdOut <- reply:
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// awaitReply waits up to timeout for the client's reply, returning it once it
+// has made it through the interceptors. It returns nil if an interceptor
+// dropped the reply, and also reports whether the client didn't reply in time.
+func (r *relay) awaitReply(timeout time.Duration) (reply *pb.Commands, timedOut bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case cmd := <-r.cmdIn:
+		return r.chain.commands(r.ctx, cmd), false
+	case <-timer.C:
+		return nil, true
+	case <-r.ctx.Done():
+		return nil, false
+	}
+}
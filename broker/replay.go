@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// ReplayOptions controls how a recording is played back
+type ReplayOptions struct {
+	// Playback speed relative to the recording's sim time; 0 plays frames as
+	// fast as the client accepts them
+	Speed float64
+	// If set, frames are only played when released by Step, and Speed is
+	// ignored
+	StepMode bool
+}
+
+// Maximum number of frames which can be released by Step ahead of playback
+const maxPendingSteps = 1024
+
+// Replayer manages virtual robots which play back recorded sensor data to
+// clients, in place of a robot running in Webots
+type Replayer struct {
+	broker *Broker
+
+	mu     sync.Mutex
+	robots map[string]*replayRobot
+}
+
+type replayRobot struct {
+	name   string
+	frames []*pb.SensorsData
+	opts   ReplayOptions
+	handle *RobotHandle
+	steps  chan struct{}
+	logger *logrus.Entry
+}
+
+// NewReplayer creates a replayer for the given broker
+func NewReplayer(broker *Broker) *Replayer {
+	return &Replayer{
+		broker: broker,
+		robots: make(map[string]*replayRobot),
+	}
+}
+
+// LoadRecording reads all entries of the session recording at path
+func LoadRecording(path string) ([]*pb.RecordingEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	var entries []*pb.RecordingEntry
+	for {
+		entry, err := readRecordingEntry(reader)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// recordedBound returns the details of the connection in a recording, or nil
+// if it has none
+func recordedBound(entries []*pb.RecordingEntry) *pb.RecordingEntry_Bound {
+	for _, entry := range entries {
+		if bound := entry.GetBound(); bound != nil {
+			return bound
+		}
+	}
+	return nil
+}
+
+// Start registers a virtual robot which plays back the sensor data in the
+// recording at path once a client is connected to it. If name is empty, the
+// robot takes the name of the robot in the recording. The robot unregisters
+// itself when playback is finished.
+func (r *Replayer) Start(name string, path string, opts ReplayOptions) (string, error) {
+	entries, err := LoadRecording(path)
+	if err != nil {
+		return "", err
+	}
+	robot, err := r.start(name, entries, opts)
+	if err != nil {
+		return "", err
+	}
+	return robot.name, nil
+}
+
+// start registers a replay robot for the recording entries
+func (r *Replayer) start(name string, entries []*pb.RecordingEntry, opts ReplayOptions) (*replayRobot, error) {
+	if opts.Speed < 0 {
+		return nil, errors.New("Replay speed can't be negative")
+	}
+	bound := recordedBound(entries)
+	if name == "" {
+		name = bound.GetRobotName()
+	}
+	if name == "" {
+		return nil, errors.New("No robot name given, and none in recording")
+	}
+	var frames []*pb.SensorsData
+	for _, entry := range entries {
+		if sd := entry.GetSensorData(); sd != nil {
+			frames = append(frames, sd)
+		}
+	}
+	if len(frames) == 0 {
+		return nil, errors.New("Recording contains no sensor data")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	handle := r.broker.RegisterRobot(name, r.broker.ctx, bound.GetRobotInfo())
+	if handle == nil {
+		return nil, errors.New("Robot name in use")
+	}
+	robot := &replayRobot{
+		name:   name,
+		frames: frames,
+		opts:   opts,
+		handle: handle,
+		steps:  make(chan struct{}, maxPendingSteps),
+		logger: log.WithField("robot", name),
+	}
+	r.robots[name] = robot
+	go func() {
+		robot.run()
+		r.broker.UnregisterRobot(name)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.robots, name)
+	}()
+	robot.logger.WithField("frames", len(frames)).Info("Replay robot started")
+	return robot, nil
+}
+
+// Step releases the next count frames of a replay robot in step mode. Frames
+// released before a client is bound are played once it is.
+func (r *Replayer) Step(name string, count int) error {
+	r.mu.Lock()
+	robot, ok := r.robots[name]
+	r.mu.Unlock()
+	if !ok {
+		return errors.New("Replay robot not found")
+	}
+	if !robot.opts.StepMode {
+		return errors.New("Replay robot is not in step mode")
+	}
+	if robot.handle.ctx.Err() != nil {
+		return errors.New("Replay robot finished")
+	}
+	for i := 0; i < count; i++ {
+		select {
+		case robot.steps <- struct{}{}:
+		default:
+			return errors.New("Too many frames pending")
+		}
+	}
+	return nil
+}
+
+// Stop ends playback and unregisters a replay robot
+func (r *Replayer) Stop(name string) error {
+	r.mu.Lock()
+	robot, ok := r.robots[name]
+	r.mu.Unlock()
+	if !ok {
+		return errors.New("Replay robot not found")
+	}
+	robot.handle.cancel()
+	return nil
+}
+
+// GetRobotNames returns the names of all running replay robots
+func (r *Replayer) GetRobotNames() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.robots))
+	for name := range r.robots {
+		names = append(names, name)
+	}
+	return names
+}
+
+// run waits for the robot to be bound and plays back its frames
+func (robot *replayRobot) run() {
+	ctx := robot.handle.ctx
+	var connection RobotConnection
+	robot.logger.Debug("Replay robot waiting for peer")
+	select {
+	case connection = <-robot.handle.GetConnection():
+	case <-ctx.Done():
+		return
+	}
+	robot.logger.Debug("Replay robot got peer")
+	// Commands are drained continuously for async connections; sync
+	// connections are answered exactly once per frame
+	if !connection.IsSync {
+		go func() {
+			for {
+				select {
+				case <-connection.CmdIn:
+				case <-connection.Ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	var lastTimestamp float64
+	for i, sd := range robot.frames {
+		if robot.opts.StepMode {
+			select {
+			case <-robot.steps:
+			case <-connection.Ctx.Done():
+				return
+			}
+		} else if i > 0 && robot.opts.Speed > 0 {
+			delay := time.Duration((sd.GetTimestamp() - lastTimestamp) / robot.opts.Speed * float64(time.Second))
+			select {
+			case <-time.After(delay):
+			case <-connection.Ctx.Done():
+				return
+			}
+		}
+		lastTimestamp = sd.GetTimestamp()
+		select {
+		case connection.SdOut <- sd:
+		case <-connection.Ctx.Done():
+			return
+		}
+		if connection.IsSync {
+			select {
+			case <-connection.CmdIn:
+			case <-connection.Ctx.Done():
+				return
+			}
+		}
+	}
+	robot.logger.Info("Replay finished")
+}
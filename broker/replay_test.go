@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type ReplaySuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	replayer       *Replayer
+	dir            string
+	recording      string
+}
+
+func (suite *ReplaySuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32, syncTimeout: time.Second})
+	suite.replayer = NewReplayer(suite.broker)
+	dir, err := ioutil.TempDir("", "erebus-replay")
+	suite.Require().NoError(err)
+	suite.dir = dir
+	suite.recording = filepath.Join(dir, "test"+recordingExt)
+	file, err := os.Create(suite.recording)
+	suite.Require().NoError(err)
+	entries := []*pb.RecordingEntry{
+		{Entry: &pb.RecordingEntry_Bound_{Bound: &pb.RecordingEntry_Bound{RobotName: "robot", ClientName: "client"}}},
+		{Entry: &pb.RecordingEntry_SensorData{SensorData: &pb.SensorsData{Timestamp: 0.032}}},
+		{Entry: &pb.RecordingEntry_Commands{Commands: &pb.Commands{}}},
+		{Entry: &pb.RecordingEntry_SensorData{SensorData: &pb.SensorsData{Timestamp: 0.064}}},
+		{Entry: &pb.RecordingEntry_Commands{Commands: &pb.Commands{}}},
+		{Entry: &pb.RecordingEntry_Unbound_{Unbound: &pb.RecordingEntry_Unbound{}}},
+	}
+	for _, entry := range entries {
+		suite.Require().NoError(writeRecordingEntry(file, entry))
+	}
+	suite.Require().NoError(file.Close())
+}
+
+func (suite *ReplaySuite) TearDownTest() {
+	suite.globalCtxClose()
+	os.RemoveAll(suite.dir)
+}
+
+func (suite *ReplaySuite) connectClient(robotName string) ClientConnection {
+	client := suite.broker.RegisterClient("client", suite.globalCtx, true)
+	suite.Require().NotNil(client)
+	clientConns := make(chan ClientConnection, 1)
+	go func() { clientConns <- <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", robotName))
+	return <-clientConns
+}
+
+func (suite *ReplaySuite) TestReplay() {
+	name, err := suite.replayer.Start("", suite.recording, ReplayOptions{Speed: 0})
+	suite.Require().NoError(err)
+	suite.Equal("robot", name)
+	suite.Contains(suite.broker.GetRobotNames(), "robot")
+	conn := suite.connectClient(name)
+	for _, timestamp := range []float64{0.032, 0.064} {
+		suite.Equal(timestamp, (<-conn.SdIn).GetTimestamp())
+		conn.CmdOut <- &pb.Commands{}
+	}
+	<-conn.Ctx.Done()
+	time.Sleep(closeTimeout)
+	suite.NotContains(suite.broker.GetRobotNames(), "robot")
+	suite.Empty(suite.replayer.GetRobotNames())
+}
+
+func (suite *ReplaySuite) TestStepMode() {
+	name, err := suite.replayer.Start("replay", suite.recording, ReplayOptions{StepMode: true})
+	suite.Require().NoError(err)
+	suite.Equal("replay", name)
+	conn := suite.connectClient(name)
+	select {
+	case <-conn.SdIn:
+		suite.Fail("Frame played before step")
+	case <-time.After(closeTimeout):
+	}
+	suite.Require().NoError(suite.replayer.Step(name, 1))
+	suite.Equal(0.032, (<-conn.SdIn).GetTimestamp())
+	conn.CmdOut <- &pb.Commands{}
+	suite.Require().NoError(suite.replayer.Stop(name))
+	<-conn.Ctx.Done()
+}
+
+func (suite *ReplaySuite) TestStepNotInStepMode() {
+	name, err := suite.replayer.Start("", suite.recording, ReplayOptions{Speed: 1})
+	suite.Require().NoError(err)
+	suite.Error(suite.replayer.Step(name, 1))
+	suite.Error(suite.replayer.Step("nonexistant", 1))
+}
+
+func (suite *ReplaySuite) TestDuplicateName() {
+	suite.Require().NotNil(suite.broker.RegisterRobot("robot", suite.globalCtx, nil))
+	_, err := suite.replayer.Start("", suite.recording, ReplayOptions{})
+	suite.Error(err)
+}
+
+func TestReplaySuite(t *testing.T) {
+	suite.Run(t, new(ReplaySuite))
+}
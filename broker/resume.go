@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// sessionState tracks whether a robot or client handle has a live session
+// stream. When the stream drops, the handle is detached: it stays registered,
+// and stays bound if it was, for the resume grace period, and the peer may
+// reclaim it by presenting its resume token in a new handshake. All fields are
+// protected by the broker's mutex.
+type sessionState struct {
+	// Empty if the broker doesn't allow resuming sessions
+	resumeToken string
+	// Ends the attached session stream, if any
+	cancelStream context.CancelFunc
+	// Closed when a detached handle is resumed; nil while attached
+	resumed    chan struct{}
+	graceTimer *time.Timer
+	// Sim state changes of the bound connection sent while detached
+	missedSimStates []*pb.SimState
+}
+
+// ErrSessionActive is returned when resuming a session whose stream hasn't
+// ended yet. The stream is ended, so resuming again shortly after succeeds.
+var ErrSessionActive = errors.New("Session still active, try again")
+
+func newResumeToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// newSessionState creates the session state of a newly registered handle.
+// b.mu must be held.
+func (b *Broker) newSessionState() sessionState {
+	if b.simInfo.resumeGrace <= 0 {
+		return sessionState{}
+	}
+	token, err := newResumeToken()
+	if err != nil {
+		log.Errorf("Couldn't generate resume token: %s", err.Error())
+	}
+	return sessionState{resumeToken: token}
+}
+
+// attach records the stream of a newly registered handle's session
+func (b *Broker) attach(s *sessionState, cancelStream context.CancelFunc) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s.cancelStream = cancelStream
+}
+
+// detach starts the grace period of a handle whose session stream ended,
+// calling cancel if it isn't resumed in time. It returns the channel closed on
+// resumption. b.mu must be held.
+func (b *Broker) detach(s *sessionState, cancel context.CancelFunc, logger *logrus.Entry) <-chan struct{} {
+	s.cancelStream = nil
+	if s.resumeToken == "" {
+		cancel()
+		return nil
+	}
+	resumed := make(chan struct{})
+	s.resumed = resumed
+	s.graceTimer = time.AfterFunc(b.simInfo.resumeGrace, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if s.resumed == resumed {
+			logger.Info("Resume grace period expired")
+			cancel()
+		}
+	})
+	logger.WithField("grace", b.simInfo.resumeGrace).Info("Session detached, awaiting resume")
+	return resumed
+}
+
+// resume reclaims a detached handle with its resume token, returning the sim
+// state changes it missed. b.mu must be held.
+func (s *sessionState) resume(token string, cancelStream context.CancelFunc) ([]*pb.SimState, error) {
+	if s.resumeToken == "" || !tokensEqual(s.resumeToken, token) {
+		return nil, errors.New("invalid resume token")
+	}
+	if s.resumed == nil {
+		// The peer reconnected before its old stream was noticed to have
+		// dropped
+		if s.cancelStream != nil {
+			s.cancelStream()
+		}
+		return nil, ErrSessionActive
+	}
+	s.graceTimer.Stop()
+	close(s.resumed)
+	s.resumed = nil
+	missed := s.missedSimStates
+	s.missedSimStates = nil
+	s.cancelStream = cancelStream
+	return missed, nil
+}
+
+// isDetached reports whether the handle is awaiting resumption. b.mu must be
+// held.
+func (s *sessionState) isDetached() bool {
+	return s.resumed != nil
+}
+
+// ResumeRobot reclaims the detached robot with the given name, returning the
+// sim state changes its bound connection missed
+func (b *Broker) ResumeRobot(name, token string, cancelStream context.CancelFunc) (*RobotHandle, []*pb.SimState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	robot, ok := b.robots[name]
+	if !ok {
+		return nil, nil, errors.New("Robot not registered")
+	}
+	missed, err := robot.session.resume(token, cancelStream)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.WithField("robot", name).Info("Robot resumed")
+	return robot, missed, nil
+}
+
+// ResumeClient reclaims the detached client with the given name, returning
+// the sim state changes its bound connection missed
+func (b *Broker) ResumeClient(name, token string, cancelStream context.CancelFunc) (*ClientHandle, []*pb.SimState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	client, ok := b.clients[name]
+	if !ok {
+		return nil, nil, errors.New("Client not registered")
+	}
+	missed, err := client.session.resume(token, cancelStream)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.WithField("client", name).Info("Client resumed")
+	return client, missed, nil
+}
+
+// DetachRobot handles the end of a robot's session stream. The robot stays
+// registered for the resume grace period, during which commands for it are
+// discarded.
+func (b *Broker) DetachRobot(name string, robot *RobotHandle) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if robot.ctx.Err() != nil {
+		return
+	}
+	logger := log.WithField("robot", name)
+	resumed := b.detach(&robot.session, robot.cancel, logger)
+	if resumed == nil || robot.current == nil {
+		return
+	}
+	conn := *robot.current
+	go func() {
+		for {
+			select {
+			case <-conn.CmdIn:
+			case ssc := <-conn.SimStateChange:
+				b.recordMissedSimState(&robot.session, ssc)
+			case <-resumed:
+				return
+			case <-conn.Ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// DetachClient handles the end of a client's session stream. The client stays
+// registered for the resume grace period, during which its robot is sent safe
+// stop commands in reply to each sensor frame. last is the last commands the
+// client sent, if any.
+func (b *Broker) DetachClient(name string, client *ClientHandle, last *pb.Commands) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if client.ctx.Err() != nil {
+		return
+	}
+	logger := log.WithField("client", name)
+	resumed := b.detach(&client.session, client.cancel, logger)
+	if resumed == nil || client.current == nil {
+		return
+	}
+	conn := *client.current
+	stop := safeStopCommands(conn.RobotInfo, last)
+	go func() {
+		for {
+			select {
+			case <-conn.SdIn:
+				select {
+				case conn.CmdOut <- stop:
+				case <-resumed:
+					return
+				case <-conn.Ctx.Done():
+					return
+				}
+			case ssc := <-conn.SimStateChange:
+				b.recordMissedSimState(&client.session, ssc)
+			case <-resumed:
+				return
+			case <-conn.Ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (b *Broker) recordMissedSimState(s *sessionState, state *pb.SimState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s.missedSimStates = append(s.missedSimStates, state)
+}
+
+// safeStopCommands returns commands stopping every motor the robot declared,
+// and every motor in last
+func safeStopCommands(info *pb.RobotInfo, last *pb.Commands) *pb.Commands {
+	stop := SyncPolicyZero.commands(last)
+	stopped := make(map[string]bool)
+	for _, cmd := range stop.GetCommands() {
+		stopped[cmd.GetName()] = true
+	}
+	for _, motor := range info.GetMotorInfos() {
+		if !stopped[motor.GetName()] {
+			stop.Commands = append(stop.Commands, &pb.Command{
+				Name:    motor.GetName(),
+				Command: &pb.Command_MotorCommand_{MotorCommand: &pb.Command_MotorCommand{Velocity: 0}},
+			})
+		}
+	}
+	return stop
+}
+
+// RobotConnection returns the connection the robot is bound in, if any
+func (b *Broker) RobotConnection(robot *RobotHandle) (RobotConnection, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if robot.current == nil {
+		return RobotConnection{}, false
+	}
+	return *robot.current, true
+}
+
+// ClientConnection returns the connection the client is bound in, if any
+func (b *Broker) ClientConnection(client *ClientHandle) (ClientConnection, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if client.current == nil {
+		return ClientConnection{}, false
+	}
+	return *client.current, true
+}
+
+// streamEnded returns the error a session ends with once its stream context is
+// done, which is either because the peer went away or because another session
+// resumed it
+func streamEnded(srv interface{ Context() context.Context }) error {
+	if srv.Context().Err() != nil {
+		return nil
+	}
+	return status.Error(codes.Aborted, "session resumed by another stream")
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+const resumeGrace = 100 * time.Millisecond
+
+type ResumeSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	robotInfo      *pb.RobotInfo
+}
+
+func (suite *ResumeSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32, resumeGrace: resumeGrace})
+	suite.robotInfo = &pb.RobotInfo{
+		MotorInfos: []*pb.MotorInfo{{Name: "left"}, {Name: "right"}},
+	}
+}
+
+func (suite *ResumeSuite) TearDownTest() {
+	suite.globalCtxClose()
+}
+
+// bind registers a robot and a client and binds them
+func (suite *ResumeSuite) bind() (*RobotHandle, RobotConnection, *ClientHandle, ClientConnection) {
+	robot := suite.broker.RegisterRobot("robot", suite.globalCtx, suite.robotInfo)
+	suite.Require().NotNil(robot)
+	client := suite.broker.RegisterClient("client", suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	robotConnChan := make(chan RobotConnection, 1)
+	clientConnChan := make(chan ClientConnection, 1)
+	go func() { robotConnChan <- <-robot.GetConnection() }()
+	go func() { clientConnChan <- <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	return robot, <-robotConnChan, client, <-clientConnChan
+}
+
+func (suite *ResumeSuite) TestResumeClient() {
+	_, robotConn, client, _ := suite.bind()
+	suite.Require().NotEmpty(client.session.resumeToken)
+	suite.broker.DetachClient("client", client, nil)
+	suite.Contains(suite.broker.GetClientNames(), "client")
+
+	// The robot is kept stopped while the client is away
+	robotConn.SdOut <- &pb.SensorsData{}
+	select {
+	case cmds := <-robotConn.CmdIn:
+		suite.Len(cmds.GetCommands(), 2)
+		for _, cmd := range cmds.GetCommands() {
+			suite.Equal(0.0, cmd.GetMotorCommand().GetVelocity())
+		}
+	case <-time.After(resumeGrace / 2):
+		suite.Fail("Robot wasn't sent stop commands")
+	}
+	go func() { <-robotConn.SimStateChange }()
+	suite.broker.SetSimState(pb.SimState{State: pb.SimState_STOP})
+	time.Sleep(closeTimeout)
+
+	_, _, err := suite.broker.ResumeClient("client", "wrong", func() {})
+	suite.Error(err)
+	resumed, missed, err := suite.broker.ResumeClient("client", client.session.resumeToken, func() {})
+	suite.Require().NoError(err)
+	suite.Equal(client, resumed)
+	suite.Require().Len(missed, 1)
+	suite.Equal(pb.SimState_STOP, missed[0].GetState())
+
+	// The connection outlives the grace period once resumed
+	time.Sleep(resumeGrace + closeTimeout)
+	_, ok := suite.broker.ClientConnection(client)
+	suite.True(ok)
+	suite.Len(suite.broker.GetConnections(), 1)
+}
+
+func (suite *ResumeSuite) TestResumeActiveSession() {
+	client := suite.broker.RegisterClient("client", suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	streamCtx, cancelStream := context.WithCancel(suite.globalCtx)
+	suite.broker.attach(&client.session, cancelStream)
+	_, _, err := suite.broker.ResumeClient("client", client.session.resumeToken, func() {})
+	suite.Equal(ErrSessionActive, err)
+	suite.Error(streamCtx.Err(), "Old stream wasn't ended")
+}
+
+// failingSessionStream is a client session stream which sends a handshake, and
+// fails to send anything back
+type failingSessionStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	handshake *pb.ClientControllerMessage_ControllerMessage
+}
+
+func (s *failingSessionStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *failingSessionStream) Recv() (*pb.ClientControllerMessage_ControllerMessage, error) {
+	return s.handshake, nil
+}
+
+func (s *failingSessionStream) Send(*pb.ClientControllerMessage_ServerMessage) error {
+	return errors.New("stream broken")
+}
+
+func (suite *ResumeSuite) TestFailedHandshakeNotKept() {
+	server := NewClientControllerServer(suite.broker, nil)
+	stream := &failingSessionStream{
+		ctx: suite.globalCtx,
+		handshake: &pb.ClientControllerMessage_ControllerMessage{Message: &pb.ClientControllerMessage_ControllerMessage_ClientControllerHandshake{
+			ClientControllerHandshake: &pb.ClientControllerHandshake{ClientName: "client"},
+		}},
+	}
+	suite.Error(server.Session(stream))
+	time.Sleep(closeTimeout)
+	// The client never got a token, so the session isn't held for it
+	suite.NotContains(suite.broker.GetClientNames(), "client")
+}
+
+func (suite *ResumeSuite) TestGraceExpiry() {
+	robot, _, _, _ := suite.bind()
+	suite.broker.DetachRobot("robot", robot)
+	suite.Error(suite.broker.ConnectClientToRobot("client", "robot"))
+	time.Sleep(resumeGrace + closeTimeout)
+	suite.NotContains(suite.broker.GetRobotNames(), "robot")
+	suite.Empty(suite.broker.GetConnections())
+	_, _, err := suite.broker.ResumeRobot("robot", robot.session.resumeToken, func() {})
+	suite.Error(err)
+}
+
+func (suite *ResumeSuite) TestResumeDisabled() {
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+	robot := suite.broker.RegisterRobot("robot", suite.globalCtx, nil)
+	suite.Require().NotNil(robot)
+	suite.Empty(robot.session.resumeToken)
+	suite.broker.DetachRobot("robot", robot)
+	time.Sleep(closeTimeout)
+	suite.NotContains(suite.broker.GetRobotNames(), "robot")
+}
+
+func (suite *ResumeSuite) TestSafeStopCommands() {
+	last := &pb.Commands{Commands: []*pb.Command{{
+		Name:    "right",
+		Command: &pb.Command_MotorCommand_{MotorCommand: &pb.Command_MotorCommand{Velocity: 3}},
+	}}}
+	stop := safeStopCommands(suite.robotInfo, last)
+	names := make([]string, 0)
+	for _, cmd := range stop.GetCommands() {
+		names = append(names, cmd.GetName())
+		suite.Equal(0.0, cmd.GetMotorCommand().GetVelocity())
+	}
+	suite.ElementsMatch([]string{"left", "right"}, names)
+	suite.Equal(3.0, last.GetCommands()[0].GetMotorCommand().GetVelocity(), "Last commands were modified")
+}
+
+func TestResumeSuite(t *testing.T) {
+	suite.Run(t, new(ResumeSuite))
+}
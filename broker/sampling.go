@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// SensorSampling holds the sensor sampling periods requested by the client of
+// a connection. Sensors start out sampled every timestep.
+type SensorSampling struct {
+	info     *pb.RobotInfo
+	timestep int
+
+	mu      sync.Mutex
+	periods map[string]*pb.SensorSamplingPeriod
+	// Sim time each sensor was last forwarded to the client
+	lastSent map[string]float64
+	changed  chan struct{}
+}
+
+func newSensorSampling(info *pb.RobotInfo, timestep int) *SensorSampling {
+	return &SensorSampling{
+		info:     info,
+		timestep: timestep,
+		periods:  make(map[string]*pb.SensorSamplingPeriod),
+		lastSent: make(map[string]float64),
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// Request validates sampling periods against the robot's sensors, and applies
+// them if they are all valid. Each period must be 0 (off) or a multiple of the
+// timestep.
+func (s *SensorSampling) Request(periods []*pb.SensorSamplingPeriod) error {
+	sensors := make(map[string]pb.SensorType_SensorType)
+	for _, sensor := range s.info.GetSensorInfos() {
+		sensors[sensor.GetName()] = sensor.GetType()
+	}
+	for _, period := range periods {
+		sensorType, ok := sensors[period.GetName()]
+		if !ok {
+			return fmt.Errorf("Unknown sensor \"%s\"", period.GetName())
+		}
+		if period.GetType() != pb.SensorType_UNKNOWN && period.GetType() != sensorType {
+			return fmt.Errorf("Sensor \"%s\" is a %s, not a %s", period.GetName(), sensorType, period.GetType())
+		}
+		if p := period.GetSamplingPeriod(); p < 0 || s.timestep > 0 && p%int32(s.timestep) != 0 {
+			return fmt.Errorf("Sampling period of sensor \"%s\" must be 0 or a multiple of %dms", period.GetName(), s.timestep)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, period := range periods {
+		s.periods[period.GetName()] = &pb.SensorSamplingPeriod{
+			Name:           period.GetName(),
+			Type:           sensors[period.GetName()],
+			SamplingPeriod: period.GetSamplingPeriod(),
+		}
+	}
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Changed returns a channel which receives a value after periods are
+// requested, coalescing requests made before it is read
+func (s *SensorSampling) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// Periods returns the periods of all sensors the client has requested, ordered
+// by sensor name
+func (s *SensorSampling) Periods() []*pb.SensorSamplingPeriod {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	periods := make([]*pb.SensorSamplingPeriod, 0, len(s.periods))
+	for _, period := range s.periods {
+		periods = append(periods, period)
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].GetName() < periods[j].GetName()
+	})
+	return periods
+}
+
+// filter returns the sensor frame with only the entries which are due to be
+// sent to the client. A nil SensorSampling forwards every entry.
+func (s *SensorSampling) filter(sd *pb.SensorsData) *pb.SensorsData {
+	if s == nil {
+		return sd
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.periods) == 0 {
+		return sd
+	}
+	filtered := &pb.SensorsData{Timestamp: sd.GetTimestamp()}
+	for _, data := range sd.GetData() {
+		if s.isDue(data.GetName(), sd.GetTimestamp()) {
+			filtered.Data = append(filtered.Data, data)
+		}
+	}
+	return filtered
+}
+
+// isDue reports whether a sensor is due at the given sim time, and if so marks
+// it as sent. s.mu must be held.
+func (s *SensorSampling) isDue(name string, now float64) bool {
+	period, ok := s.periods[name]
+	if !ok {
+		return true
+	}
+	if period.GetSamplingPeriod() == 0 {
+		return false
+	}
+	last, sent := s.lastSent[name]
+	// Half a timestep of slack absorbs floating point error in sim times; a
+	// time before the last one means the simulation was reset
+	if !sent || now < last || (now-last)*1000 >= float64(period.GetSamplingPeriod())-float64(s.timestep)/2 {
+		s.lastSent[name] = now
+		return true
+	}
+	return false
+}
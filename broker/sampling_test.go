@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type SamplingSuite struct {
+	suite.Suite
+	sampling *SensorSampling
+}
+
+var samplingTestInfo = &pb.RobotInfo{
+	SensorInfos: []*pb.SensorInfo{
+		{Name: "so0", Type: pb.SensorType_DISTANCE_SENSOR},
+		{Name: "so1", Type: pb.SensorType_DISTANCE_SENSOR},
+		{Name: "camera", Type: pb.SensorType_CAMERA_RECOGNITION_SENSOR},
+	},
+}
+
+func (suite *SamplingSuite) SetupTest() {
+	suite.sampling = newSensorSampling(samplingTestInfo, 32)
+}
+
+// samplingFrame returns a sensor frame with an entry for every test sensor
+func samplingFrame(timestamp float64) *pb.SensorsData {
+	sd := &pb.SensorsData{Timestamp: timestamp}
+	for _, sensor := range samplingTestInfo.GetSensorInfos() {
+		sd.Data = append(sd.Data, &pb.SensorData{Name: sensor.GetName()})
+	}
+	return sd
+}
+
+func sensorNames(sd *pb.SensorsData) []string {
+	var names []string
+	for _, data := range sd.GetData() {
+		names = append(names, data.GetName())
+	}
+	return names
+}
+
+func (suite *SamplingSuite) TestValidation() {
+	suite.Error(suite.sampling.Request([]*pb.SensorSamplingPeriod{{Name: "nonexistant", SamplingPeriod: 32}}))
+	suite.Error(suite.sampling.Request([]*pb.SensorSamplingPeriod{{Name: "so0", SamplingPeriod: 40}}))
+	suite.Error(suite.sampling.Request([]*pb.SensorSamplingPeriod{{Name: "so0", SamplingPeriod: -32}}))
+	suite.Error(suite.sampling.Request([]*pb.SensorSamplingPeriod{{Name: "so0", Type: pb.SensorType_POSITION_SENSOR, SamplingPeriod: 32}}))
+	// A request with any invalid period is rejected as a whole
+	suite.Error(suite.sampling.Request([]*pb.SensorSamplingPeriod{
+		{Name: "so0", SamplingPeriod: 64},
+		{Name: "so1", SamplingPeriod: 65},
+	}))
+	suite.Empty(suite.sampling.Periods())
+	suite.NoError(suite.sampling.Request([]*pb.SensorSamplingPeriod{
+		{Name: "so1", SamplingPeriod: 64},
+		{Name: "so0", Type: pb.SensorType_DISTANCE_SENSOR, SamplingPeriod: 0},
+	}))
+	periods := suite.sampling.Periods()
+	suite.Require().Len(periods, 2)
+	suite.Equal("so0", periods[0].GetName())
+	suite.Equal("so1", periods[1].GetName())
+	suite.Equal(pb.SensorType_DISTANCE_SENSOR, periods[1].GetType())
+}
+
+func (suite *SamplingSuite) TestChangedCoalesces() {
+	select {
+	case <-suite.sampling.Changed():
+		suite.Fail("Changed before any request")
+	default:
+	}
+	suite.Require().NoError(suite.sampling.Request([]*pb.SensorSamplingPeriod{{Name: "so0", SamplingPeriod: 64}}))
+	suite.Require().NoError(suite.sampling.Request([]*pb.SensorSamplingPeriod{{Name: "so1", SamplingPeriod: 64}}))
+	<-suite.sampling.Changed()
+	select {
+	case <-suite.sampling.Changed():
+		suite.Fail("Requests not coalesced")
+	default:
+	}
+}
+
+func (suite *SamplingSuite) TestFilter() {
+	suite.Equal([]string{"so0", "so1", "camera"}, sensorNames(suite.sampling.filter(samplingFrame(0.032))))
+	suite.Require().NoError(suite.sampling.Request([]*pb.SensorSamplingPeriod{
+		{Name: "so1", SamplingPeriod: 0},
+		{Name: "camera", SamplingPeriod: 96},
+	}))
+	expected := [][]string{
+		{"so0", "camera"},
+		{"so0"},
+		{"so0"},
+		{"so0", "camera"},
+	}
+	for i, names := range expected {
+		timestamp := 0.064 + float64(i)*0.032
+		suite.Equal(names, sensorNames(suite.sampling.filter(samplingFrame(timestamp))), "at %g", timestamp)
+	}
+	// Sensors are due again straight after a reset
+	suite.Equal([]string{"so0", "camera"}, sensorNames(suite.sampling.filter(samplingFrame(0.032))))
+}
+
+func (suite *SamplingSuite) TestRelayFilters() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sdIn := make(chan *pb.SensorsData)
+	sdOut := make(chan *pb.SensorsData)
+	r := &relay{
+		ctx:      ctx,
+		logger:   log.WithField("test", "sampling"),
+		sampling: suite.sampling,
+		sdIn:     sdIn,
+		sdOut:    sdOut,
+		cmdIn:    make(chan *pb.Commands),
+		cmdOut:   make(chan *pb.Commands),
+	}
+	go r.runAsync()
+	suite.Require().NoError(suite.sampling.Request([]*pb.SensorSamplingPeriod{{Name: "camera", SamplingPeriod: 0}}))
+	sdIn <- samplingFrame(0.032)
+	suite.Equal([]string{"so0", "so1"}, sensorNames(<-sdOut))
+}
+
+func TestSamplingSuite(t *testing.T) {
+	suite.Run(t, new(SamplingSuite))
+}
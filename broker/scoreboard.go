@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// Scoreboard keeps the scores of robots, and of the teams whose clients were
+// bound to them, from the game events posted by the supervisor. Scores are
+// cleared when the simulation is reset.
+type Scoreboard struct {
+	broker *Broker
+
+	mu     sync.Mutex
+	robots map[string]*pb.Scores_RobotScore
+	teams  map[string]int32
+	// Latest scores, which listeners are sent
+	scores    *pb.Scores
+	listeners map[chan *pb.Scores]struct{}
+}
+
+// NewScoreboard creates an empty scoreboard, which runs until the broker's
+// context is done
+func NewScoreboard(broker *Broker) *Scoreboard {
+	s := &Scoreboard{
+		broker:    broker,
+		robots:    make(map[string]*pb.Scores_RobotScore),
+		teams:     make(map[string]int32),
+		scores:    &pb.Scores{},
+		listeners: make(map[chan *pb.Scores]struct{}),
+	}
+	events := broker.GetEventQueue(broker.ctx)
+	go func() {
+		for event := range events {
+			if event.Type == EventSimStateChanged && event.SimState.GetState() == pb.SimState_RESET {
+				s.reset()
+			}
+		}
+	}()
+	return s
+}
+
+// Post records a game event
+func (s *Scoreboard) Post(event *pb.GameEvent) error {
+	if event.GetRobotName() == "" {
+		return errors.New("No robot name given")
+	}
+	if _, ok := pb.GameEvent_Type_name[int32(event.GetType())]; !ok || event.GetType() == pb.GameEvent_UNKNOWN {
+		return errors.New("Unknown event type")
+	}
+	// Points go to the team controlling the robot at the time
+	team := ""
+	for _, conn := range s.broker.GetConnections() {
+		if conn.RobotName == event.GetRobotName() {
+			team = conn.ClientName
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	robot, ok := s.robots[event.GetRobotName()]
+	if !ok {
+		robot = &pb.Scores_RobotScore{RobotName: event.GetRobotName()}
+		s.robots[robot.RobotName] = robot
+	}
+	robot.Score += event.GetPoints()
+	if team != "" {
+		robot.TeamName = team
+		s.teams[team] += event.GetPoints()
+	}
+	switch event.GetType() {
+	case pb.GameEvent_VICTIM_PICKED_UP:
+		robot.VictimsPickedUp++
+	case pb.GameEvent_VICTIM_DELIVERED:
+		robot.VictimsDelivered++
+	case pb.GameEvent_BASE_ENTERED:
+		robot.InBase = true
+	case pb.GameEvent_BASE_EXITED:
+		robot.InBase = false
+	case pb.GameEvent_PENALTY:
+		robot.Penalties++
+	}
+	log.WithFields(logrus.Fields{
+		"robot":  event.GetRobotName(),
+		"team":   team,
+		"event":  event.GetType(),
+		"points": event.GetPoints(),
+	}).Info("Game event")
+	s.publish(event)
+	return nil
+}
+
+// Scores returns the current scores
+func (s *Scoreboard) Scores() *pb.Scores {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scores
+}
+
+// Subscribe returns a channel which receives the current scores, and the
+// scores after every change until ctx is done. A listener which falls behind
+// only receives the latest scores.
+func (s *Scoreboard) Subscribe(ctx context.Context) <-chan *pb.Scores {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan *pb.Scores, 1)
+	ch <- s.scores
+	s.listeners[ch] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.listeners, ch)
+	}()
+	return ch
+}
+
+// Restore replaces the scores with scores from a saved state
+func (s *Scoreboard) Restore(scores *pb.Scores) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.robots = make(map[string]*pb.Scores_RobotScore)
+	s.teams = make(map[string]int32)
+	for _, robot := range scores.GetRobots() {
+		s.robots[robot.GetRobotName()] = proto.Clone(robot).(*pb.Scores_RobotScore)
+	}
+	for _, team := range scores.GetTeams() {
+		s.teams[team.GetTeamName()] = team.GetScore()
+	}
+	s.publish(nil)
+}
+
+func (s *Scoreboard) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.robots = make(map[string]*pb.Scores_RobotScore)
+	s.teams = make(map[string]int32)
+	log.Info("Scoreboard reset")
+	s.publish(nil)
+}
+
+// publish snapshots the scores and sends them to every listener. s.mu must be
+// held.
+func (s *Scoreboard) publish(event *pb.GameEvent) {
+	scores := &pb.Scores{LastEvent: event}
+	for _, robot := range s.robots {
+		scores.Robots = append(scores.Robots, proto.Clone(robot).(*pb.Scores_RobotScore))
+	}
+	sort.Slice(scores.Robots, func(i, j int) bool {
+		return scores.Robots[i].RobotName < scores.Robots[j].RobotName
+	})
+	for team, score := range s.teams {
+		scores.Teams = append(scores.Teams, &pb.Scores_TeamScore{TeamName: team, Score: score})
+	}
+	sort.Slice(scores.Teams, func(i, j int) bool {
+		if scores.Teams[i].Score != scores.Teams[j].Score {
+			return scores.Teams[i].Score > scores.Teams[j].Score
+		}
+		return scores.Teams[i].TeamName < scores.Teams[j].TeamName
+	})
+	s.scores = scores
+	for listener := range s.listeners {
+		// Replace scores the listener hasn't received yet
+		select {
+		case <-listener:
+		default:
+		}
+		listener <- scores
+	}
+}
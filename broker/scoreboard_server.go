@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type ScoreboardServer struct {
+	pb.UnimplementedScoreboardServer
+
+	scoreboard *Scoreboard
+}
+
+func NewScoreboardServer(scoreboard *Scoreboard) *ScoreboardServer {
+	return &ScoreboardServer{scoreboard: scoreboard}
+}
+
+func (s *ScoreboardServer) PostGameEvent(_ context.Context, event *pb.GameEvent) (*pb.PostGameEventResponse, error) {
+	if err := s.scoreboard.Post(event); err != nil {
+		return &pb.PostGameEventResponse{Data: &pb.PostGameEventResponse_Error{Error: err.Error()}}, nil
+	}
+	return &pb.PostGameEventResponse{Data: &pb.PostGameEventResponse_Ok_{Ok: &pb.PostGameEventResponse_Ok{}}}, nil
+}
+
+func (s *ScoreboardServer) GetScoreboard(context.Context, *pb.Null) (*pb.Scores, error) {
+	return s.scoreboard.Scores(), nil
+}
+
+func (s *ScoreboardServer) SubscribeScoreboard(_ *pb.Null, srv pb.Scoreboard_SubscribeScoreboardServer) error {
+	ctx, cancel := s.scoreboard.broker.untilShutdown(srv.Context())
+	defer cancel()
+	scores := s.scoreboard.Subscribe(ctx)
+	for {
+		select {
+		case update := <-scores:
+			if err := srv.Send(update); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
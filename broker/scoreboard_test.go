@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type ScoreboardSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	scoreboard     *Scoreboard
+}
+
+func (suite *ScoreboardSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+	suite.scoreboard = NewScoreboard(suite.broker)
+}
+
+func (suite *ScoreboardSuite) TearDownTest() {
+	suite.globalCtxClose()
+}
+
+func (suite *ScoreboardSuite) post(eventType pb.GameEvent_Type, robot string, points int32) {
+	suite.Require().NoError(suite.scoreboard.Post(&pb.GameEvent{Type: eventType, RobotName: robot, Points: points}))
+}
+
+func (suite *ScoreboardSuite) TestInvalidEvents() {
+	suite.Error(suite.scoreboard.Post(&pb.GameEvent{Type: pb.GameEvent_PENALTY}))
+	suite.Error(suite.scoreboard.Post(&pb.GameEvent{RobotName: "robot0"}))
+	suite.Error(suite.scoreboard.Post(&pb.GameEvent{Type: 42, RobotName: "robot0"}))
+	suite.Empty(suite.scoreboard.Scores().GetRobots())
+}
+
+func (suite *ScoreboardSuite) TestScores() {
+	robot := suite.broker.RegisterRobot("robot1", suite.globalCtx, nil)
+	suite.Require().NotNil(robot)
+	client := suite.broker.RegisterClient("team-a", suite.globalCtx, false)
+	suite.Require().NotNil(client)
+	go func() { <-robot.GetConnection() }()
+	go func() { <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("team-a", "robot1"))
+
+	suite.post(pb.GameEvent_VICTIM_PICKED_UP, "robot1", 1)
+	suite.post(pb.GameEvent_BASE_ENTERED, "robot1", 0)
+	suite.post(pb.GameEvent_VICTIM_DELIVERED, "robot1", 1)
+	suite.post(pb.GameEvent_PENALTY, "robot0", -2)
+	scores := suite.scoreboard.Scores()
+	suite.Require().Len(scores.GetRobots(), 2)
+	suite.Equal("robot0", scores.GetRobots()[0].GetRobotName())
+	suite.Equal(int32(-2), scores.GetRobots()[0].GetScore())
+	suite.Equal(int32(1), scores.GetRobots()[0].GetPenalties())
+	suite.Empty(scores.GetRobots()[0].GetTeamName())
+	robot1 := scores.GetRobots()[1]
+	suite.Equal("team-a", robot1.GetTeamName())
+	suite.Equal(int32(2), robot1.GetScore())
+	suite.Equal(int32(1), robot1.GetVictimsPickedUp())
+	suite.Equal(int32(1), robot1.GetVictimsDelivered())
+	suite.True(robot1.GetInBase())
+	suite.Require().Len(scores.GetTeams(), 1)
+	suite.Equal(int32(2), scores.GetTeams()[0].GetScore())
+	suite.Equal(pb.GameEvent_PENALTY, scores.GetLastEvent().GetType())
+
+	suite.broker.SetSimState(pb.SimState{State: pb.SimState_RESET})
+	time.Sleep(closeTimeout)
+	suite.Empty(suite.scoreboard.Scores().GetRobots())
+	suite.Empty(suite.scoreboard.Scores().GetTeams())
+}
+
+func (suite *ScoreboardSuite) TestSubscribe() {
+	ctx, cancel := context.WithCancel(suite.globalCtx)
+	defer cancel()
+	updates := suite.scoreboard.Subscribe(ctx)
+	suite.Empty((<-updates).GetRobots())
+	// A listener which falls behind gets the latest scores
+	suite.post(pb.GameEvent_VICTIM_PICKED_UP, "robot0", 1)
+	suite.post(pb.GameEvent_VICTIM_DELIVERED, "robot0", 1)
+	scores := <-updates
+	suite.Equal(int32(2), scores.GetRobots()[0].GetScore())
+	select {
+	case <-updates:
+		suite.Fail("Stale scores were sent")
+	default:
+	}
+}
+
+func TestScoreboardSuite(t *testing.T) {
+	suite.Run(t, new(ScoreboardSuite))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// Shutdown starts shutting the broker down: new sessions are refused, every
+// bound robot is sent commands stopping its motors before being unbound, and
+// every robot and client is told the broker is shutting down, with reason,
+// before its session ends. It returns an error if the broker is already
+// shutting down.
+func (b *Broker) Shutdown(reason string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	select {
+	case <-b.shuttingDown:
+		return errors.New("Already shutting down")
+	default:
+	}
+	log.WithField("reason", reason).Info("Shutting down")
+	b.shutdownReason = reason
+	close(b.shuttingDown)
+	b.publishEvent(Event{Type: EventShuttingDown})
+	return nil
+}
+
+// ShuttingDown returns a channel which is closed once the broker starts
+// shutting down
+func (b *Broker) ShuttingDown() <-chan struct{} {
+	return b.shuttingDown
+}
+
+// WaitSessions waits up to timeout for every robot and client session to end
+// once the broker is shutting down, returning whether they all did
+func (b *Broker) WaitSessions(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		b.sessions.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// beginSession counts a robot or client session which has sent its handshake,
+// returning false if the broker is shutting down and the session must be
+// refused. Sessions which begin must call endSession when they end.
+func (b *Broker) beginSession() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	select {
+	case <-b.shuttingDown:
+		return false
+	default:
+	}
+	b.sessions.Add(1)
+	return true
+}
+
+func (b *Broker) endSession() {
+	b.sessions.Done()
+}
+
+// untilShutdown returns a context which is also done once the broker starts
+// shutting down, for streams which should end then rather than hold up the
+// server's shutdown
+func (b *Broker) untilShutdown(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-b.shuttingDown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+func (b *Broker) serverShuttingDown() *pb.ServerShuttingDown {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return &pb.ServerShuttingDown{Reason: b.shutdownReason}
+}
+
+// shutDownRobot ends a robot's session as the broker shuts down, stopping and
+// unbinding the robot first if it is bound
+func (s *WbControllerServer) shutDownRobot(srv pb.WbController_SessionServer, robot *RobotHandle, isBound bool, logger *logrus.Entry) error {
+	if isBound {
+		err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_Commands{
+			Commands: safeStopCommands(robot.info, nil),
+		}})
+		if err != nil {
+			logger.Errorf("Couldn't send commands message: %s", err.Error())
+			return err
+		}
+		err = srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerUnbound{WbControllerUnbound: &pb.WbControllerUnbound{}}})
+		if err != nil {
+			logger.Errorf("Couldn't send unbound message: %s", err.Error())
+			return err
+		}
+	}
+	err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_ServerShuttingDown{
+		ServerShuttingDown: s.broker.serverShuttingDown(),
+	}})
+	if err != nil {
+		logger.Errorf("Couldn't send shutting down message: %s", err.Error())
+		return err
+	}
+	logger.Info("Robot session ended for shutdown")
+	return nil
+}
+
+// shutDownClient ends a client's session as the broker shuts down, unbinding
+// the client first if it is bound
+func (s *ClientControllerServer) shutDownClient(srv pb.ClientController_SessionServer, isBound bool, logger *logrus.Entry) error {
+	if isBound {
+		err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ClientControllerUnbound{ClientControllerUnbound: &pb.ClientControllerUnbound{}}})
+		if err != nil {
+			logger.Errorf("Couldn't send unbound message: %s", err.Error())
+			return err
+		}
+	}
+	err := srv.Send(&pb.ClientControllerMessage_ServerMessage{Message: &pb.ClientControllerMessage_ServerMessage_ServerShuttingDown{
+		ServerShuttingDown: s.broker.serverShuttingDown(),
+	}})
+	if err != nil {
+		logger.Errorf("Couldn't send shutting down message: %s", err.Error())
+		return err
+	}
+	logger.Info("Client session ended for shutdown")
+	return nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type ShutdownSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	server         *grpc.Server
+	conn           *grpc.ClientConn
+}
+
+func (suite *ShutdownSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+	suite.server = grpc.NewServer()
+	pb.RegisterWbControllerServer(suite.server, NewWbControllerServer(suite.broker))
+	pb.RegisterClientControllerServer(suite.server, NewClientControllerServer(suite.broker, nil))
+	pb.RegisterControlServer(suite.server, NewControlServer(suite.broker, nil, nil, nil, nil))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	go suite.server.Serve(lis)
+	suite.conn, err = grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	suite.Require().NoError(err)
+}
+
+func (suite *ShutdownSuite) TearDownTest() {
+	suite.conn.Close()
+	suite.server.Stop()
+	suite.globalCtxClose()
+}
+
+func (suite *ShutdownSuite) robotSession(name string) pb.WbController_SessionClient {
+	session, err := pb.NewWbControllerClient(suite.conn).Session(suite.globalCtx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(session.Send(&pb.WbControllerMessage_ClientMessage{Message: &pb.WbControllerMessage_ClientMessage_WbControllerHandshake{
+		WbControllerHandshake: &pb.WbControllerHandshake{
+			RobotName: name,
+			RobotInfo: &pb.RobotInfo{MotorInfos: []*pb.MotorInfo{{Name: "left"}, {Name: "right"}}},
+		},
+	}}))
+	return session
+}
+
+func (suite *ShutdownSuite) clientSession(name string) pb.ClientController_SessionClient {
+	session, err := pb.NewClientControllerClient(suite.conn).Session(suite.globalCtx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(session.Send(&pb.ClientControllerMessage_ControllerMessage{Message: &pb.ClientControllerMessage_ControllerMessage_ClientControllerHandshake{
+		ClientControllerHandshake: &pb.ClientControllerHandshake{ClientName: name},
+	}}))
+	return session
+}
+
+func (suite *ShutdownSuite) TestBoundPeersNotified() {
+	robot := suite.robotSession("robot")
+	client := suite.clientSession("client")
+	msg, err := robot.Recv()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(msg.GetWbControllerHandshakeResponse().GetOk())
+	cMsg, err := client.Recv()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(cMsg.GetClientControllerHandshakeResponse().GetOk())
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	msg, err = robot.Recv()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(msg.GetWbControllerBound())
+	cMsg, err = client.Recv()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(cMsg.GetClientControllerBound())
+
+	suite.Require().NoError(suite.broker.Shutdown("maintenance"))
+
+	// The robot is stopped before it is unbound
+	msg, err = robot.Recv()
+	suite.Require().NoError(err)
+	suite.Require().Len(msg.GetCommands().GetCommands(), 2)
+	for _, cmd := range msg.GetCommands().GetCommands() {
+		suite.Equal(0.0, cmd.GetMotorCommand().GetVelocity())
+	}
+	msg, err = robot.Recv()
+	suite.Require().NoError(err)
+	suite.NotNil(msg.GetWbControllerUnbound())
+	msg, err = robot.Recv()
+	suite.Require().NoError(err)
+	suite.Equal("maintenance", msg.GetServerShuttingDown().GetReason())
+	_, err = robot.Recv()
+	suite.Equal(io.EOF, err)
+
+	cMsg, err = client.Recv()
+	suite.Require().NoError(err)
+	suite.NotNil(cMsg.GetClientControllerUnbound())
+	cMsg, err = client.Recv()
+	suite.Require().NoError(err)
+	suite.Equal("maintenance", cMsg.GetServerShuttingDown().GetReason())
+	_, err = client.Recv()
+	suite.Equal(io.EOF, err)
+
+	suite.True(suite.broker.WaitSessions(time.Second))
+}
+
+func (suite *ShutdownSuite) TestUnboundPeersNotified() {
+	robot := suite.robotSession("robot")
+	msg, err := robot.Recv()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(msg.GetWbControllerHandshakeResponse().GetOk())
+
+	suite.Require().NoError(suite.broker.Shutdown("maintenance"))
+	msg, err = robot.Recv()
+	suite.Require().NoError(err)
+	suite.Equal("maintenance", msg.GetServerShuttingDown().GetReason())
+	_, err = robot.Recv()
+	suite.Equal(io.EOF, err)
+	suite.True(suite.broker.WaitSessions(time.Second))
+	suite.Empty(suite.broker.GetRobotNames())
+}
+
+func (suite *ShutdownSuite) TestNewSessionsRefused() {
+	suite.Require().NoError(suite.broker.Shutdown("maintenance"))
+	msg, err := suite.robotSession("robot").Recv()
+	suite.Require().NoError(err)
+	suite.NotEmpty(msg.GetWbControllerHandshakeResponse().GetError())
+	cMsg, err := suite.clientSession("client").Recv()
+	suite.Require().NoError(err)
+	suite.NotEmpty(cMsg.GetClientControllerHandshakeResponse().GetError())
+	suite.Empty(suite.broker.GetRobotNames())
+	suite.Empty(suite.broker.GetClientNames())
+}
+
+func (suite *ShutdownSuite) TestShutdownRPC() {
+	control := NewControlServer(suite.broker, nil, nil, nil, nil)
+	res, err := control.Shutdown(suite.globalCtx, &pb.ControlMessage_ShutdownRequest{Reason: "done"})
+	suite.Require().NoError(err)
+	suite.NotNil(res.GetOk())
+	select {
+	case <-suite.broker.ShuttingDown():
+	default:
+		suite.Fail("Broker isn't shutting down")
+	}
+	res, err = control.Shutdown(suite.globalCtx, &pb.ControlMessage_ShutdownRequest{})
+	suite.Require().NoError(err)
+	suite.NotEmpty(res.GetError())
+}
+
+func (suite *ShutdownSuite) TestStreamsEnd() {
+	ctx, cancel := suite.broker.untilShutdown(suite.globalCtx)
+	defer cancel()
+	suite.Require().NoError(suite.broker.Shutdown(""))
+	select {
+	case <-ctx.Done():
+	case <-time.After(closeTimeout):
+		suite.Fail("Stream context wasn't done")
+	}
+}
+
+func (suite *ShutdownSuite) TestSubscriptionsEndCleanly() {
+	control := pb.NewControlClient(suite.conn)
+	events, err := control.SubscribeEvents(suite.globalCtx, &pb.Null{})
+	suite.Require().NoError(err)
+	clients, err := control.SubscribeClientControllers(suite.globalCtx, &pb.Null{})
+	suite.Require().NoError(err)
+	// Wait for both subscriptions to be listening
+	for listeners := 0; listeners < 2; time.Sleep(time.Millisecond) {
+		suite.broker.mu.RLock()
+		listeners = len(suite.broker.eventListeners)
+		suite.broker.mu.RUnlock()
+	}
+
+	suite.Require().NoError(suite.broker.Shutdown("maintenance"))
+	for {
+		event, err := events.Recv()
+		if err != nil {
+			break
+		}
+		suite.NotEqual(pb.ControlMessage_BrokerEvent_UNKNOWN, event.GetEventType())
+	}
+	_, err = clients.Recv()
+	suite.Error(err)
+}
+
+func TestShutdownSuite(t *testing.T) {
+	suite.Run(t, new(ShutdownSuite))
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// Time between saves while the match clock is running, as the time it has
+// counted changes continuously
+const stateSaveInterval = time.Second
+
+// StateStore saves the broker's durable state to a file on every change, so
+// that a broker restarted after a crash or a shutdown picks up where it left
+// off: the simulation state, the connections between clients and robots, the
+// pairing rules, the match clock and the scores. Restored connections are
+// re-established by the pairer as their clients and robots register again.
+type StateStore struct {
+	path       string
+	broker     *Broker
+	pairer     *Pairer
+	clock      *MatchClock
+	scoreboard *Scoreboard
+
+	// Bound connections, robot by client name. They are tracked from the
+	// broker's events rather than looked up when saving, so that the
+	// connections a shutdown ends are kept.
+	connections map[string]string
+}
+
+// NewStateStore creates a store saving the broker's state to path, after
+// restoring the state saved there if there is one. It runs until the broker's
+// context is done or the broker starts shutting down.
+func NewStateStore(path string, broker *Broker, pairer *Pairer, clock *MatchClock, scoreboard *Scoreboard) (*StateStore, error) {
+	s := &StateStore{
+		path:        path,
+		broker:      broker,
+		pairer:      pairer,
+		clock:       clock,
+		scoreboard:  scoreboard,
+		connections: make(map[string]string),
+	}
+	saved, err := loadState(path)
+	if err != nil {
+		return nil, err
+	}
+	// Listen before restoring, so that no change is missed
+	events := broker.GetEventQueue(broker.ctx)
+	scores := scoreboard.Subscribe(broker.ctx)
+	if saved != nil {
+		if err := s.restore(saved); err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err.Error())
+		}
+	}
+	for _, conn := range broker.GetConnections() {
+		s.connections[conn.ClientName] = conn.RobotName
+	}
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	go s.run(events, scores)
+	return s, nil
+}
+
+// loadState reads the state saved at path, returning nil if nothing has been
+// saved there yet
+func loadState(path string) (*pb.BrokerState, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	state := &pb.BrokerState{}
+	if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(f, state); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return state, nil
+}
+
+func (s *StateStore) restore(state *pb.BrokerState) error {
+	rules := make([]PairingRule, 0, len(state.GetPairingRules()))
+	for _, r := range state.GetPairingRules() {
+		rule, err := ParsePairingRule(r)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+	connections := make(map[string]string, len(state.GetConnections()))
+	for _, conn := range state.GetConnections() {
+		connections[conn.GetClientName()] = conn.GetRobotName()
+	}
+	var elapsed time.Duration
+	if state.GetMatch().GetElapsed() != nil {
+		var err error
+		if elapsed, err = ptypes.Duration(state.GetMatch().GetElapsed()); err != nil {
+			return err
+		}
+	}
+	s.pairer.Restore(rules, connections)
+	s.clock.Restore(elapsed, state.GetMatch().GetExpired())
+	s.scoreboard.Restore(state.GetScores())
+	// Set last, as the match clock starts counting from here if the
+	// simulation was started
+	current := s.broker.GetSimState()
+	if simState := state.GetSimState(); simState != nil && simState.GetState() != current.State {
+		s.broker.SetSimState(*simState)
+	}
+	log.WithFields(logrus.Fields{
+		"file":        s.path,
+		"connections": len(connections),
+		"rules":       len(rules),
+		"state":       state.GetSimState().GetState(),
+	}).Info("Restored broker state")
+	return nil
+}
+
+func (s *StateStore) run(events <-chan Event, scores <-chan *pb.Scores) {
+	ticker := time.NewTicker(stateSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			switch event.Type {
+			case EventConnectionBound:
+				s.connections[event.ClientName] = event.RobotName
+			case EventConnectionUnbound:
+				delete(s.connections, event.ClientName)
+			case EventShuttingDown:
+				// Nothing the shutdown itself ends is saved, so that it is
+				// all restored on the next startup
+				s.trySave()
+				return
+			}
+		case <-scores:
+		case <-s.pairer.Changed():
+		case <-ticker.C:
+			if !s.clock.Status().Running {
+				continue
+			}
+		}
+		s.trySave()
+	}
+}
+
+func (s *StateStore) trySave() {
+	if err := s.save(); err != nil {
+		log.Errorf("Couldn't save broker state: %s", err.Error())
+	}
+}
+
+// save writes the current state to the store's file, replacing the previous
+// state only once the new one is fully written
+func (s *StateStore) save() error {
+	simState := s.broker.GetSimState()
+	status := s.clock.Status()
+	state := &pb.BrokerState{
+		SavedAt:  ptypes.TimestampNow(),
+		SimState: &simState,
+		Match: &pb.BrokerState_Match{
+			Elapsed: ptypes.DurationProto(status.Elapsed),
+			Expired: status.Expired,
+		},
+		Scores: s.scoreboard.Scores(),
+	}
+	// Restored connections awaiting their peers are kept until they are
+	// re-established or given up on
+	connections := s.pairer.Pending()
+	for client, robot := range s.connections {
+		connections[client] = robot
+	}
+	for client, robot := range connections {
+		state.Connections = append(state.Connections, &pb.BrokerState_Connection{ClientName: client, RobotName: robot})
+	}
+	sort.Slice(state.Connections, func(i, j int) bool {
+		return state.Connections[i].ClientName < state.Connections[j].ClientName
+	})
+	for _, rule := range s.pairer.Rules() {
+		state.PairingRules = append(state.PairingRules, rule.Rule)
+	}
+
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{Indent: "  "}).Marshal(&buf, state); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
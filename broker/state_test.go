@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type StateSuite struct {
+	suite.Suite
+	dir  string
+	path string
+	runs []context.CancelFunc
+}
+
+// brokerRun is a broker with everything whose state is saved
+type brokerRun struct {
+	ctx        context.Context
+	broker     *Broker
+	pairer     *Pairer
+	clock      *MatchClock
+	scoreboard *Scoreboard
+}
+
+func (suite *StateSuite) SetupTest() {
+	var err error
+	suite.dir, err = ioutil.TempDir("", "erebus-state")
+	suite.Require().NoError(err)
+	suite.path = filepath.Join(suite.dir, "state.json")
+	suite.runs = nil
+}
+
+func (suite *StateSuite) TearDownTest() {
+	for _, cancel := range suite.runs {
+		cancel()
+	}
+	os.RemoveAll(suite.dir)
+}
+
+// start starts a broker keeping its state in the suite's state file. A broker
+// which crashed is simulated by leaving a run alone and starting another.
+func (suite *StateSuite) start(rules ...string) *brokerRun {
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.runs = append(suite.runs, cancel)
+	run := &brokerRun{ctx: ctx, broker: NewBroker(ctx, SimInfo{timestep: 32})}
+	var parsed []PairingRule
+	for _, rule := range rules {
+		parsed = append(parsed, suite.rule(rule))
+	}
+	run.pairer = NewPairer(run.broker, parsed)
+	var err error
+	run.clock, err = NewMatchClock(run.broker, time.Minute)
+	suite.Require().NoError(err)
+	run.scoreboard = NewScoreboard(run.broker)
+	_, err = NewStateStore(suite.path, run.broker, run.pairer, run.clock, run.scoreboard)
+	suite.Require().NoError(err)
+	return run
+}
+
+// register registers a robot and a client which accept a connection when
+// bound
+func (suite *StateSuite) register(run *brokerRun, robotName string, clientName string) *RobotHandle {
+	robot := run.broker.RegisterRobot(robotName, run.ctx, &pb.RobotInfo{})
+	suite.Require().NotNil(robot)
+	go func() { <-robot.GetConnection() }()
+	client := run.broker.RegisterClient(clientName, run.ctx, false)
+	suite.Require().NotNil(client)
+	go func() { <-client.GetConnection() }()
+	return robot
+}
+
+func (suite *StateSuite) saved() *pb.BrokerState {
+	state, err := loadState(suite.path)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(state)
+	return state
+}
+
+func (suite *StateSuite) TestRestore() {
+	run := suite.start("team-*=spare")
+	suite.register(run, "robot", "client")
+	suite.Require().NoError(run.broker.ConnectClientToRobot("client", "robot"))
+	suite.Require().NoError(run.pairer.Add(suite.rule("team-a=robot0")))
+	suite.Require().NoError(run.scoreboard.Post(&pb.GameEvent{Type: pb.GameEvent_VICTIM_DELIVERED, RobotName: "robot", Points: 3}))
+	run.broker.SetSimState(pb.SimState{State: pb.SimState_START})
+	time.Sleep(closeTimeout)
+	run.broker.SetSimState(pb.SimState{State: pb.SimState_STOP})
+	time.Sleep(closeTimeout)
+
+	saved := suite.saved()
+	suite.Equal(pb.SimState_STOP, saved.GetSimState().GetState())
+	suite.Equal([]string{"team-*=spare", "team-a=robot0"}, saved.GetPairingRules())
+	suite.Require().Len(saved.GetConnections(), 1)
+	suite.Equal("client", saved.GetConnections()[0].GetClientName())
+	suite.Equal("robot", saved.GetConnections()[0].GetRobotName())
+
+	// The saved rules replace the configured ones
+	run = suite.start("team-b=robot1")
+	suite.Equal(pb.SimState_STOP, run.broker.GetSimState().State)
+	suite.Len(run.pairer.Rules(), 2)
+	suite.True(run.clock.Status().Elapsed >= closeTimeout)
+	scores := run.scoreboard.Scores()
+	suite.Require().Len(scores.GetRobots(), 1)
+	suite.Equal(int32(3), scores.GetRobots()[0].GetScore())
+	suite.Require().Len(scores.GetTeams(), 1)
+	suite.Equal("client", scores.GetTeams()[0].GetTeamName())
+
+	// The connection is re-established once both peers are back, even though
+	// a rule matches the client
+	run.broker.RegisterRobot("spare", run.ctx, nil)
+	suite.register(run, "robot", "client")
+	time.Sleep(closeTimeout)
+	conns := run.broker.GetConnections()
+	suite.Require().Len(conns, 1)
+	suite.Equal("robot", conns[0].RobotName)
+	suite.Empty(run.pairer.Pending())
+}
+
+func (suite *StateSuite) TestShutdownKeepsConnections() {
+	run := suite.start()
+	robot := suite.register(run, "robot", "client")
+	suite.Require().NoError(run.broker.ConnectClientToRobot("client", "robot"))
+	time.Sleep(closeTimeout)
+	suite.Require().NoError(run.broker.Shutdown(""))
+	robot.cancel()
+	time.Sleep(closeTimeout)
+	suite.Empty(run.broker.GetConnections())
+	suite.Len(suite.saved().GetConnections(), 1)
+}
+
+func (suite *StateSuite) TestRestoredConnectionGivenUp() {
+	run := suite.start()
+	suite.register(run, "robot", "client")
+	suite.Require().NoError(run.broker.ConnectClientToRobot("client", "robot"))
+	time.Sleep(closeTimeout)
+
+	// The client is connected to another robot while its robot is away
+	run = suite.start()
+	suite.register(run, "other", "client")
+	suite.Require().NoError(run.broker.ConnectClientToRobot("client", "other"))
+	run.broker.RegisterRobot("robot", run.ctx, nil)
+	time.Sleep(closeTimeout)
+	suite.Empty(run.pairer.Pending())
+	saved := suite.saved()
+	suite.Require().Len(saved.GetConnections(), 1)
+	suite.Equal("other", saved.GetConnections()[0].GetRobotName())
+}
+
+func (suite *StateSuite) rule(rule string) PairingRule {
+	parsed, err := ParsePairingRule(rule)
+	suite.Require().NoError(err)
+	return parsed
+}
+
+func TestStateSuite(t *testing.T) {
+	suite.Run(t, new(StateSuite))
+}
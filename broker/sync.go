@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// SyncPolicy determines what a robot in lockstep mode is sent when its client
+// fails to answer a sensor frame before the tick timeout
+type SyncPolicy int
+
+const (
+	// SyncPolicyRepeat re-sends the last commands the client sent
+	SyncPolicyRepeat SyncPolicy = iota
+	// SyncPolicyZero sets every motor the client has commanded to zero velocity
+	SyncPolicyZero
+	// SyncPolicyDrop sends no commands, leaving the robot's devices unchanged
+	SyncPolicyDrop
+)
+
+var syncPolicyNames = map[SyncPolicy]string{
+	SyncPolicyRepeat: "repeat",
+	SyncPolicyZero:   "zero",
+	SyncPolicyDrop:   "drop",
+}
+
+func (p SyncPolicy) String() string {
+	if name, ok := syncPolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("SyncPolicy(%d)", int(p))
+}
+
+// ParseSyncPolicy parses the name of a sync policy (repeat, zero or drop)
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	for policy, policyName := range syncPolicyNames {
+		if strings.EqualFold(name, policyName) {
+			return policy, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid sync policy \"%s\"", name)
+}
+
+// commands returns the commands to send to the robot in place of a late
+// client's reply, given the last commands the client sent
+func (p SyncPolicy) commands(last *pb.Commands) *pb.Commands {
+	switch p {
+	case SyncPolicyRepeat:
+		if last != nil {
+			return last
+		}
+	case SyncPolicyZero:
+		zero := &pb.Commands{}
+		for _, cmd := range last.GetCommands() {
+			if cmd.GetMotorCommand() != nil {
+				zero.Commands = append(zero.Commands, &pb.Command{
+					Name:    cmd.GetName(),
+					Command: &pb.Command_MotorCommand_{MotorCommand: &pb.Command_MotorCommand{Velocity: 0}},
+				})
+			}
+		}
+		return zero
+	}
+	return &pb.Commands{}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+const syncTestTimeout = 20 * time.Millisecond
+
+type SyncSuite struct {
+	suite.Suite
+	ctx       context.Context
+	ctxClose  context.CancelFunc
+	robotSd   chan *pb.SensorsData
+	robotCmd  chan *pb.Commands
+	clientSd  chan *pb.SensorsData
+	clientCmd chan *pb.Commands
+	logs      *test.Hook
+}
+
+func (suite *SyncSuite) SetupTest() {
+	suite.ctx, suite.ctxClose = context.WithCancel(context.Background())
+	suite.robotSd = make(chan *pb.SensorsData)
+	suite.robotCmd = make(chan *pb.Commands)
+	suite.clientSd = make(chan *pb.SensorsData)
+	suite.clientCmd = make(chan *pb.Commands)
+}
+
+func (suite *SyncSuite) TearDownTest() {
+	suite.ctxClose()
+}
+
+func (suite *SyncSuite) startRelay(policy SyncPolicy, chain ...Interceptor) {
+	logger, logs := test.NewNullLogger()
+	suite.logs = logs
+	r := &relay{
+		ctx:    suite.ctx,
+		logger: logger.WithField("test", suite.T().Name()),
+		chain:  chain,
+		sdIn:   suite.robotSd,
+		sdOut:  suite.clientSd,
+		cmdIn:  suite.clientCmd,
+		cmdOut: suite.robotCmd,
+	}
+	go r.runSync(syncTestTimeout, policy)
+}
+
+func motorCommands(name string, velocity float64) *pb.Commands {
+	return &pb.Commands{Commands: []*pb.Command{{
+		Name:    name,
+		Command: &pb.Command_MotorCommand_{MotorCommand: &pb.Command_MotorCommand{Velocity: velocity}},
+	}}}
+}
+
+func (suite *SyncSuite) TestClientReplyForwarded() {
+	suite.startRelay(SyncPolicyRepeat)
+	sd := &pb.SensorsData{Timestamp: 1}
+	suite.robotSd <- sd
+	suite.Equal(sd, <-suite.clientSd)
+	cmd := motorCommands("left wheel", 1)
+	suite.clientCmd <- cmd
+	suite.Equal(cmd, <-suite.robotCmd)
+}
+
+func (suite *SyncSuite) TestRepeatPolicy() {
+	suite.startRelay(SyncPolicyRepeat)
+	cmd := motorCommands("left wheel", 1)
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	<-suite.clientSd
+	suite.clientCmd <- cmd
+	<-suite.robotCmd
+	suite.robotSd <- &pb.SensorsData{Timestamp: 2}
+	<-suite.clientSd
+	suite.Equal(cmd, <-suite.robotCmd)
+}
+
+func (suite *SyncSuite) TestZeroPolicy() {
+	suite.startRelay(SyncPolicyZero)
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	<-suite.clientSd
+	suite.clientCmd <- motorCommands("left wheel", 1)
+	<-suite.robotCmd
+	suite.robotSd <- &pb.SensorsData{Timestamp: 2}
+	<-suite.clientSd
+	suite.Equal(motorCommands("left wheel", 0), <-suite.robotCmd)
+}
+
+func (suite *SyncSuite) TestDropPolicy() {
+	suite.startRelay(SyncPolicyDrop)
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	<-suite.clientSd
+	suite.Empty((<-suite.robotCmd).GetCommands())
+}
+
+func (suite *SyncSuite) TestLateReplyDiscarded() {
+	suite.startRelay(SyncPolicyDrop)
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	<-suite.clientSd
+	<-suite.robotCmd
+	suite.clientCmd <- motorCommands("left wheel", 1)
+	suite.robotSd <- &pb.SensorsData{Timestamp: 2}
+	<-suite.clientSd
+	cmd := motorCommands("left wheel", 2)
+	suite.clientCmd <- cmd
+	suite.Equal(cmd, <-suite.robotCmd)
+}
+
+func (suite *SyncSuite) TestDroppedFrameUsesPolicy() {
+	suite.startRelay(SyncPolicyDrop, InterceptorFuncs{
+		SensorsData: func(_ context.Context, sd *pb.SensorsData) *pb.SensorsData { return nil },
+	})
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	suite.Empty((<-suite.robotCmd).GetCommands())
+	suite.Empty(suite.warnings(), "Dropped frame reported as a missed deadline")
+}
+
+func (suite *SyncSuite) TestDroppedReplyUsesPolicy() {
+	suite.startRelay(SyncPolicyDrop, InterceptorFuncs{
+		Commands: func(_ context.Context, cmd *pb.Commands) *pb.Commands { return nil },
+	})
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	<-suite.clientSd
+	suite.clientCmd <- motorCommands("left wheel", 1)
+	suite.Empty((<-suite.robotCmd).GetCommands())
+	suite.Empty(suite.warnings(), "Dropped reply reported as a missed deadline")
+}
+
+func (suite *SyncSuite) TestPolicyCommandsObserved() {
+	observed := make(chan *pb.Commands, 1)
+	suite.startRelay(SyncPolicyZero, InterceptorFuncs{
+		Commands: func(_ context.Context, cmd *pb.Commands) *pb.Commands {
+			suite.Fail("Policy reply passed through the interceptors")
+			return cmd
+		},
+		PolicyCommands: func(_ context.Context, cmd *pb.Commands) { observed <- cmd },
+	})
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	<-suite.clientSd
+	cmd := <-suite.robotCmd
+	select {
+	case observed := <-observed:
+		suite.Equal(cmd, observed)
+	default:
+		suite.Fail("Policy reply not observed")
+	}
+}
+
+func (suite *SyncSuite) TestTimeoutWarned() {
+	suite.startRelay(SyncPolicyDrop)
+	suite.robotSd <- &pb.SensorsData{Timestamp: 1}
+	<-suite.clientSd
+	<-suite.robotCmd
+	suite.Len(suite.warnings(), 1)
+}
+
+// warnings returns the warnings the relay has logged
+func (suite *SyncSuite) warnings() []*logrus.Entry {
+	var warnings []*logrus.Entry
+	for _, entry := range suite.logs.AllEntries() {
+		if entry.Level == logrus.WarnLevel {
+			warnings = append(warnings, entry)
+		}
+	}
+	return warnings
+}
+
+func TestParseSyncPolicy(t *testing.T) {
+	for _, policy := range []SyncPolicy{SyncPolicyRepeat, SyncPolicyZero, SyncPolicyDrop} {
+		parsed, err := ParseSyncPolicy(policy.String())
+		if err != nil || parsed != policy {
+			t.Errorf("Failed to round-trip sync policy %s", policy)
+		}
+	}
+	if _, err := ParseSyncPolicy("invalid"); err == nil {
+		t.Error("Parsed invalid sync policy")
+	}
+}
+
+func TestSyncSuite(t *testing.T) {
+	suite.Run(t, new(SyncSuite))
+}
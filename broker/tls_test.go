@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type TLSSuite struct {
+	suite.Suite
+}
+
+func peerContext(authInfo credentials.AuthInfo) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: authInfo})
+}
+
+func (suite *TLSSuite) TestCheckPeerName() {
+	verified := peerContext(credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "red"}}}},
+	}})
+	suite.NoError(checkPeerName(verified, "red"))
+	suite.Error(checkPeerName(verified, "blue"))
+	// Peers without a verified certificate are left to token authentication
+	suite.NoError(checkPeerName(peerContext(credentials.TLSInfo{}), "blue"))
+	suite.NoError(checkPeerName(peerContext(nil), "blue"))
+	suite.NoError(checkPeerName(context.Background(), "blue"))
+}
+
+func TestTLSSuite(t *testing.T) {
+	suite.Run(t, new(TLSSuite))
+}
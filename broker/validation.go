@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// CommandValidator checks the commands of a client against the devices of the
+// robot it is bound to, and counts the commands it rejects. Robots which
+// declare no motors or LEDs predate device declarations, so their commands are
+// not validated.
+type CommandValidator struct {
+	motors map[string]*pb.MotorInfo
+	leds   map[string]*pb.LEDInfo
+
+	rejected uint64
+}
+
+func newCommandValidator(info *pb.RobotInfo) *CommandValidator {
+	v := &CommandValidator{
+		motors: make(map[string]*pb.MotorInfo),
+		leds:   make(map[string]*pb.LEDInfo),
+	}
+	for _, motor := range info.GetMotorInfos() {
+		v.motors[motor.GetName()] = motor
+	}
+	for _, led := range info.GetLedInfos() {
+		v.leds[led.GetName()] = led
+	}
+	return v
+}
+
+// Validate returns the valid commands of cmds, and an error for each invalid
+// one
+func (v *CommandValidator) Validate(cmds *pb.Commands) (*pb.Commands, []*pb.CommandError) {
+	if len(v.motors) == 0 && len(v.leds) == 0 {
+		return cmds, nil
+	}
+	valid := &pb.Commands{}
+	var errs []*pb.CommandError
+	for _, cmd := range cmds.GetCommands() {
+		if err := v.validate(cmd); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		valid.Commands = append(valid.Commands, cmd)
+	}
+	if len(errs) == 0 {
+		return cmds, nil
+	}
+	atomic.AddUint64(&v.rejected, uint64(len(errs)))
+	return valid, errs
+}
+
+func (v *CommandValidator) validate(cmd *pb.Command) *pb.CommandError {
+	name := cmd.GetName()
+	motor, isMotor := v.motors[name]
+	_, isLED := v.leds[name]
+	commandError := func(reason pb.CommandError_Reason, format string, a ...interface{}) *pb.CommandError {
+		return &pb.CommandError{Device: name, Reason: reason, Message: fmt.Sprintf(format, a...)}
+	}
+	if !isMotor && !isLED {
+		return commandError(pb.CommandError_UNKNOWN_DEVICE, "Robot has no motor or LED \"%s\"", name)
+	}
+	switch cmd.GetCommand().(type) {
+	case *pb.Command_MotorCommand_:
+		if !isMotor {
+			return commandError(pb.CommandError_WRONG_DEVICE_TYPE, "\"%s\" is not a motor", name)
+		}
+		velocity := cmd.GetMotorCommand().GetVelocity()
+		if math.IsNaN(velocity) || math.IsInf(velocity, 0) {
+			return commandError(pb.CommandError_VELOCITY_OUT_OF_RANGE, "Velocity %g of motor \"%s\" is not a finite number", velocity, name)
+		}
+		// A max velocity of 0 means the robot didn't declare one
+		if maxVelocity := motor.GetMaxVelocity(); maxVelocity > 0 && math.Abs(velocity) > maxVelocity {
+			return commandError(pb.CommandError_VELOCITY_OUT_OF_RANGE, "Velocity %g of motor \"%s\" is beyond its max of %g", velocity, name, maxVelocity)
+		}
+	case *pb.Command_LedCommand:
+		if !isLED {
+			return commandError(pb.CommandError_WRONG_DEVICE_TYPE, "\"%s\" is not an LED", name)
+		}
+	default:
+		return commandError(pb.CommandError_EMPTY_COMMAND, "Command for \"%s\" is empty", name)
+	}
+	return nil
+}
+
+// Rejected returns the number of commands rejected so far
+func (v *CommandValidator) Rejected() uint64 {
+	return atomic.LoadUint64(&v.rejected)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type ValidationSuite struct {
+	suite.Suite
+	validator *CommandValidator
+}
+
+func (suite *ValidationSuite) SetupTest() {
+	suite.validator = newCommandValidator(&pb.RobotInfo{
+		MotorInfos: []*pb.MotorInfo{
+			{Name: "left wheel", MaxVelocity: 6.28},
+			{Name: "arm"},
+		},
+		LedInfos: []*pb.LEDInfo{{Name: "led"}},
+	})
+}
+
+func motorCommand(name string, velocity float64) *pb.Command {
+	return &pb.Command{Name: name, Command: &pb.Command_MotorCommand_{MotorCommand: &pb.Command_MotorCommand{Velocity: velocity}}}
+}
+
+func ledCommand(name string, state int32) *pb.Command {
+	return &pb.Command{Name: name, Command: &pb.Command_LedCommand{LedCommand: &pb.Command_LEDCommand{State: state}}}
+}
+
+func (suite *ValidationSuite) TestValidCommands() {
+	cmds := &pb.Commands{Commands: []*pb.Command{
+		motorCommand("left wheel", -6.28),
+		motorCommand("arm", 100),
+		ledCommand("led", 1),
+	}}
+	valid, errs := suite.validator.Validate(cmds)
+	suite.Empty(errs)
+	suite.Equal(cmds, valid)
+	suite.Zero(suite.validator.Rejected())
+}
+
+func (suite *ValidationSuite) TestInvalidCommands() {
+	cmds := &pb.Commands{Commands: []*pb.Command{
+		motorCommand("right wheel", 1),
+		motorCommand("left wheel", 1),
+		ledCommand("left wheel", 1),
+		motorCommand("led", 1),
+		motorCommand("left wheel", 7),
+		motorCommand("arm", math.NaN()),
+		motorCommand("arm", math.Inf(-1)),
+		{Name: "led"},
+	}}
+	valid, errs := suite.validator.Validate(cmds)
+	suite.Equal([]*pb.Command{cmds.Commands[1]}, valid.GetCommands())
+	reasons := make([]pb.CommandError_Reason, len(errs))
+	for i, err := range errs {
+		reasons[i] = err.GetReason()
+	}
+	suite.Equal([]pb.CommandError_Reason{
+		pb.CommandError_UNKNOWN_DEVICE,
+		pb.CommandError_WRONG_DEVICE_TYPE,
+		pb.CommandError_WRONG_DEVICE_TYPE,
+		pb.CommandError_VELOCITY_OUT_OF_RANGE,
+		pb.CommandError_VELOCITY_OUT_OF_RANGE,
+		pb.CommandError_VELOCITY_OUT_OF_RANGE,
+		pb.CommandError_EMPTY_COMMAND,
+	}, reasons)
+	suite.Equal("right wheel", errs[0].GetDevice())
+	suite.NotEmpty(errs[0].GetMessage())
+	suite.Equal(uint64(7), suite.validator.Rejected())
+}
+
+func (suite *ValidationSuite) TestUndeclaredDevices() {
+	validator := newCommandValidator(&pb.RobotInfo{})
+	cmds := &pb.Commands{Commands: []*pb.Command{motorCommand("anything", 1000)}}
+	valid, errs := validator.Validate(cmds)
+	suite.Empty(errs)
+	suite.Equal(cmds, valid)
+}
+
+func TestValidationSuite(t *testing.T) {
+	suite.Run(t, new(ValidationSuite))
+}
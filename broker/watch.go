@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+// Number of messages buffered for each watcher before further messages are
+// dropped
+const watcherBuffer = 64
+
+// TrafficType is the kind of message described by a Traffic
+type TrafficType int
+
+const (
+	TrafficBound TrafficType = iota + 1
+	TrafficUnbound
+	TrafficSensorsData
+	TrafficCommands
+)
+
+// Traffic is a copy of a message passing through a watched robot's connection,
+// or a change in whether the robot is bound
+type Traffic struct {
+	Type       TrafficType
+	Time       time.Time
+	ClientName string
+	IsSync     bool // Set for TrafficBound
+	// Set for TrafficSensorsData and TrafficCommands; shared with the
+	// connection, so must not be modified
+	SensorsData *pb.SensorsData
+	Commands    *pb.Commands
+	// Set for TrafficCommands sent by the sync policy in place of the
+	// client's reply
+	FromPolicy bool
+	// Messages dropped since the last one received because the watcher fell
+	// behind
+	Dropped uint64
+}
+
+type watcher struct {
+	// Accessed atomically, so kept first for alignment
+	dropped uint64
+	ch      chan Traffic
+}
+
+// robotWatchers is the set of watchers of each robot. It has its own lock, so
+// that copying traffic to watchers doesn't contend with the broker's.
+type robotWatchers struct {
+	// Number of watchers of all robots, accessed atomically
+	watching int64
+
+	mu      sync.RWMutex
+	byRobot map[string]map[*watcher]struct{}
+}
+
+func newRobotWatchers() *robotWatchers {
+	return &robotWatchers{byRobot: make(map[string]map[*watcher]struct{})}
+}
+
+// publish sends traffic to every watcher of a robot, dropping it for watchers
+// whose buffers are full
+func (w *robotWatchers) publish(robotName string, traffic Traffic) {
+	// Skip taking the lock on every message of unwatched matches
+	if atomic.LoadInt64(&w.watching) == 0 {
+		return
+	}
+	traffic.Time = time.Now()
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for watcher := range w.byRobot[robotName] {
+		// Sensor data and commands are published concurrently, so the count
+		// is taken rather than read
+		traffic.Dropped = atomic.SwapUint64(&watcher.dropped, 0)
+		select {
+		case watcher.ch <- traffic:
+		default:
+			atomic.AddUint64(&watcher.dropped, traffic.Dropped+1)
+		}
+	}
+}
+
+// WatchRobot returns a channel which receives a copy of the sensor data and
+// commands passing through every connection the robot is bound in, and its
+// binds and unbinds, until ctx is done. If the robot is already bound, the
+// first message is a TrafficBound. Watchers never hold up the robot or its
+// client; messages are dropped if the watcher falls behind.
+func (b *Broker) WatchRobot(ctx context.Context, robotName string) (<-chan Traffic, error) {
+	// Bound and unbound traffic is published with b.mu held, so holding it
+	// here orders the current state before any change to it
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if _, ok := b.robots[robotName]; !ok {
+		return nil, errors.New("Robot not found")
+	}
+	w := &watcher{ch: make(chan Traffic, watcherBuffer)}
+	for _, conn := range b.connections {
+		if conn.robotName == robotName && conn.bound {
+			w.ch <- Traffic{Type: TrafficBound, Time: conn.boundSince, ClientName: conn.clientName, IsSync: conn.isSync}
+		}
+	}
+	b.watchers.mu.Lock()
+	defer b.watchers.mu.Unlock()
+	watchers, ok := b.watchers.byRobot[robotName]
+	if !ok {
+		watchers = make(map[*watcher]struct{})
+		b.watchers.byRobot[robotName] = watchers
+	}
+	watchers[w] = struct{}{}
+	atomic.AddInt64(&b.watchers.watching, 1)
+	go func() {
+		<-ctx.Done()
+		b.watchers.mu.Lock()
+		defer b.watchers.mu.Unlock()
+		delete(watchers, w)
+		if len(watchers) == 0 {
+			delete(b.watchers.byRobot, robotName)
+		}
+		atomic.AddInt64(&b.watchers.watching, -1)
+		close(w.ch)
+	}()
+	return w.ch, nil
+}
+
+// trafficTap copies the messages a relay forwards to the watchers of its
+// robot. A nil tap copies nothing.
+type trafficTap struct {
+	watchers   *robotWatchers
+	robotName  string
+	clientName string
+}
+
+func (t *trafficTap) sensorsData(sd *pb.SensorsData) {
+	if t == nil {
+		return
+	}
+	t.watchers.publish(t.robotName, Traffic{Type: TrafficSensorsData, ClientName: t.clientName, SensorsData: sd})
+}
+
+func (t *trafficTap) commands(cmd *pb.Commands) {
+	if t == nil {
+		return
+	}
+	t.watchers.publish(t.robotName, Traffic{Type: TrafficCommands, ClientName: t.clientName, Commands: cmd})
+}
+
+func (t *trafficTap) policyCommands(cmd *pb.Commands) {
+	if t == nil {
+		return
+	}
+	t.watchers.publish(t.robotName, Traffic{Type: TrafficCommands, ClientName: t.clientName, Commands: cmd, FromPolicy: true})
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/ethanwu10/erebus/broker/gen"
+)
+
+type WatchSuite struct {
+	suite.Suite
+	globalCtx      context.Context
+	globalCtxClose context.CancelFunc
+	broker         *Broker
+	robot          *RobotHandle
+	client         *ClientHandle
+}
+
+func (suite *WatchSuite) SetupTest() {
+	suite.globalCtx, suite.globalCtxClose = context.WithCancel(context.Background())
+	suite.broker = NewBroker(suite.globalCtx, SimInfo{timestep: 32})
+	suite.robot = suite.broker.RegisterRobot("robot", suite.globalCtx, nil)
+	suite.Require().NotNil(suite.robot)
+	suite.client = suite.broker.RegisterClient("client", suite.globalCtx, false)
+	suite.Require().NotNil(suite.client)
+}
+
+func (suite *WatchSuite) TearDownTest() {
+	suite.globalCtxClose()
+}
+
+// bind binds the client to the robot, returning both ends of the connection
+func (suite *WatchSuite) bind() (RobotConnection, ClientConnection) {
+	robotConnChan := make(chan RobotConnection, 1)
+	clientConnChan := make(chan ClientConnection, 1)
+	go func() { robotConnChan <- <-suite.robot.GetConnection() }()
+	go func() { clientConnChan <- <-suite.client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("client", "robot"))
+	return <-robotConnChan, <-clientConnChan
+}
+
+func (suite *WatchSuite) receive(traffic <-chan Traffic) Traffic {
+	select {
+	case t := <-traffic:
+		return t
+	case <-time.After(closeTimeout):
+		suite.FailNow("Watcher didn't receive traffic")
+		return Traffic{}
+	}
+}
+
+func (suite *WatchSuite) TestWatch() {
+	traffic, err := suite.broker.WatchRobot(suite.globalCtx, "robot")
+	suite.Require().NoError(err)
+	robotConn, clientConn := suite.bind()
+	t := suite.receive(traffic)
+	suite.Equal(TrafficBound, t.Type)
+	suite.Equal("client", t.ClientName)
+
+	sd := &pb.SensorsData{Timestamp: 1}
+	robotConn.SdOut <- sd
+	suite.Equal(sd, <-clientConn.SdIn)
+	t = suite.receive(traffic)
+	suite.Equal(TrafficSensorsData, t.Type)
+	suite.Equal(sd, t.SensorsData)
+
+	cmd := &pb.Commands{}
+	clientConn.CmdOut <- cmd
+	suite.Equal(cmd, <-robotConn.CmdIn)
+	t = suite.receive(traffic)
+	suite.Equal(TrafficCommands, t.Type)
+	suite.Equal(cmd, t.Commands)
+
+	suite.Require().NoError(suite.broker.DisconnectClientFromRobot("client"))
+	t = suite.receive(traffic)
+	suite.Equal(TrafficUnbound, t.Type)
+	suite.Equal("client", t.ClientName)
+}
+
+func (suite *WatchSuite) TestWatchPolicyCommands() {
+	suite.broker.simInfo.syncTimeout = closeTimeout
+	client := suite.broker.RegisterClient("sync-client", suite.globalCtx, true)
+	suite.Require().NotNil(client)
+	traffic, err := suite.broker.WatchRobot(suite.globalCtx, "robot")
+	suite.Require().NoError(err)
+	robotConnChan := make(chan RobotConnection, 1)
+	clientConnChan := make(chan ClientConnection, 1)
+	go func() { robotConnChan <- <-suite.robot.GetConnection() }()
+	go func() { clientConnChan <- <-client.GetConnection() }()
+	suite.Require().NoError(suite.broker.ConnectClientToRobot("sync-client", "robot"))
+	robotConn, clientConn := <-robotConnChan, <-clientConnChan
+	suite.receive(traffic)
+
+	robotConn.SdOut <- &pb.SensorsData{Timestamp: 1}
+	<-clientConn.SdIn
+	suite.receive(traffic)
+	cmd := <-robotConn.CmdIn
+	t := suite.receive(traffic)
+	suite.Equal(TrafficCommands, t.Type)
+	suite.Equal(cmd, t.Commands)
+	suite.True(t.FromPolicy)
+}
+
+func (suite *WatchSuite) TestWatchBoundRobot() {
+	suite.bind()
+	traffic, err := suite.broker.WatchRobot(suite.globalCtx, "robot")
+	suite.Require().NoError(err)
+	t := suite.receive(traffic)
+	suite.Equal(TrafficBound, t.Type)
+	suite.Equal("client", t.ClientName)
+}
+
+func (suite *WatchSuite) TestUnknownRobot() {
+	_, err := suite.broker.WatchRobot(suite.globalCtx, "nobody")
+	suite.Error(err)
+}
+
+func (suite *WatchSuite) TestSlowWatcher() {
+	robotConn, clientConn := suite.bind()
+	ctx, cancel := context.WithCancel(suite.globalCtx)
+	traffic, err := suite.broker.WatchRobot(ctx, "robot")
+	suite.Require().NoError(err)
+	<-traffic // Bound
+
+	// The match goes on while nobody reads the watcher's traffic
+	frames := watcherBuffer + 10
+	for i := 0; i < frames; i++ {
+		robotConn.SdOut <- &pb.SensorsData{Timestamp: float64(i)}
+		select {
+		case <-clientConn.SdIn:
+		case <-time.After(closeTimeout):
+			suite.FailNow("Client was held up by the watcher")
+		}
+	}
+	for i := 0; i < watcherBuffer; i++ {
+		suite.Equal(float64(i), (<-traffic).SensorsData.GetTimestamp())
+	}
+	robotConn.SdOut <- &pb.SensorsData{Timestamp: float64(frames)}
+	<-clientConn.SdIn
+	t := suite.receive(traffic)
+	suite.Equal(float64(frames), t.SensorsData.GetTimestamp())
+	suite.Equal(uint64(10), t.Dropped)
+
+	cancel()
+	time.Sleep(closeTimeout)
+	_, ok := <-traffic
+	suite.False(ok, "Watcher wasn't closed")
+}
+
+func TestWatchSuite(t *testing.T) {
+	suite.Run(t, new(WatchSuite))
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"io"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/ethanwu10/erebus/broker/gen"
 )
@@ -19,8 +22,13 @@ func NewWbControllerServer(broker *Broker) *WbControllerServer {
 }
 
 func (s *WbControllerServer) Session(srv pb.WbController_SessionServer) error {
+	// Ended early if another session resumes this one
+	streamCtx, cancelStream := context.WithCancel(srv.Context())
+	defer cancelStream()
 	hasInitialized := false
 	var robotHandle *RobotHandle
+	var missedSimStates []*pb.SimState
+	resumed := false
 	var name string
 	var logger *logrus.Entry
 	for !hasInitialized {
@@ -33,52 +41,150 @@ func (s *WbControllerServer) Session(srv pb.WbController_SessionServer) error {
 		}
 		switch msg.Message.(type) {
 		case *pb.WbControllerMessage_ClientMessage_WbControllerHandshake:
-			name = msg.GetWbControllerHandshake().GetRobotName()
+			handshake := msg.GetWbControllerHandshake()
+			name = handshake.GetRobotName()
 			logger = log.WithFields(logrus.Fields{
 				"robot": name,
 			})
-			// TODO: handle RobotInfo
-			robotHandle = s.broker.RegisterRobot(name, srv.Context())
-			if robotHandle == nil {
+			if !s.broker.beginSession() {
 				srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerHandshakeResponse{
 					WbControllerHandshakeResponse: &pb.WbControllerHandshakeResponse{Data: &pb.WbControllerHandshakeResponse_Error{
-						Error: "name in use",
+						Error: "Broker shutting down",
 					}},
 				}})
-				logger.Info("Robot rejected for duplicate name")
+				logger.Info("Robot rejected during shutdown")
+				s.broker.metrics.rejectHandshake("robot", "shutting_down")
 				return nil
 			}
+			defer s.broker.endSession()
+			if token := handshake.GetResumeToken(); token != "" {
+				robotHandle, missedSimStates, err = s.broker.ResumeRobot(name, token, cancelStream)
+				if err != nil {
+					srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerHandshakeResponse{
+						WbControllerHandshakeResponse: &pb.WbControllerHandshakeResponse{Data: &pb.WbControllerHandshakeResponse_Error{
+							Error: err.Error(),
+						}},
+					}})
+					logger.Infof("Robot resume rejected: %s", err.Error())
+					s.broker.metrics.rejectHandshake("robot", "resume")
+					return nil
+				}
+				resumed = true
+			} else {
+				robotHandle = s.broker.RegisterRobot(name, s.broker.ctx, handshake.GetRobotInfo())
+				if robotHandle == nil {
+					srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerHandshakeResponse{
+						WbControllerHandshakeResponse: &pb.WbControllerHandshakeResponse{Data: &pb.WbControllerHandshakeResponse_Error{
+							Error: "name in use",
+						}},
+					}})
+					logger.Info("Robot rejected for duplicate name")
+					s.broker.metrics.rejectHandshake("robot", "name_in_use")
+					return nil
+				}
+				s.broker.attach(&robotHandle.session, cancelStream)
+			}
 			hasInitialized = true
 			if err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerHandshakeResponse{
 				WbControllerHandshakeResponse: &pb.WbControllerHandshakeResponse{Data: &pb.WbControllerHandshakeResponse_Ok_{
-					Ok: &pb.WbControllerHandshakeResponse_Ok{Timestep: int32(s.broker.simInfo.timestep)},
+					Ok: &pb.WbControllerHandshakeResponse_Ok{
+						Timestep:    int32(s.broker.simInfo.timestep),
+						ResumeToken: robotHandle.session.resumeToken,
+						Resumed:     resumed,
+					},
 				}},
 			}}); err != nil {
 				logger.Errorf("Couldn't send handshake response: %s", err.Error())
+				// The robot never learned the token for a new session, so it
+				// can't be resumed
+				if resumed {
+					s.broker.DetachRobot(name, robotHandle)
+				} else {
+					robotHandle.cancel()
+				}
 				return err
 			}
 			logger.Info("Robot connected")
 		}
 	}
+	// A session the robot closes itself, or which is evicted for missing
+	// heartbeats, ends for good; any other end leaves the robot to be resumed
+	endsForGood := false
+	defer func() {
+		if endsForGood {
+			robotHandle.cancel()
+		} else {
+			s.broker.DetachRobot(name, robotHandle)
+		}
+	}()
 	incoming := make(chan *pb.WbControllerMessage_ClientMessage)
+	var recvErr error
 	go func() {
 		for {
 			msg, err := srv.Recv()
 			if err != nil {
-				// TODO: better handle incoming errors
+				recvErr = err
 				close(incoming)
 				return
 			}
 			incoming <- msg
 		}
 	}()
+	hb := newHeartbeat(s.broker.simInfo.heartbeatInterval, s.broker.simInfo.heartbeatMisses, logger)
+	hb.rttObserver = s.broker.metrics.heartbeatRTT.WithLabelValues("robot")
+	defer hb.stop()
+	// heartbeatTick pings the robot, and evicts it once it has missed too many
+	// heartbeats
+	heartbeatTick := func() error {
+		ping, ok := hb.tick()
+		if !ok {
+			logger.Warn("Robot missed too many heartbeats, evicting")
+			endsForGood = true
+			return status.Error(codes.Unavailable, "missed too many heartbeats")
+		}
+		if err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_Ping{Ping: ping}}); err != nil {
+			logger.Errorf("Couldn't send ping: %s", err.Error())
+			return err
+		}
+		return nil
+	}
+	// hungUp handles the robot closing its stream
+	hungUp := func() error {
+		logger.Info("Robot disconnected")
+		endsForGood = recvErr == io.EOF
+		return nil
+	}
+	connection, isBound := RobotConnection{}, false
+	if resumed {
+		connection, isBound = s.broker.RobotConnection(robotHandle)
+	}
 	for {
-		var connection RobotConnection
-		logger.Debug("Robot waiting for peer")
-		select {
-		case connection = <-robotHandle.GetConnection():
-		case <-srv.Context().Done():
-			return nil
+		if !isBound {
+			logger.Debug("Robot waiting for peer")
+		LAwaitPeer:
+			for {
+				select {
+				case connection = <-robotHandle.GetConnection():
+					break LAwaitPeer
+				case controllerMsg, ok := <-incoming:
+					if !ok {
+						// Remote hung up
+						return hungUp()
+					}
+					if pong := controllerMsg.GetPong(); pong != nil {
+						hb.pong(pong)
+					}
+				case <-hb.C():
+					if err := heartbeatTick(); err != nil {
+						return err
+					}
+				case <-s.broker.ShuttingDown():
+					endsForGood = true
+					return s.shutDownRobot(srv, robotHandle, false, logger)
+				case <-streamCtx.Done():
+					return streamEnded(srv)
+				}
+			}
 		}
 		if err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerBound{
 			WbControllerBound: &pb.WbControllerBound{IsSync: connection.IsSync},
@@ -86,6 +192,25 @@ func (s *WbControllerServer) Session(srv pb.WbController_SessionServer) error {
 			logger.Errorf("Couldn't send bound message: %s", err.Error())
 			return err
 		}
+		if isBound {
+			// Catch the resumed robot up on what it missed
+			for _, ssc := range missedSimStates {
+				if err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_SimStateChange{SimStateChange: ssc}}); err != nil {
+					logger.Errorf("Couldn't send sim state change message: %s", err.Error())
+					return err
+				}
+			}
+			if periods := connection.SensorSampling.Periods(); len(periods) > 0 {
+				err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_SensorSamplingPeriods{
+					SensorSamplingPeriods: &pb.SensorSamplingPeriods{Periods: periods},
+				}})
+				if err != nil {
+					logger.Errorf("Couldn't send sensor sampling periods message: %s", err.Error())
+					return err
+				}
+			}
+			isBound = false
+		}
 		logger.Debug("Robot got peer")
 	LBoundSession:
 		for {
@@ -93,11 +218,15 @@ func (s *WbControllerServer) Session(srv pb.WbController_SessionServer) error {
 			case controllerMsg, ok := <-incoming:
 				if !ok {
 					// Remote hung up
-					logger.Info("Robot disconnected")
-					return nil
+					return hungUp()
 				}
 				if sd := controllerMsg.GetSensorData(); sd != nil {
-					connection.SdOut <- sd
+					select {
+					case connection.SdOut <- sd:
+					case <-connection.Ctx.Done():
+					}
+				} else if pong := controllerMsg.GetPong(); pong != nil {
+					hb.pong(pong)
 				}
 			case cmd, ok := <-connection.CmdIn:
 				if !ok {
@@ -108,6 +237,7 @@ func (s *WbControllerServer) Session(srv pb.WbController_SessionServer) error {
 					logger.Errorf("Couldn't send commands message: %s", err.Error())
 					return err
 				}
+				connection.Metrics.CommandsSent()
 			case ssc, ok := <-connection.SimStateChange:
 				if !ok {
 					continue
@@ -117,6 +247,18 @@ func (s *WbControllerServer) Session(srv pb.WbController_SessionServer) error {
 					logger.Errorf("Couldn't send sim state change message: %s", err.Error())
 					return err
 				}
+			case <-connection.SensorSampling.Changed():
+				err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_SensorSamplingPeriods{
+					SensorSamplingPeriods: &pb.SensorSamplingPeriods{Periods: connection.SensorSampling.Periods()},
+				}})
+				if err != nil {
+					logger.Errorf("Couldn't send sensor sampling periods message: %s", err.Error())
+					return err
+				}
+			case <-hb.C():
+				if err := heartbeatTick(); err != nil {
+					return err
+				}
 			case <-connection.Ctx.Done():
 				err := srv.Send(&pb.WbControllerMessage_ServerMessage{Message: &pb.WbControllerMessage_ServerMessage_WbControllerUnbound{WbControllerUnbound: &pb.WbControllerUnbound{}}})
 				if err != nil {
@@ -124,6 +266,11 @@ func (s *WbControllerServer) Session(srv pb.WbController_SessionServer) error {
 					return err
 				}
 				break LBoundSession
+			case <-s.broker.ShuttingDown():
+				endsForGood = true
+				return s.shutDownRobot(srv, robotHandle, true, logger)
+			case <-streamCtx.Done():
+				return streamEnded(srv)
 			}
 		}
 	}
@@ -2,3 +2,4 @@ from .client import Client
 from .behavior import Behavior
 from .sensors import Sensors, XYPair, RecognitionObject
 from .commands import Commands
+from .robot_info import RobotInfo
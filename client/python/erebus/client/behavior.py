@@ -4,6 +4,9 @@ from .commands import Commands
 
 
 class Behavior(abc.ABC):
+    # The devices of the robot the client is bound to (a RobotInfo), set
+    # before the first tick
+    robotInfo = None
 
     @abc.abstractmethod
     def tick(self, sensorData: Sensors, commands: Commands):
@@ -1,5 +1,6 @@
 import threading
 import queue
+import time
 import grpc
 from . import client_controller_pb2_grpc
 from . import client_controller_pb2
@@ -8,33 +9,93 @@ from . import sim_pb2
 from .sensors import Sensors
 from .commands import Commands
 from .behavior import Behavior
+from .robot_info import RobotInfo
+
+# Handshake error when resuming a session the broker hasn't noticed has dropped
+SESSION_ACTIVE = 'Session still active, try again'
 
 
 class WorkerThread(threading.Thread):
     def __init__(self, behaviorClass: Behavior,
                  inQueue: queue.Queue, outQueue: queue.Queue,
-                 *args, **kwargs):
+                 samplingPeriods: dict, previous=None, *args, **kwargs):
+        """
+        previous is the worker of the session this one resumes, if any, whose
+        behavior is carried on if the broker kept the robot bound
+        """
         super().__init__(*args, **kwargs)
         self.behaviorClass = behaviorClass
         self.inQueue = inQueue
         self.outQueue = outQueue
+        self.samplingPeriods = samplingPeriods
+        self.robotInfo = None
+        self.behaviorObj = None
+        if previous is not None and previous.behaviorObj is None:
+            # previous never got bound again
+            previous = previous.previous
+        self.previous = previous
+        self.resumeToken = ''
+        self.handshakeError = None
+
+    def newBehavior(self):
+        behaviorObj = self.behaviorClass()
+        behaviorObj.robotInfo = self.robotInfo
+        return behaviorObj
 
     def run(self):
         for serverMsg in iter(self.inQueue.get, None):
             if serverMsg.HasField('client_controller_handshake_response'):
-                print('Handshake successful, connected.')
+                res = serverMsg.client_controller_handshake_response
+                if res.HasField('error'):
+                    print('Handshake rejected: {}'.format(res.error))
+                    self.handshakeError = res.error
+                    continue
+                self.resumeToken = res.ok.resume_token
+                if res.ok.resumed:
+                    print('Session resumed, reconnected.')
+                else:
+                    print('Handshake successful, connected.')
+                    self.previous = None
             if serverMsg.HasField('client_controller_bound'):
                 print('robot bound')
-                # TODO: maybe init when sim first transitions to running after
-                # a reset?
-                self.behaviorObj = self.behaviorClass()
+                self.robotInfo = RobotInfo(
+                    serverMsg.client_controller_bound.robot_info)
+                if self.samplingPeriods:
+                    samplingMsg = client_controller_pb2. \
+                        ClientControllerMessage.ControllerMessage()
+                    for name, period in self.samplingPeriods.items():
+                        samplingPeriod = samplingMsg.sensor_sampling_periods. \
+                            periods.add()
+                        samplingPeriod.name = name
+                        samplingPeriod.sampling_period = period
+                    self.outQueue.put(samplingMsg)
+                if self.previous is not None and \
+                        self.previous.behaviorObj is not None:
+                    # Still bound to the same robot after resuming
+                    self.behaviorObj = self.previous.behaviorObj
+                else:
+                    # TODO: maybe init when sim first transitions to running
+                    # after a reset?
+                    self.behaviorObj = self.newBehavior()
+                self.previous = None
+            if serverMsg.HasField('sensor_sampling_periods_response'):
+                res = serverMsg.sensor_sampling_periods_response
+                if res.HasField('error'):
+                    print('sampling periods rejected: {}'.format(res.error))
+            if serverMsg.HasField('command_errors'):
+                for error in serverMsg.command_errors.errors:
+                    print('command rejected: {}'.format(error.message))
             if serverMsg.HasField('client_controller_unbound'):
                 self.behaviorObj = None
+                self.robotInfo = None
                 print('robot unbound')
+            if serverMsg.HasField('server_shutting_down'):
+                print('Broker shutting down: {}'.format(
+                    serverMsg.server_shutting_down.reason))
             if serverMsg.HasField('sim_state_change'):
                 simState = serverMsg.sim_state_change
                 if simState.state == sim_pb2.SimState.RESET:
-                    self.behaviorObj = self.behaviorClass()
+                    self.behaviorObj = self.newBehavior()
             # TODO: handle sim_state_change
             if serverMsg.HasField('ping'):
                 pong = client_controller_pb2.ClientControllerMessage.\
@@ -50,29 +111,110 @@ class WorkerThread(threading.Thread):
                 self.outQueue.put(controllerMsg)
 
 
+def readFile(path):
+    if path is None:
+        return None
+    with open(path, 'rb') as f:
+        return f.read()
+
+
 class Client:
-    def __init__(self, behaviorClass: Behavior, name: str):
+    def __init__(self, behaviorClass: Behavior, name: str,
+                 requestSync: bool = True, samplingPeriods: dict = None,
+                 token: str = '', resumeAttempts: int = 5, arena: str = ''):
         """
         Create an Erebus client using the provided name and behavior class
+
+        If requestSync is true, the simulation waits for this client to
+        respond to each tick (up to a time limit set by the broker)
+
+        samplingPeriods maps sensor names to how often (in ms) they should be
+        sampled, with 0 turning a sensor off; periods must be multiples of the
+        simulation timestep, and sensors not listed are sampled every timestep
+
+        token is the secret of the team named by name, which the broker may
+        require to accept the client
+
+        If the connection to the broker drops, the client reconnects and
+        resumes its session, keeping its robot, up to resumeAttempts times in
+        a row (0 to give up straight away)
+
+        arena names the arena to play in, if the broker runs several
         """
         self.behaviorClass = behaviorClass
         self.name = name
+        self.requestSync = requestSync
+        self.samplingPeriods = samplingPeriods or dict()
+        self.token = token
+        self.resumeAttempts = resumeAttempts
+        self.resumeToken = ''
+        self.arena = arena
 
-    def run(self, address='127.0.0.1:51512'):
+    def run(self, address='127.0.0.1:51512', tlsCA: str = None,
+            tlsCert: str = None, tlsKey: str = None):
         """
         Run the client and connect to the broker server at the provided IP
         address and port
+
+        If tlsCA or tlsCert is given, the connection uses TLS; tlsCA is the
+        path of the CA certificates to verify the broker with (default: the
+        system's CAs), and tlsCert and tlsKey are the paths of your team's
+        client certificate and its private key
         """
-        channel = grpc.insecure_channel(address)
+        if tlsCA is None and tlsCert is None:
+            channel = grpc.insecure_channel(address)
+        else:
+            channel = grpc.secure_channel(address, grpc.ssl_channel_credentials(
+                root_certificates=readFile(tlsCA),
+                private_key=readFile(tlsKey),
+                certificate_chain=readFile(tlsCert)))
         stub = client_controller_pb2_grpc.ClientControllerStub(channel)
+        self.worker = None
+        attempts = 0
+        while True:
+            try:
+                if self.session(stub):
+                    return
+                # The broker was still ending the dropped session
+                time.sleep(0.5)
+            except grpc.RpcError as e:
+                if e.code() in [grpc.StatusCode.ABORTED,
+                                grpc.StatusCode.CANCELLED]:
+                    # Another session took over
+                    raise
+                if self.worker.resumeToken:
+                    # The dropped session got through the handshake
+                    attempts = 0
+                    self.resumeToken = self.worker.resumeToken
+                if not self.resumeToken or attempts >= self.resumeAttempts:
+                    raise
+                delay = 0.5 * 2 ** attempts
+                attempts += 1
+                print('Connection lost ({}), resuming in {}s'.format(
+                    e.code().name, delay))
+                time.sleep(delay)
+
+    def session(self, stub):
+        """
+        Run a single session with the broker, resuming the last one if there
+        is a resume token
+
+        Returns False if the session should be retried
+        """
         inQueue = queue.Queue()
         outQueue = queue.Queue()
         handshakeMsg = client_controller_pb2.ClientControllerMessage \
             .ControllerMessage()
         handshake = handshakeMsg.client_controller_handshake
         handshake.client_name = self.name
+        handshake.request_sync = self.requestSync
+        handshake.token = self.token
+        handshake.resume_token = self.resumeToken
+        handshake.arena = self.arena
         outQueue.put(handshakeMsg)
-        wt = WorkerThread(self.behaviorClass, inQueue, outQueue)
+        wt = WorkerThread(self.behaviorClass, inQueue, outQueue,
+                          self.samplingPeriods, previous=self.worker)
+        self.worker = wt
         wt.start()
         try:
             for serverMsg in stub.Session(iter(outQueue.get, None),
@@ -80,4 +222,14 @@ class Client:
                 inQueue.put(serverMsg)
         finally:
             inQueue.put(None)
+            outQueue.put(None)
             wt.join()
+        if wt.handshakeError is not None and self.resumeToken:
+            if wt.handshakeError == SESSION_ACTIVE:
+                return False
+            # The session expired; start a new one
+            print('Starting a new session')
+            self.resumeToken = ''
+            self.worker = None
+            return False
+        return True
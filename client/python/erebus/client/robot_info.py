@@ -0,0 +1,48 @@
+from . import sim_pb2
+
+
+class RobotInfo:
+    """
+    The devices of the robot a client is bound to, as declared by the robot
+    """
+
+    def __init__(self, robotInfo: sim_pb2.RobotInfo):
+        self.robotInfo = robotInfo
+        self.distanceSensors = []
+        self.positionSensors = []
+        self.inertialSensors = []
+        self.cameras = []
+        for sensorInfo in robotInfo.sensor_infos:
+            if sensorInfo.type == sim_pb2.SensorType.DISTANCE_SENSOR:
+                self.distanceSensors.append(sensorInfo.name)
+            if sensorInfo.type == sim_pb2.SensorType.POSITION_SENSOR:
+                self.positionSensors.append(sensorInfo.name)
+            if sensorInfo.type == sim_pb2.SensorType.INERTIAL_SENSOR:
+                self.inertialSensors.append(sensorInfo.name)
+            if sensorInfo.type in (
+                    sim_pb2.SensorType.CAMERA_SENSOR,
+                    sim_pb2.SensorType.CAMERA_RECOGNITION_SENSOR):
+                self.cameras.append(sensorInfo.name)
+        self.motors = {m.name: m.max_velocity for m in robotInfo.motor_infos}
+        self.leds = [led.name for led in robotInfo.led_infos]
+
+    def getDistanceSensorNames(self):
+        return list(self.distanceSensors)
+
+    def getPositionSensorNames(self):
+        return list(self.positionSensors)
+
+    def getInertialSensorNames(self):
+        return list(self.inertialSensors)
+
+    def getCameraNames(self):
+        return list(self.cameras)
+
+    def getMotorNames(self):
+        return list(self.motors)
+
+    def getMotorMaxVelocity(self, name):
+        return self.motors[name]
+
+    def getLEDNames(self):
+        return list(self.leds)
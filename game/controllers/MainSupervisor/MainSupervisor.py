@@ -10,11 +10,41 @@ Changelog:
 
  - Remove clock controls and controller restarting
  - Remove robot controller handling
+ - Report game events to the broker's scoreboard
+ - Let the referee give penalties from the robot window
 """
 
 from controller import Supervisor
+from threading import Thread
 import os
+import queue
 import random
+import sys
+
+# The scoreboard's generated gRPC modules, and the helper connecting to the
+# broker, are alongside the Erebus supervisor controller
+sys.path.append(os.path.join(os.path.dirname(os.path.abspath(__file__)),
+                             '..', '..', '..', 'wb-controllers',
+                             'erebus-supervisor-controller'))
+try:
+    import grpc
+    import scoreboard_pb2
+    import scoreboard_pb2_grpc
+    from broker_channel import brokerChannel
+except ImportError:
+    grpc = None
+
+# Address of the broker whose scoreboard game events are reported to
+BROKER_ADDRESS = os.environ.get('EREBUS_BROKER_ADDRESS', '127.0.0.1:51512')
+
+# Connections to a broker using TLS are configured by the EREBUS_TLS_CA,
+# EREBUS_TLS_CERT and EREBUS_TLS_KEY environment variables
+
+# Token for the broker's Scoreboard service, if it requires one
+ADMIN_TOKEN = os.environ.get('EREBUS_ADMIN_TOKEN', '')
+
+# Arena this world runs in, if the broker runs several
+ARENA = os.environ.get('EREBUS_ARENA', '')
 
 # Create the instance of the supervisor class
 supervisor = Supervisor()
@@ -37,6 +67,54 @@ mainSupervisor = supervisor.getSelf()
 # Maximum time for a match
 maxTime = 120
 
+# Points deducted for each penalty the referee gives
+penaltyPoints = 1
+
+
+class ScoreReporter(Thread):
+    '''Posts game events to the broker's scoreboard in the background, so a slow
+    or missing broker never holds up the match'''
+
+    def __init__(self, address: str):
+        super().__init__(daemon=True)
+        self.events = queue.Queue()
+        self.enabled = grpc is not None
+        if not self.enabled:
+            print("gRPC modules not found, not reporting to the scoreboard")
+            return
+        self.stub = scoreboard_pb2_grpc.ScoreboardStub(brokerChannel(address))
+        self.metadata = [('authorization', 'Bearer ' + ADMIN_TOKEN)] \
+            if ADMIN_TOKEN else []
+        if ARENA:
+            self.metadata.append(('arena', ARENA))
+
+    def report(self, robotNode, eventType: str, points: int = 0,
+               description: str = "") -> None:
+        '''Queue an event of the given GameEvent type name for a robot'''
+        if not self.enabled:
+            return
+        self.events.put(scoreboard_pb2.GameEvent(
+            type=scoreboard_pb2.GameEvent.Type.Value(eventType),
+            robot_name=robotNode.getField("name").getSFString(),
+            points=points,
+            description=description,
+            timestamp=supervisor.getTime()
+        ))
+
+    def run(self) -> None:
+        if not self.enabled:
+            return
+        while True:
+            event = self.events.get()
+            try:
+                response = self.stub.PostGameEvent(event,
+                                                   metadata=self.metadata,
+                                                   timeout=5)
+                if response.WhichOneof('data') == 'error':
+                    print("Scoreboard rejected event:", response.error)
+            except grpc.RpcError as e:
+                print("Couldn't report event to the scoreboard:", e.code())
+
 
 class Robot:
     '''Robot object to hold values whether its in a base or holding a human'''
@@ -200,6 +278,10 @@ gameStarted = False
 robot0Obj = Robot()
 robot1Obj = Robot()
 
+# Report scores to the broker
+scoreReporter = ScoreReporter(BROKER_ADDRESS)
+scoreReporter.start()
+
 # Both robots start in bases
 #robot0InBase = True
 #robot1InBase = True
@@ -245,6 +327,7 @@ while simulationRunning:
                 if robot0Obj.timeStopped(robot0) >= 2:
                     print("Robot 0 is near a human")
                     robot0Obj.increaseScore(1)
+                    scoreReporter.report(robot0, 'VICTIM_PICKED_UP', 1)
                     robot0Obj.loadHuman()
 
                     # send message to robot window saying human is loaded
@@ -260,6 +343,7 @@ while simulationRunning:
                 if robot1Obj.timeStopped(robot1) >= 2:
                     print("Robot 1 is near a human")
                     robot1Obj.increaseScore(1)
+                    scoreReporter.report(robot1, 'VICTIM_PICKED_UP', 1)
                     robot1Obj.loadHuman()
 
                     # send message to robot window saying human is loaded
@@ -274,8 +358,10 @@ while simulationRunning:
         robot0Obj.inBase = r0
         if robot0Obj.inBase:
             print("Robot 0 entered a base")
+            scoreReporter.report(robot0, 'BASE_ENTERED')
         else:
             print("Robot 0 exited a base")
+            scoreReporter.report(robot0, 'BASE_EXITED')
 
     if robot0Obj.inBase:
         if robot0Obj.hasHumanLoaded():
@@ -284,6 +370,7 @@ while simulationRunning:
                 # if robot has a human loaded, gain a point
                 robot0Obj.increaseScore(1)
                 robot0Obj.unLoadHuman()
+                scoreReporter.report(robot0, 'VICTIM_DELIVERED', 1)
 
                 # send message to robot window saying human is unloaded
                 supervisor.wwiSendText("humanUnloaded0")
@@ -295,8 +382,10 @@ while simulationRunning:
         robot1Obj.inBase = r1
         if robot1Obj.inBase:
             print("Robot 1 entered a base")
+            scoreReporter.report(robot1, 'BASE_ENTERED')
         else:
             print("Robot 1 exited a base")
+            scoreReporter.report(robot1, 'BASE_EXITED')
 
     if robot1Obj.inBase:
         if robot1Obj.hasHumanLoaded():
@@ -305,6 +394,7 @@ while simulationRunning:
                 # if robot has a human loaded, gain a point
                 robot1Obj.increaseScore(1)
                 robot1Obj.unLoadHuman()
+                scoreReporter.report(robot1, 'VICTIM_DELIVERED', 1)
 
                 # send message to robot window saying human is unloaded
                 supervisor.wwiSendText("humanUnloaded1")
@@ -343,6 +433,20 @@ while simulationRunning:
             if parts[0] == "pause":
                 # Pause the match
                 currentlyRunning = False
+            if parts[0] == "penalty" and len(parts) > 2:
+                # The referee penalised a robot, giving the reason (which may
+                # itself contain commas)
+                reason = ",".join(parts[2:])
+                if parts[1] == "0":
+                    robot0Obj.increaseScore(-penaltyPoints)
+                    scoreReporter.report(robot0, 'PENALTY', -penaltyPoints,
+                                         reason)
+                    print("Robot 0 penalised:", reason)
+                if parts[1] == "1":
+                    robot1Obj.increaseScore(-penaltyPoints)
+                    scoreReporter.report(robot1, 'PENALTY', -penaltyPoints,
+                                         reason)
+                    print("Robot 1 penalised:", reason)
             if parts[0] == "reset":
                 # Reset the simulation
                 supervisor.simulationReset()
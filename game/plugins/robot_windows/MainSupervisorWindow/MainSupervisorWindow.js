@@ -227,6 +227,15 @@ function unloadPressed(id){
 	}
 }
 
+function penaltyPressed(id){
+	//Penalty button pressed
+	//Ask the referee why, and send the penalty for the robot (cancelled if no reason is given)
+	var reason = window.prompt("Reason for penalising robot " + String(id));
+	if (reason){
+		window.robotWindow.send("penalty," + String(id) + "," + reason);
+	}
+}
+
 function file0Opened(){
 	//When file 0 value is changed
 	//Get the files
@@ -1,20 +1,48 @@
 from controller import Robot
 from queue import Queue
 from threading import Thread
+from os import environ, path
+from tempfile import gettempdir
+from time import sleep
+import re
+import sys
 import grpc
 import sim_pb2
 import wb_controller_pb2
 import wb_controller_pb2_grpc
 
+# The helper connecting to the broker is shared with the Erebus supervisor
+# controller
+sys.path.append(path.join(path.dirname(path.realpath(__file__)),
+                          '..', 'erebus-supervisor-controller'))
+from broker_channel import brokerChannel  # noqa: E402
+
 
 # Note: localhost doesn't always work correctly - use 127.0.0.1
 BROKER_ADDRESS = '127.0.0.1:51512'
 
+# Arena the robot is in, if the broker runs several
+ARENA = environ.get('EREBUS_ARENA', '')
+
 MOTORS = ['left wheel', 'right wheel']
 DISTANCE_SENSORS = ['so{}'.format(i) for i in range(8)]
 POSITION_SENSORS = ['left wheel sensor', 'right wheel sensor']
 CAMERA_SENSORS = ['camera']
 INERTIAL_SENSORS = []
+LEDS = []
+
+# Handshake error when resuming a session the broker hasn't noticed has dropped
+SESSION_ACTIVE = 'Session still active, try again'
+
+# Ways a session can end
+SESSION_ENDED = 'ended'
+# Resuming the session was rejected; a new one should be started
+SESSION_RESUME_REJECTED = 'resume rejected'
+# The session to resume is still active; resuming should be tried again
+SESSION_RETRY = 'retry'
+
+# Connections to a broker using TLS are configured by the EREBUS_TLS_CA,
+# EREBUS_TLS_CERT and EREBUS_TLS_KEY environment variables
 
 
 def _cvtRecognitionObject(ro):
@@ -65,20 +93,76 @@ def gatherInertialSensorData(robot, name):
     return sd
 
 
-def gatherSensorsData(robot):
+def gatherSensorsData(robot, samplingPeriods):
+    """
+    Gather the readings of all sensors which are turned on (sensors not in
+    samplingPeriods are sampled every timestep)
+    """
     sds = sim_pb2.SensorsData()
     sds.timestamp = robot.getTime()
-    for camera in CAMERA_SENSORS:
-        sds.data.append(gatherCameraRecognitionData(robot, camera))
-    for ds in DISTANCE_SENSORS:
-        sds.data.append(gatherDistanceSensorData(robot, ds))
-    for ps in POSITION_SENSORS:
-        sds.data.append(gatherPositionSensorData(robot, ps))
-    for imu in INERTIAL_SENSORS:
-        sds.data.append(gatherInertialSensorData(robot, imu))
+    sensors = [
+        (CAMERA_SENSORS, gatherCameraRecognitionData),
+        (DISTANCE_SENSORS, gatherDistanceSensorData),
+        (POSITION_SENSORS, gatherPositionSensorData),
+        (INERTIAL_SENSORS, gatherInertialSensorData),
+    ]
+    for names, gather in sensors:
+        for name in names:
+            if samplingPeriods.get(name) != 0:
+                sds.data.append(gather(robot, name))
     return sds
 
 
+def setSamplingPeriod(robot, name, period):
+    """
+    Enable a sensor with the given sampling period (ms), or disable it if the
+    period is 0
+    """
+    if name in CAMERA_SENSORS:
+        c = robot.getCamera(name)
+        if period:
+            c.enable(period)
+            c.recognitionEnable(period)
+        else:
+            c.recognitionDisable()
+            c.disable()
+        return
+    if name in DISTANCE_SENSORS:
+        sensor = robot.getDistanceSensor(name)
+    elif name in POSITION_SENSORS:
+        sensor = robot.getPositionSensor(name)
+    elif name in INERTIAL_SENSORS:
+        sensor = robot.getInertialUnit(name)
+    else:
+        return
+    if period:
+        sensor.enable(period)
+    else:
+        sensor.disable()
+
+
+def gatherRobotInfo(robot):
+    info = sim_pb2.RobotInfo()
+    sensors = [
+        (CAMERA_SENSORS, sim_pb2.SensorType.CAMERA_RECOGNITION_SENSOR),
+        (DISTANCE_SENSORS, sim_pb2.SensorType.DISTANCE_SENSOR),
+        (POSITION_SENSORS, sim_pb2.SensorType.POSITION_SENSOR),
+        (INERTIAL_SENSORS, sim_pb2.SensorType.INERTIAL_SENSOR),
+    ]
+    for names, sensorType in sensors:
+        for name in names:
+            sensorInfo = info.sensor_infos.add()
+            sensorInfo.name = name
+            sensorInfo.type = sensorType
+    for motor in MOTORS:
+        motorInfo = info.motor_infos.add()
+        motorInfo.name = motor
+        motorInfo.max_velocity = robot.getMotor(motor).getMaxVelocity()
+    for led in LEDS:
+        info.led_infos.add().name = led
+    return info
+
+
 def applyCommands(robot, commands):
     for cmd in commands.commands:
         if cmd.HasField('motor_command'):
@@ -99,10 +183,25 @@ class WbtTicker(Thread):
         self.syncChannel = syncChannel
         self.doneFunc = doneFunc
         self.isRunning = False
+        self.samplingPeriods = dict()
+        self.samplingQueue = Queue()
+
+    def setSamplingPeriods(self, periods):
+        """
+        Change sensor sampling periods from another thread; they take effect
+        from the next step
+        """
+        self.samplingQueue.put(periods)
+
+    def applySamplingPeriods(self):
+        while not self.samplingQueue.empty():
+            for period in self.samplingQueue.get().periods:
+                self.samplingPeriods[period.name] = period.sampling_period
+                setSamplingPeriod(self.robot, period.name,
+                                  period.sampling_period)
 
     def run(self):
         # init
-        # TODO: client-configurable sensor init
         for camera in CAMERA_SENSORS:
             c = self.robot.getCamera(camera)
             c.enable(self.step)
@@ -121,8 +220,10 @@ class WbtTicker(Thread):
         # run
         self.isRunning = True
         while self.isRunning:
+            self.applySamplingPeriods()
             sdMsg = wb_controller_pb2.WbControllerMessage.ClientMessage()
-            sdMsg.sensor_data.CopyFrom(gatherSensorsData(self.robot))
+            sdMsg.sensor_data.CopyFrom(
+                gatherSensorsData(self.robot, self.samplingPeriods))
             self.dataChannel.put(sdMsg)
 
             if self.syncChannel is not None:
@@ -163,16 +264,67 @@ class WbtIdleTicker(Thread):
                 self.doneFunc()
 
 
+def resumeTokenFile(name):
+    """
+    Path of the file keeping the resume token of the robot's session, so that
+    a restarted controller can resume it. Sessions with different brokers or
+    arenas are kept in different files.
+    """
+    key = '-'.join([BROKER_ADDRESS, ARENA, name])
+    return path.join(gettempdir(), 'erebus-robot-{}.token'.format(
+        re.sub(r'[^A-Za-z0-9._-]+', '_', key)))
+
+
+def readResumeToken(name):
+    try:
+        with open(resumeTokenFile(name)) as f:
+            return f.read().strip()
+    except OSError:
+        return ''
+
+
+def writeResumeToken(name, token):
+    try:
+        with open(resumeTokenFile(name), 'w') as f:
+            f.write(token)
+    except OSError as e:
+        print('Could not save resume token: {}'.format(e))
+
+
 def main():
     robot = Robot()
     assert robot.getSynchronization()
     name = robot.getName()
-    channel = grpc.insecure_channel(BROKER_ADDRESS)
+    channel = brokerChannel(BROKER_ADDRESS)
     stub = wb_controller_pb2_grpc.WbControllerStub(channel)
+    resumeToken = readResumeToken(name)
+    while True:
+        result = session(robot, name, stub, resumeToken)
+        if result == SESSION_RETRY:
+            # The broker hasn't noticed the previous session dropping yet
+            sleep(0.5)
+        elif result == SESSION_RESUME_REJECTED:
+            # The session could not be resumed; start a new one
+            resumeToken = ''
+        else:
+            break
+
+
+def session(robot, name, stub, resumeToken):
+    """
+    Run the robot's session with the broker, resuming the session of a
+    previous run of the controller if resumeToken is set
+
+    Returns SESSION_RESUME_REJECTED or SESSION_RETRY if the session could not
+    be resumed, and SESSION_ENDED otherwise
+    """
     sendQueue = Queue(32)
     handshakeMsg = wb_controller_pb2.WbControllerMessage.ClientMessage()
     handshakeMsg.wb_controller_handshake.robot_name = name
-    # TODO: fill robot_info
+    handshakeMsg.wb_controller_handshake.robot_info.CopyFrom(
+        gatherRobotInfo(robot))
+    handshakeMsg.wb_controller_handshake.resume_token = resumeToken
+    handshakeMsg.wb_controller_handshake.arena = ARENA
     sendQueue.put(handshakeMsg)
     timestep = float('NaN')
     isIdle = True
@@ -191,14 +343,24 @@ def main():
             if timestep != timestep:
                 # handshake response not received (timestep is nan)
                 if serverMsg.HasField('wb_controller_handshake_response'):
-                    if serverMsg.wb_controller_handshake_response \
-                            .HasField('error'):
+                    res = serverMsg.wb_controller_handshake_response
+                    if res.HasField('error'):
+                        if resumeToken:
+                            print('Could not resume session: {}'.format(
+                                res.error))
+                            sendQueue.put(None)
+                            if res.error == SESSION_ACTIVE:
+                                return SESSION_RETRY
+                            return SESSION_RESUME_REJECTED
                         raise RuntimeError('Failed to handshake: {}'.format(
-                            serverMsg.wb_controller_handshake_response.error
+                            res.error
                         ))
-                    print('Robot connected to broker')
-                    timestep = \
-                        serverMsg.wb_controller_handshake_response.ok.timestep
+                    if res.ok.resumed:
+                        print('Robot resumed session with broker')
+                    else:
+                        print('Robot connected to broker')
+                    writeResumeToken(name, res.ok.resume_token)
+                    timestep = res.ok.timestep
                     isIdle = True
                     ticker = WbtIdleTicker(robot, doneFunc=cancel)
                     ticker.start()
@@ -209,6 +371,9 @@ def main():
                         wb_controller_pb2.WbControllerMessage.ClientMessage()
                     pong.pong.nonce = nonce
                     sendQueue.put(pong)
+                if serverMsg.HasField('server_shutting_down'):
+                    print('Broker shutting down: {}'.format(
+                        serverMsg.server_shutting_down.reason))
                 if isIdle:
                     if serverMsg.HasField('wb_controller_bound'):
                         ticker.isRunning = False
@@ -232,6 +397,9 @@ def main():
                         isIdle = True
                         ticker = WbtIdleTicker(robot, doneFunc=cancel)
                         ticker.start()
+                    if serverMsg.HasField('sensor_sampling_periods'):
+                        ticker.setSamplingPeriods(
+                            serverMsg.sensor_sampling_periods)
                     if serverMsg.HasField('commands'):
                         applyCommands(robot, serverMsg.commands)
                         if syncChannel is not None:
@@ -240,6 +408,7 @@ def main():
         if ticker is not None:
             ticker.isRunning = False
             ticker.join()
+    return SESSION_ENDED
 
 
 main()
@@ -0,0 +1,26 @@
+"""
+Channels to the broker, shared by the Erebus controllers and the game's
+supervisor so that they connect the same way
+"""
+
+from os import environ
+import grpc
+
+
+def brokerChannel(address):
+    """
+    Open a channel to the broker, over TLS if EREBUS_TLS_CA or EREBUS_TLS_CERT
+    is set
+    """
+    tlsFiles = [environ.get(var) for var in
+                ['EREBUS_TLS_CA', 'EREBUS_TLS_KEY', 'EREBUS_TLS_CERT']]
+    if tlsFiles[0] is None and tlsFiles[2] is None:
+        return grpc.insecure_channel(address)
+    contents = []
+    for tlsFile in tlsFiles:
+        if tlsFile is None:
+            contents.append(None)
+        else:
+            with open(tlsFile, 'rb') as f:
+                contents.append(f.read())
+    return grpc.secure_channel(address, grpc.ssl_channel_credentials(*contents))
@@ -1,6 +1,6 @@
 from controller import Supervisor
 from threading import Thread, Condition
-from os import path
+from os import path, environ
 import sys
 import subprocess
 import platform
@@ -9,6 +9,7 @@ import sim_pb2
 import types_pb2
 import control_pb2_grpc
 import time
+from broker_channel import brokerChannel
 
 
 # Set to address of an already-running broker to use it instead of starting a
@@ -19,6 +20,16 @@ EXTERN_BROKER_ADDRESS = None
 # is set)
 BROKER_PORT = 51512
 
+# Connections to an external broker using TLS are configured by the
+# EREBUS_TLS_CA, EREBUS_TLS_CERT and EREBUS_TLS_KEY environment variables
+
+# Token for the broker's Control service, if it requires one (the broker
+# started by this controller requires it if EREBUS_ADMIN_TOKEN is set)
+ADMIN_TOKEN = environ.get('EREBUS_ADMIN_TOKEN', '')
+
+# Arena this world runs in, if the external broker runs several
+ARENA = environ.get('EREBUS_ARENA', '')
+
 # Webots run mode to use when simulation is in "running" state
 RUN_MODE = Supervisor.SIMULATION_MODE_REAL_TIME
 
@@ -136,15 +147,21 @@ class SimStateHandler(Thread):
         super().__init__(*args, **kwargs)
         self.supervisor = supervisor
         self.stub = stub
+        self.metadata = [('authorization', 'Bearer ' + ADMIN_TOKEN)] \
+            if ADMIN_TOKEN else []
+        if ARENA:
+            self.metadata.append(('arena', ARENA))
         self.call = self.stub.SubscribeSimulationState(
                 types_pb2.Null(),
-                wait_for_ready=True
+                wait_for_ready=True,
+                metadata=self.metadata
         )
 
     def run(self):
         setSimulationState(
             self.supervisor,
-            self.stub.GetSimulationState(types_pb2.Null(), wait_for_ready=True)
+            self.stub.GetSimulationState(types_pb2.Null(), wait_for_ready=True,
+                                         metadata=self.metadata)
         )
         for simState in self.call:
             setSimulationState(self.supervisor, simState)
@@ -166,7 +183,9 @@ def main():
     else:
         brokerProcess = None
     try:
-        channel = grpc.insecure_channel(brokerAddress)
+        channel = brokerChannel(brokerAddress) \
+            if EXTERN_BROKER_ADDRESS is not None \
+            else grpc.insecure_channel(brokerAddress)
         stub = control_pb2_grpc.ControlStub(channel)
         simStateHandler = SimStateHandler(stub=stub, supervisor=supervisor)
         simStateHandler.start()